@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -41,6 +43,10 @@ import (
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clibuildinfo "github.com/jfrog/jfrog-cli/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/builds"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/licenses"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/nugetlock"
 	"github.com/jfrog/jfrog-cli/buildtools"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildadddependencies"
@@ -50,9 +56,13 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddockercreate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildlicenses"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildslist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildsquery"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildverifysignature"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
@@ -113,10 +123,13 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/imageindex"
+	"github.com/jfrog/jfrog-cli/utils/imagemetadata"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/jszwec/csvutil"
 	"github.com/urfave/cli"
@@ -243,6 +256,30 @@ func GetCommands() []cli.Command {
 			Action:       buildPublishCmd,
 			Category:     buildCategory,
 		},
+		{
+			Name:         "build-verify-signature",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildVerifySignature),
+			Aliases:      []string{"bvs"},
+			Usage:        buildverifysignature.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-verify-signature", buildverifysignature.GetDescription(), buildverifysignature.Usage),
+			UsageText:    buildverifysignature.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildVerifySignatureCmd,
+			Category:     buildCategory,
+		},
+		{
+			Name:         "build-licenses",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildLicenses),
+			Aliases:      []string{"bli"},
+			Usage:        buildlicenses.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-licenses", buildlicenses.GetDescription(), buildlicenses.Usage),
+			UsageText:    buildlicenses.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildLicensesCmd,
+			Category:     buildCategory,
+		},
 		{
 			Name:         "build-collect-env",
 			Aliases:      []string{"bce"},
@@ -340,6 +377,31 @@ func GetCommands() []cli.Command {
 			Action:       buildDiscardCmd,
 			Category:     buildCategory,
 		},
+		{
+			Name:     "builds",
+			Usage:    "Browse the published builds.",
+			Category: buildCategory,
+			Subcommands: []cli.Command{
+				{
+					Name:         "list",
+					Flags:        cliutils.GetCommandFlags(cliutils.BuildsList),
+					Usage:        buildslist.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt builds list", buildslist.GetDescription(), buildslist.Usage),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action:       buildsListCmd,
+				},
+				{
+					Name:         "query",
+					Flags:        cliutils.GetCommandFlags(cliutils.BuildsQuery),
+					Usage:        buildsquery.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt builds query", buildsquery.GetDescription(), buildsquery.Usage),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action:       buildsQueryCmd,
+				},
+			},
+		},
 		{
 			Name:         "git-lfs-clean",
 			Flags:        cliutils.GetCommandFlags(cliutils.GitLfsClean),
@@ -610,9 +672,11 @@ func GetCommands() []cli.Command {
 		},
 		{
 			Name:         "nuget-deps-tree",
+			Flags:        cliutils.GetCommandFlags(cliutils.NugetDepsTree),
 			Aliases:      []string{"ndt"},
 			Usage:        nugettree.GetDescription(),
 			HelpName:     corecommon.CreateUsage("rt nuget-deps-tree", nugettree.GetDescription(), nugettree.Usage),
+			UsageText:    nugettree.GetArguments(),
 			ArgsUsage:    common.CreateEnvVars(),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       nugetDepsTreeCmd,
@@ -1065,10 +1129,20 @@ func dockerPromoteCmd(c *cli.Context) error {
 	params.SourceTag = c.String("source-tag")
 	params.TargetTag = c.String("target-tag")
 	params.Copy = c.Bool("copy")
+	// The manifests of a multi-platform image are promoted along with its tag. They're found before the promotion, which may move the tag.
+	var manifestFolders []string
+	if params.SourceTag != "" {
+		if manifestFolders, err = imageindex.GetManifestFolders(artDetails, params.SourceRepo, path.Join(params.SourceDockerImage, params.SourceTag)); err != nil {
+			return err
+		}
+	}
 	dockerPromoteCommand := container.NewDockerPromoteCommand()
 	dockerPromoteCommand.SetParams(params).SetServerDetails(artDetails)
 
-	return commands.Exec(dockerPromoteCommand)
+	if err = commands.Exec(dockerPromoteCommand); err != nil {
+		return err
+	}
+	return imageindex.PromoteManifests(artDetails, params, manifestFolders)
 }
 
 func containerPushCmd(c *cli.Context, containerManagerType containerutils.ContainerManagerType) (err error) {
@@ -1140,16 +1214,46 @@ func BuildDockerCreateCmd(c *cli.Context) error {
 		return err
 	}
 	sourceRepo := c.Args().Get(0)
-	imageNameWithDigestFile := c.String("image-file")
-	if imageNameWithDigestFile == "" {
+	imageFile := c.String("image-file")
+	if imageFile == "" {
 		return cliutils.PrintHelpAndReturnError("The '--image-file' command option was not provided.", c)
 	}
 	buildConfiguration, err := cliutils.CreateBuildConfigurationWithModule(c)
 	if err != nil {
 		return err
 	}
+	// The image file may be the metadata of buildx, Kaniko, Buildah or ko, which may include several images.
+	images, err := imagemetadata.ReadFile(imageFile, c.String("image-name"))
+	if err != nil {
+		return err
+	}
+	if err = imagemetadata.ValidateDigests(artDetails, sourceRepo, images); err != nil {
+		return err
+	}
+	for _, image := range images {
+		if err = buildDockerCreate(artDetails, buildConfiguration, sourceRepo, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildDockerCreate(artDetails *coreConfig.ServerDetails, buildConfiguration *build.BuildConfiguration, sourceRepo string, image imagemetadata.Image) (err error) {
+	log.Info("Adding the image", image.String(), "to the build-info")
+	// The build-docker-create command reads the image from a file in the <image>@sha256:<digest> form.
+	imageNameWithDigestFile, err := fileutils.CreateTempFile()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Remove(imageNameWithDigestFile.Name())))
+	}()
+	_, err = imageNameWithDigestFile.WriteString(image.String())
+	if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(imageNameWithDigestFile.Close())); err != nil {
+		return err
+	}
 	buildDockerCreateCommand := container.NewBuildDockerCreateCommand()
-	if err = buildDockerCreateCommand.SetImageNameWithDigest(imageNameWithDigestFile); err != nil {
+	if err = buildDockerCreateCommand.SetImageNameWithDigest(imageNameWithDigestFile.Name()); err != nil {
 		return err
 	}
 	buildDockerCreateCommand.SetRepo(sourceRepo).SetServerDetails(artDetails).SetBuildConfiguration(buildConfiguration)
@@ -1207,8 +1311,26 @@ func nugetDepsTreeCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
-
-	return dotnet.DependencyTreeCmd()
+	if c.IsSet("format") && !slices.Contains(nugetlock.Formats, c.String("format")) {
+		return cliutils.PrintHelpAndReturnError(fmt.Sprintf("The --format option must be one of: %s.", strings.Join(nugetlock.Formats, ", ")), c)
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockFiles, err := nugetlock.FindLockFiles(workingDir)
+	if err != nil {
+		return err
+	}
+	// Without lock files, the tree is read from the assets files of the projects.
+	if len(lockFiles) == 0 && !c.IsSet("format") {
+		return dotnet.DependencyTreeCmd()
+	}
+	depsTreeCmd := nugetlock.NewDepsTreeCommand()
+	if c.IsSet("format") {
+		depsTreeCmd.SetFormat(c.String("format"))
+	}
+	return commands.Exec(depsTreeCmd)
 }
 
 func pingCmd(c *cli.Context) error {
@@ -1667,9 +1789,20 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	var buildSignCmd *clibuildinfo.BuildSignCommand
+	if c.String("sign-key") != "" && !buildInfoConfiguration.DryRun {
+		// The key is validated before publishing, so the build-info isn't published unsigned because of an invalid key.
+		buildSignCmd = clibuildinfo.NewBuildSignCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetKeyPath(c.String("sign-key"))
+		if err = buildSignCmd.LoadKey(); err != nil {
+			return err
+		}
+	}
 	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(cliutils.GetDetailedSummary(c))
 
 	err = commands.Exec(buildPublishCmd)
+	if err == nil && buildSignCmd != nil {
+		err = commands.Exec(buildSignCmd)
+	}
 	if buildPublishCmd.IsDetailedSummary() {
 		if summary := buildPublishCmd.GetSummary(); summary != nil {
 			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
@@ -1678,6 +1811,48 @@ func buildPublishCmd(c *cli.Context) error {
 	return err
 }
 
+func buildVerifySignatureCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if c.String("key") == "" {
+		return cliutils.PrintHelpAndReturnError("The --key option is mandatory.", c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildVerifySignatureCmd := clibuildinfo.NewBuildVerifySignatureCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetKeyPath(c.String("key"))
+	return commands.Exec(buildVerifySignatureCmd)
+}
+
+func buildLicensesCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	buildLicensesCmd := licenses.NewBuildLicensesCommand().SetBuildConfiguration(buildConfiguration).SetFromRt(c.Bool("from-rt")).
+		SetPolicyPath(c.String("policy")).SetFailOnViolation(c.BoolT("fail"))
+	if c.String("format") != "" {
+		buildLicensesCmd.SetFormat(c.String("format"))
+	}
+	if c.Bool("from-rt") {
+		rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+		if err != nil {
+			return err
+		}
+		buildLicensesCmd.SetServerDetails(rtDetails)
+	}
+	return commands.Exec(buildLicensesCmd)
+}
+
 func buildAppendCmd(c *cli.Context) error {
 	if c.NArg() != 4 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -1759,13 +1934,43 @@ func buildAddGitCmd(c *cli.Context) error {
 		return err
 	}
 
-	buildAddGitConfigurationCmd := buildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id"))
+	var dotGitPath string
 	if c.NArg() == 3 {
-		buildAddGitConfigurationCmd.SetDotGitPath(c.Args().Get(2))
+		dotGitPath = c.Args().Get(2)
 	} else if c.NArg() == 1 {
-		buildAddGitConfigurationCmd.SetDotGitPath(c.Args().Get(0))
+		dotGitPath = c.Args().Get(0)
+	}
+	buildAddGitConfigurationCmd, err := createBuildAddGitCmd(c, buildConfiguration, dotGitPath)
+	if err != nil {
+		return err
+	}
+	if err = commands.Exec(buildAddGitConfigurationCmd); err != nil {
+		return err
+	}
+	if !c.Bool("pr-metadata") && !c.Bool("changed-files") {
+		return nil
+	}
+	buildAddVcsMetadataCmd := clibuildinfo.NewBuildAddVcsMetadataCommand().SetBuildConfiguration(buildConfiguration).SetServerId(c.String("server-id")).SetDotGitPath(dotGitPath).
+		SetCollectPullRequest(c.Bool("pr-metadata")).SetCollectChangedFiles(c.Bool("changed-files"))
+	return commands.Exec(buildAddVcsMetadataCmd)
+}
+
+// Returns the command collecting the VCS details from the .git directory.
+// If no .git directory is found, the revision and branch detected from the CI provider are collected instead, when the detection is enabled.
+func createBuildAddGitCmd(c *cli.Context, buildConfiguration *build.BuildConfiguration, dotGitPath string) (commands.Command, error) {
+	buildAddGitCmd := buildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id")).SetDotGitPath(dotGitPath)
+	if dotGitPath != "" {
+		return buildAddGitCmd, nil
+	}
+	ciBuild := cliutils.DetectCiBuild()
+	if ciBuild == nil || ciBuild.VcsRevision == "" {
+		return buildAddGitCmd, nil
+	}
+	_, exists, err := fileutils.FindUpstream(".git", fileutils.Any)
+	if err != nil || exists {
+		return buildAddGitCmd, err
 	}
-	return commands.Exec(buildAddGitConfigurationCmd)
+	return clibuildinfo.NewBuildAddCiVcsCommand().SetBuildConfiguration(buildConfiguration).SetCiBuild(ciBuild).SetServerId(c.String("server-id")), nil
 }
 
 func buildScanLegacyCmd(c *cli.Context) error {
@@ -1827,6 +2032,41 @@ func buildPromoteCmd(c *cli.Context) error {
 	return commands.Exec(buildPromotionCmd)
 }
 
+func buildsListCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	limit, err := cliutils.GetIntFlagValue(c, "limit", builds.DefaultLimit)
+	if err != nil {
+		return err
+	}
+	buildsListCmd := builds.NewBuildsListCommand().SetServerDetails(rtDetails).SetNamePattern(c.String("name")).SetSince(c.String("since")).
+		SetProject(cliutils.GetProject(c)).SetLimit(limit).SetFormat(c.String("format"))
+	return commands.Exec(buildsListCmd)
+}
+
+func buildsQueryCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	limit, err := cliutils.GetIntFlagValue(c, "limit", builds.DefaultLimit)
+	if err != nil {
+		return err
+	}
+	buildsQueryCmd := builds.NewBuildsQueryCommand().SetServerDetails(rtDetails).SetNamePattern(c.String("name")).SetSince(c.String("since")).
+		SetProject(cliutils.GetProject(c)).SetLimit(limit).SetFormat(c.String("format")).SetProperty(c.String("property")).
+		SetPromotionStatus(c.String("status")).SetVcsRevision(c.String("vcs-revision")).SetSha256(c.String("sha256"))
+	return commands.Exec(buildsQueryCmd)
+}
+
 func buildDiscardCmd(c *cli.Context) error {
 	if c.NArg() > 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -0,0 +1,421 @@
+package bazel
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ToolName       = "bazel"
+	executableName = "bazel"
+
+	ModuleType buildinfo.ModuleType = "bazel"
+
+	buildEventsFlag   = "--build_event_json_file"
+	deployOutputsFlag = "--deploy-outputs"
+)
+
+// The Bazel commands which build targets, and report their downloads and outputs to the build event log.
+var buildCommands = []string{"build", "test", "run", "coverage"}
+
+// The files which mark the root of a Bazel workspace.
+var workspaceFiles = []string{"MODULE.bazel", "WORKSPACE.bazel", "WORKSPACE"}
+
+// Runs a Bazel command with a temporary bazelrc fragment, which configures Artifactory for Bazel:
+// The downloads of http_archive rules and Bzlmod are rewritten to the resolver repository, and the deployer repository is the remote cache.
+// The downloads of the build commands are collected from the build event log into the build-info dependencies,
+// and with the --deploy-outputs option, the outputs of the requested targets are deployed to the deployer repository as build-info artifacts.
+type BazelCommand struct {
+	cmdName            string
+	configFilePath     string
+	bazelArgs          []string
+	deployOutputs      bool
+	resolverDetails    *config.ServerDetails
+	resolverRepo       string
+	deployerDetails    *config.ServerDetails
+	deployerRepo       string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewBazelCommand() *BazelCommand {
+	return &BazelCommand{}
+}
+
+func (bc *BazelCommand) SetCmdName(cmdName string) *BazelCommand {
+	bc.cmdName = cmdName
+	return bc
+}
+
+func (bc *BazelCommand) SetConfigFilePath(configFilePath string) *BazelCommand {
+	bc.configFilePath = configFilePath
+	return bc
+}
+
+func (bc *BazelCommand) SetArgs(args []string) *BazelCommand {
+	bc.bazelArgs = args
+	return bc
+}
+
+func (bc *BazelCommand) CommandName() string {
+	return "rt_bazel_" + bc.cmdName
+}
+
+func (bc *BazelCommand) ServerDetails() (*config.ServerDetails, error) {
+	if bc.deployerDetails != nil {
+		return bc.deployerDetails, nil
+	}
+	return bc.resolverDetails, nil
+}
+
+// Reads the resolver and deployer configuration, and extracts the JFrog CLI options from the bazel arguments.
+func (bc *BazelCommand) Init() (err error) {
+	if bc.bazelArgs, bc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(bc.bazelArgs); err != nil {
+		return err
+	}
+	flagIndex, deployOutputs, err := coreutils.FindBooleanFlag(deployOutputsFlag, bc.bazelArgs)
+	if err != nil {
+		return err
+	}
+	coreutils.RemoveFlagFromCommand(&bc.bazelArgs, flagIndex, flagIndex)
+	bc.deployOutputs = deployOutputs
+	resolverConfig, err := projectconfig.GetRepoConfig(bc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig != nil {
+		if bc.resolverDetails, err = resolverConfig.ServerDetails(); err != nil {
+			return err
+		}
+		bc.resolverRepo = resolverConfig.TargetRepo()
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(bc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig != nil {
+		if bc.deployerDetails, err = deployerConfig.ServerDetails(); err != nil {
+			return err
+		}
+		bc.deployerRepo = deployerConfig.TargetRepo()
+	}
+	switch {
+	case resolverConfig == nil && deployerConfig == nil:
+		return errorutils.CheckErrorf("the resolver and deployer repositories are missing from the config file (%s). Please run 'jf bazel-config'", bc.configFilePath)
+	case bc.deployOutputs && deployerConfig == nil:
+		return errorutils.CheckErrorf("the %s option requires a deployer repository. Please run 'jf bazel-config' with the --repo-deploy option", deployOutputsFlag)
+	}
+	return nil
+}
+
+func (bc *BazelCommand) Run() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	collectBuildInfo, err := bc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	isBuild := isBuildCommand(bc.cmdName)
+	collectBuildInfo = collectBuildInfo && isBuild
+	buildEventsPath, options, env, err := bc.configure(tempDir, isBuild && (collectBuildInfo || bc.deployOutputs))
+	if err != nil {
+		return err
+	}
+	homeBazelrcPath, err := getHomeBazelrcPath()
+	if err != nil {
+		return err
+	}
+	bazelrcPath := filepath.Join(tempDir, bazelrcFileName)
+	if err = writeBazelrc(bazelrcPath, homeBazelrcPath, options); err != nil {
+		return err
+	}
+	args := append([]string{"--bazelrc=" + bazelrcPath, bc.cmdName}, bc.bazelArgs...)
+	if err = runBazel(env, args...); err != nil {
+		return err
+	}
+	if buildEventsPath == "" {
+		return nil
+	}
+	events, err := readBuildEvents(buildEventsPath)
+	if err != nil {
+		return err
+	}
+	workspaceDir, err := getWorkspaceDir()
+	if err != nil {
+		return err
+	}
+	moduleId := filepath.Base(workspaceDir)
+	if collectBuildInfo {
+		lockDownloads, err := readModuleLock(workspaceDir)
+		if err != nil {
+			return err
+		}
+		dependencies, err := bc.getDependencies(bc.mergeDownloads(events.downloads, lockDownloads))
+		if err != nil {
+			return err
+		}
+		if err = buildinfoutils.SaveDependencies(bc.buildConfiguration, moduleId, ModuleType, dependencies); err != nil {
+			return err
+		}
+	}
+	if !bc.deployOutputs {
+		return nil
+	}
+	return bc.deploy(events.outputs, moduleId, collectBuildInfo)
+}
+
+func isBuildCommand(cmdName string) bool {
+	for _, buildCommand := range buildCommands {
+		if cmdName == buildCommand {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the options of the bazelrc fragment, and the environment variables of Bazel.
+// If the build events are required, the path of the build event log is returned.
+// The build event log of the user is used if the user requested one.
+func (bc *BazelCommand) configure(tempDir string, buildEventsRequired bool) (buildEventsPath string, options []bazelrcOption, env []string, err error) {
+	if bc.resolverDetails != nil {
+		repoUrl := repositoryUrl(bc.resolverDetails, bc.resolverRepo)
+		var parsedUrl *url.URL
+		if parsedUrl, err = url.Parse(repoUrl); err != nil {
+			return "", nil, nil, errorutils.CheckError(err)
+		}
+		downloaderConfigPath := filepath.Join(tempDir, downloaderConfigFileName)
+		if err = writeDownloaderConfig(downloaderConfigPath, repoUrl, parsedUrl.Host); err != nil {
+			return
+		}
+		options = append(options, bazelrcOption{"common", "--experimental_downloader_config=" + downloaderConfigPath})
+		var userNetrcPath string
+		if userNetrcPath, err = getUserNetrcPath(); err != nil {
+			return
+		}
+		netrcPath := filepath.Join(tempDir, netrcFileName)
+		if err = writeNetrc(userNetrcPath, netrcPath, parsedUrl.Hostname(), bc.resolverDetails); err != nil {
+			return
+		}
+		env = append(env, "NETRC="+netrcPath)
+	}
+	if bc.deployerDetails != nil {
+		options = append(options, bazelrcOption{"build", "--remote_cache=" + repositoryUrl(bc.deployerDetails, bc.deployerRepo)})
+		var authorization string
+		if authorization, err = remoteCacheAuthorization(bc.deployerDetails); err != nil {
+			return
+		}
+		if authorization != "" {
+			options = append(options, bazelrcOption{"build", "--remote_header=Authorization=" + authorization})
+		}
+	}
+	if !buildEventsRequired {
+		return
+	}
+	_, _, buildEventsPath, err = coreutils.FindFlag(buildEventsFlag, bc.bazelArgs)
+	if err != nil || buildEventsPath != "" {
+		return
+	}
+	buildEventsPath = filepath.Join(tempDir, buildEventsFileName)
+	options = append(options, bazelrcOption{"build", buildEventsFlag + "=" + buildEventsPath})
+	return
+}
+
+// Adds the downloads of the lockfile to the downloads of the build event log, which lacks the downloads that were already in the repository cache.
+// The lockfile has the original URLs, which are rewritten to the resolver repository like the downloader rewrites them.
+func (bc *BazelCommand) mergeDownloads(eventDownloads, lockDownloads []download) []download {
+	downloads := slices.Clone(eventDownloads)
+	indexes := make(map[string]int)
+	for i, eventDownload := range downloads {
+		indexes[eventDownload.url] = i
+	}
+	for _, lockDownload := range lockDownloads {
+		lockDownload.url = bc.rewriteUrl(lockDownload.url)
+		if i, exists := indexes[lockDownload.url]; exists {
+			if downloads[i].sha256 == "" {
+				downloads[i].sha256 = lockDownload.sha256
+			}
+			continue
+		}
+		indexes[lockDownload.url] = len(downloads)
+		downloads = append(downloads, lockDownload)
+	}
+	return downloads
+}
+
+// Returns the URL which the downloader config rewrites the URL to, or the URL itself if it isn't rewritten.
+func (bc *BazelCommand) rewriteUrl(downloadUrl string) string {
+	if bc.resolverDetails == nil {
+		return downloadUrl
+	}
+	repoUrl := repositoryUrl(bc.resolverDetails, bc.resolverRepo)
+	parsedRepoUrl, err := url.Parse(repoUrl)
+	if err != nil {
+		return downloadUrl
+	}
+	_, withoutScheme, found := strings.Cut(downloadUrl, "://")
+	if !found || strings.HasPrefix(withoutScheme, parsedRepoUrl.Host+"/") {
+		return downloadUrl
+	}
+	return repoUrl + "/" + withoutScheme
+}
+
+// Returns the dependencies of the downloads. The downloads from the resolver repository are identified by their path in the repository,
+// and their checksums are read from Artifactory. Other downloads are identified by their URL, without the scheme.
+// The SHA-256 checksums of the lockfile are used for the downloads which aren't found in Artifactory.
+func (bc *BazelCommand) getDependencies(downloads []download) ([]buildinfo.Dependency, error) {
+	var repoUrl string
+	if bc.resolverDetails != nil {
+		repoUrl = repositoryUrl(bc.resolverDetails, bc.resolverRepo) + "/"
+	}
+	var dependencies []buildinfo.Dependency
+	var servicesManager artifactory.ArtifactoryServicesManager
+	for _, download := range downloads {
+		repoPath, fromRepo := strings.CutPrefix(download.url, repoUrl)
+		if repoUrl == "" || !fromRepo {
+			_, withoutScheme, _ := strings.Cut(download.url, "://")
+			dependencies = append(dependencies, buildinfo.Dependency{Id: withoutScheme, Type: getFileType(download.url), Checksum: buildinfo.Checksum{Sha256: download.sha256}})
+			continue
+		}
+		if servicesManager == nil {
+			var err error
+			if servicesManager, err = utils.CreateServiceManager(bc.resolverDetails, -1, 0, false); err != nil {
+				return nil, err
+			}
+		}
+		dependency, err := getRepoDependency(servicesManager, bc.resolverRepo, repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if dependency.Checksum.IsEmpty() {
+			dependency.Checksum.Sha256 = download.sha256
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// Returns the dependency of a file in the repository, with the checksums of the file if it's found in Artifactory.
+func getRepoDependency(servicesManager artifactory.ArtifactoryServicesManager, repo, repoPath string) (dependency buildinfo.Dependency, err error) {
+	dependency = buildinfo.Dependency{Id: repoPath, Type: getFileType(repoPath)}
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = repo + "/" + repoPath
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return dependency, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	item := new(specutils.ResultItem)
+	if reader.NextRecord(item) != nil {
+		log.Debug("The checksums of the dependency " + repo + "/" + repoPath + " were not found in Artifactory.")
+		return dependency, errorutils.CheckError(reader.GetError())
+	}
+	dependency.Checksum = item.ToDependency().Checksum
+	return dependency, nil
+}
+
+func getFileType(path string) string {
+	return strings.TrimPrefix(filepath.Ext(path), ".")
+}
+
+// Deploys the outputs to the deployer repository, under their paths in the output directory, and saves them as build-info artifacts.
+func (bc *BazelCommand) deploy(outputs []outputFile, moduleId string, collectBuildInfo bool) (err error) {
+	var uploadParams []services.UploadParams
+	for _, output := range outputs {
+		localPath := output.localPath()
+		if localPath == "" {
+			log.Warn("The output " + output.Name + " is not available locally, and therefore is not deployed.")
+			continue
+		}
+		up := services.NewUploadParams()
+		up.CommonParams = &specutils.CommonParams{Pattern: localPath, Target: bc.deployerRepo + "/" + output.Name}
+		up.Flat = true
+		if collectBuildInfo {
+			if up.BuildProps, err = build.CreateBuildPropsFromConfiguration(bc.buildConfiguration); err != nil {
+				return err
+			}
+		}
+		uploadParams = append(uploadParams, up)
+	}
+	if len(uploadParams) == 0 {
+		log.Info("No outputs were found to deploy.")
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(bc.deployerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close(), summary.TransferDetailsReader.Close())
+	}()
+	log.Info("Deployed", summary.TotalSucceeded, "outputs to", bc.deployerRepo)
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to deploy %d outputs to Artifactory. See Artifactory logs for more details", summary.TotalFailed)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveArtifacts(bc.buildConfiguration, moduleId, ModuleType, artifacts)
+}
+
+// Returns the directory of the Bazel workspace, which is the module, or the working directory if no workspace file is found.
+func getWorkspaceDir() (string, error) {
+	for _, workspaceFile := range workspaceFiles {
+		workspaceDir, exists, err := fileutils.FindUpstream(workspaceFile, fileutils.File)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return workspaceDir, nil
+		}
+	}
+	wd, err := os.Getwd()
+	return wd, errorutils.CheckError(err)
+}
+
+func runBazel(env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the bazel executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -0,0 +1,60 @@
+package bazel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("NETRC", filepath.Join(tempDir, "missing"))
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}
+	bazelCmd := &BazelCommand{resolverDetails: serverDetails, resolverRepo: "bazel-remote", deployerDetails: serverDetails, deployerRepo: "bazel-cache"}
+	buildEventsPath, options, env, err := bazelCmd.configure(tempDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tempDir, buildEventsFileName), buildEventsPath)
+	assert.Equal(t, []bazelrcOption{
+		{"common", "--experimental_downloader_config=" + filepath.Join(tempDir, downloaderConfigFileName)},
+		{"build", "--remote_cache=https://acme.jfrog.io/artifactory/bazel-cache"},
+		{"build", "--remote_header=Authorization=Bearer token"},
+		{"build", "--build_event_json_file=" + buildEventsPath},
+	}, options)
+	assert.Equal(t, []string{"NETRC=" + filepath.Join(tempDir, netrcFileName)}, env)
+
+	// The build event log of the user is read instead.
+	bazelCmd = &BazelCommand{deployerDetails: serverDetails, deployerRepo: "bazel-cache", bazelArgs: []string{"//...", "--build_event_json_file=events.json"}}
+	buildEventsPath, options, env, err = bazelCmd.configure(tempDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, "events.json", buildEventsPath)
+	assert.Len(t, options, 2)
+	assert.Empty(t, env)
+}
+
+func TestGetDependenciesOfOtherHosts(t *testing.T) {
+	dependencies, err := (&BazelCommand{}).getDependencies([]download{{url: "https://github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip", sha256: "f4a9314518ca6acfa16cc4ab43b0b8ce1e4ea64b81c38d8a3772883f153346b8"}})
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	assert.Equal(t, "github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip", dependencies[0].Id)
+	assert.Equal(t, "zip", dependencies[0].Type)
+	assert.Equal(t, "f4a9314518ca6acfa16cc4ab43b0b8ce1e4ea64b81c38d8a3772883f153346b8", dependencies[0].Sha256)
+}
+
+func TestMergeDownloads(t *testing.T) {
+	bazelCmd := &BazelCommand{resolverDetails: &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, resolverRepo: "bazel-remote"}
+	downloads := bazelCmd.mergeDownloads(
+		[]download{{url: "https://acme.jfrog.io/artifactory/bazel-remote/bcr.bazel.build/modules/rules_go/0.50.1/source.json"}},
+		[]download{
+			{url: "https://bcr.bazel.build/modules/rules_go/0.50.1/source.json", sha256: "a1b2"},
+			{url: "https://github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip", sha256: "c3d4"},
+			{url: "https://acme.jfrog.io/artifactory/generic-local/tools.zip", sha256: "e5f6"},
+		})
+	assert.Equal(t, []download{
+		{url: "https://acme.jfrog.io/artifactory/bazel-remote/bcr.bazel.build/modules/rules_go/0.50.1/source.json", sha256: "a1b2"},
+		{url: "https://acme.jfrog.io/artifactory/bazel-remote/github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip", sha256: "c3d4"},
+		{url: "https://acme.jfrog.io/artifactory/generic-local/tools.zip", sha256: "e5f6"},
+	}, downloads)
+}
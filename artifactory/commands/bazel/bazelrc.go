@@ -0,0 +1,122 @@
+package bazel
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	bazelrcFileName          = "jfrog.bazelrc"
+	downloaderConfigFileName = "downloader.cfg"
+	netrcFileName            = "netrc"
+	buildEventsFileName      = "build_events.json"
+)
+
+// Returns the URL of an Artifactory repository, which serves its files under their paths.
+func repositoryUrl(serverDetails *config.ServerDetails, repo string) string {
+	return strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/" + repo
+}
+
+// Writes the downloader configuration, which rewrites the downloads of Bazel from every host except Artifactory to the repository.
+// The downloader matches the URLs without their scheme, so that https://github.com/<path> is downloaded from <repository>/github.com/<path>.
+// All the hosts are therefore resolved from the same repository, which must serve the files of each host under <host>/<path>.
+// The downloads from Artifactory itself aren't rewritten.
+func writeDownloaderConfig(configPath, repoUrl, artifactoryHost string) error {
+	content := fmt.Sprintf("rewrite ((?!%s/).*) %s/$1\n", regexp.QuoteMeta(artifactoryHost), repoUrl)
+	return errorutils.CheckError(os.WriteFile(configPath, []byte(content), 0644))
+}
+
+// Writes a netrc file with the credentials of the server, followed by the netrc file of the user.
+// Bazel reads the credentials of the rewritten downloads from the file in the NETRC environment variable.
+func writeNetrc(userNetrcPath, netrcPath, host string, serverDetails *config.ServerDetails) error {
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil {
+		return err
+	}
+	var content strings.Builder
+	if password != "" {
+		content.WriteString(fmt.Sprintf("machine %s\nlogin %s\npassword %s\n", host, username, password))
+	}
+	userNetrc, err := os.ReadFile(userNetrcPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	content.Write(userNetrc)
+	return errorutils.CheckError(os.WriteFile(netrcPath, []byte(content.String()), 0600))
+}
+
+// Returns the path of the netrc file of the user.
+func getUserNetrcPath() (string, error) {
+	if netrcPath := os.Getenv("NETRC"); netrcPath != "" {
+		return netrcPath, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".netrc"), nil
+}
+
+// Returns the path of the home bazelrc file of the user, which Bazel reads unless the --bazelrc startup option is set.
+func getHomeBazelrcPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".bazelrc"), nil
+}
+
+// Returns the value of the Authorization header of the remote cache.
+func remoteCacheAuthorization(serverDetails *config.ServerDetails) (string, error) {
+	if serverDetails.GetAccessToken() != "" && serverDetails.GetPassword() == "" {
+		return "Bearer " + serverDetails.GetAccessToken(), nil
+	}
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil || password == "" {
+		return "", err
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+}
+
+// An option of a Bazel command in a bazelrc file.
+type bazelrcOption struct {
+	command string
+	option  string
+}
+
+// Writes the bazelrc fragment, which is passed to Bazel with the --bazelrc startup option.
+// The option replaces the home bazelrc of the user, so the fragment imports it first, if it exists, and its options are then overridden by ours.
+// The system and workspace bazelrc files are still read by Bazel.
+// The options are quoted, since the values of headers include spaces.
+func writeBazelrc(bazelrcPath, homeBazelrcPath string, options []bazelrcOption) (err error) {
+	file, err := os.OpenFile(bazelrcPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	writer := bufio.NewWriter(file)
+	if homeBazelrcPath != "" {
+		if _, err = writer.WriteString("try-import '" + filepath.ToSlash(homeBazelrcPath) + "'\n"); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	for _, option := range options {
+		if _, err = writer.WriteString(option.command + " '" + option.option + "'\n"); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return errorutils.CheckError(writer.Flush())
+}
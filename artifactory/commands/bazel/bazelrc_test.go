@@ -0,0 +1,60 @@
+package bazel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBazelrc(t *testing.T) {
+	bazelrcPath := filepath.Join(t.TempDir(), bazelrcFileName)
+	require.NoError(t, writeBazelrc(bazelrcPath, "/home/user/.bazelrc", []bazelrcOption{
+		{"build", "--remote_cache=https://acme.jfrog.io/artifactory/bazel-cache"},
+		{"build", "--remote_header=Authorization=Bearer token"},
+	}))
+	content, err := os.ReadFile(bazelrcPath)
+	require.NoError(t, err)
+	// The home bazelrc, which --bazelrc replaces, is imported before the options which override it.
+	assert.Equal(t, "try-import '/home/user/.bazelrc'\nbuild '--remote_cache=https://acme.jfrog.io/artifactory/bazel-cache'\nbuild '--remote_header=Authorization=Bearer token'\n", string(content))
+}
+
+func TestWriteDownloaderConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), downloaderConfigFileName)
+	require.NoError(t, writeDownloaderConfig(configPath, "https://acme.jfrog.io/artifactory/bazel-remote", "acme.jfrog.io"))
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "rewrite ((?!acme\\.jfrog\\.io/).*) https://acme.jfrog.io/artifactory/bazel-remote/$1\n", string(content))
+}
+
+func TestWriteNetrc(t *testing.T) {
+	tempDir := t.TempDir()
+	userNetrcPath := filepath.Join(tempDir, ".netrc")
+	require.NoError(t, os.WriteFile(userNetrcPath, []byte("machine github.com\nlogin octocat\npassword secret\n"), 0600))
+	netrcPath := filepath.Join(tempDir, netrcFileName)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "pass"}
+	require.NoError(t, writeNetrc(userNetrcPath, netrcPath, "acme.jfrog.io", serverDetails))
+	content, err := os.ReadFile(netrcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "machine acme.jfrog.io\nlogin user\npassword pass\nmachine github.com\nlogin octocat\npassword secret\n", string(content))
+
+	// A missing netrc file of the user is allowed.
+	require.NoError(t, writeNetrc(filepath.Join(tempDir, "missing"), netrcPath, "acme.jfrog.io", serverDetails))
+}
+
+func TestRemoteCacheAuthorization(t *testing.T) {
+	authorization, err := remoteCacheAuthorization(&config.ServerDetails{AccessToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", authorization)
+
+	authorization, err = remoteCacheAuthorization(&config.ServerDetails{User: "user", Password: "pass"})
+	require.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", authorization)
+
+	authorization, err = remoteCacheAuthorization(&config.ServerDetails{})
+	require.NoError(t, err)
+	assert.Empty(t, authorization)
+}
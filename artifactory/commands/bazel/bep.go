@@ -0,0 +1,84 @@
+package bazel
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// An event of the Build Event Protocol, as written to the --build_event_json_file file.
+// Only the events which are used by the command are parsed.
+type buildEvent struct {
+	Id struct {
+		Fetch *struct {
+			Url string `json:"url"`
+		} `json:"fetch"`
+	} `json:"id"`
+	Fetch *struct {
+		Success bool `json:"success"`
+	} `json:"fetch"`
+	NamedSetOfFiles *struct {
+		Files []outputFile `json:"files"`
+	} `json:"namedSetOfFiles"`
+}
+
+// An output of the requested targets. The name is relative to the output directory of the configuration, such as bazel-out/k8-fastbuild/bin.
+type outputFile struct {
+	Name string `json:"name"`
+	Uri  string `json:"uri"`
+}
+
+// The downloads and the outputs reported by a Bazel command.
+type buildEvents struct {
+	downloads []download
+	outputs   []outputFile
+}
+
+// Reads the downloaded URLs, and the outputs of the requested targets, from the build event log.
+func readBuildEvents(path string) (*buildEvents, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	events := new(buildEvents)
+	downloaded := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	// The events of large builds may exceed the default maximum token size of the scanner.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		event := new(buildEvent)
+		if err = json.Unmarshal(scanner.Bytes(), event); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the build event log %s: %s", path, err.Error())
+		}
+		switch {
+		case event.Id.Fetch != nil:
+			if event.Fetch != nil && event.Fetch.Success && !downloaded[event.Id.Fetch.Url] {
+				downloaded[event.Id.Fetch.Url] = true
+				events.downloads = append(events.downloads, download{url: event.Id.Fetch.Url})
+			}
+		case event.NamedSetOfFiles != nil:
+			events.outputs = append(events.outputs, event.NamedSetOfFiles.Files...)
+		}
+	}
+	return events, errorutils.CheckError(scanner.Err())
+}
+
+// Returns the local path of an output, or an empty string if the output isn't a local file, such as an output which was only uploaded to the remote cache.
+func (of *outputFile) localPath() string {
+	parsedUri, err := url.Parse(of.Uri)
+	if err != nil || parsedUri.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(parsedUri.Path)
+}
@@ -0,0 +1,33 @@
+package bazel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBuildEvents = `{"id":{"started":{}},"started":{"uuid":"5b0c6a8e","command":"build"}}
+{"id":{"fetch":{"url":"https://acme.jfrog.io/artifactory/bazel-remote/github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip"}},"fetch":{"success":true}}
+{"id":{"fetch":{"url":"https://acme.jfrog.io/artifactory/bazel-remote/github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip"}},"fetch":{"success":true}}
+{"id":{"fetch":{"url":"https://acme.jfrog.io/artifactory/bazel-remote/bcr.bazel.build/missing.json"}},"fetch":{}}
+
+{"id":{"namedSet":{"id":"0"}},"namedSetOfFiles":{"files":[{"name":"app/server","uri":"file:///home/user/.cache/bazel/execroot/_main/bazel-out/k8-fastbuild/bin/app/server","pathPrefix":["bazel-out","k8-fastbuild","bin"]},{"name":"app/server.runfiles","uri":"bytestream://acme.jfrog.io/blobs/0a1b/12"}]}}
+`
+
+func TestReadBuildEvents(t *testing.T) {
+	buildEventsPath := filepath.Join(t.TempDir(), buildEventsFileName)
+	require.NoError(t, os.WriteFile(buildEventsPath, []byte(testBuildEvents), 0644))
+	events, err := readBuildEvents(buildEventsPath)
+	require.NoError(t, err)
+
+	// Duplicate and failed downloads are skipped.
+	assert.Equal(t, []download{{url: "https://acme.jfrog.io/artifactory/bazel-remote/github.com/bazelbuild/rules_go/releases/download/v0.50.1/rules_go-v0.50.1.zip"}}, events.downloads)
+	require.Len(t, events.outputs, 2)
+	assert.Equal(t, "app/server", events.outputs[0].Name)
+	assert.Equal(t, filepath.FromSlash("/home/user/.cache/bazel/execroot/_main/bazel-out/k8-fastbuild/bin/app/server"), events.outputs[0].localPath())
+	// Outputs which are only in the remote cache aren't local.
+	assert.Empty(t, events.outputs[1].localPath())
+}
@@ -0,0 +1,101 @@
+package bazel
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	moduleLockFileName = "MODULE.bazel.lock"
+	integrityPrefix    = "sha256-"
+)
+
+// A download of a Bazel command, with its SHA-256 checksum if it's known.
+type download struct {
+	url    string
+	sha256 string
+}
+
+// Reads the downloads of the workspace from the Bzlmod lockfile. Unlike the fetch events of the build event log,
+// the lockfile lists the downloads of the workspace even when they are already in the repository cache.
+// The lockfile includes the registry files, and the archives of the repositories which are generated by the module extensions.
+// Returns nil if the workspace has no lockfile.
+func readModuleLock(workspaceDir string) ([]download, error) {
+	lockPath := filepath.Join(workspaceDir, moduleLockFileName)
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Debug(moduleLockFileName, "was not found in", workspaceDir)
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var lock map[string]any
+	if err = json.Unmarshal(content, &lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", lockPath, err.Error())
+	}
+	var downloads []download
+	registryFileHashes, _ := lock["registryFileHashes"].(map[string]any)
+	for _, fileUrl := range slices.Sorted(maps.Keys(registryFileHashes)) {
+		// Files which aren't found in a registry have no hash.
+		if sha256, ok := registryFileHashes[fileUrl].(string); ok {
+			downloads = append(downloads, download{url: fileUrl, sha256: sha256})
+		}
+	}
+	return append(downloads, getRepoSpecDownloads(lock)...), nil
+}
+
+// Returns the downloads of the repository rules in the value, which are the attributes with a 'url' or 'urls' key.
+// Only the first URL of each repository is returned, since the rest are its mirrors.
+func getRepoSpecDownloads(value any) []download {
+	var downloads []download
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if downloadUrl := getFirstUrl(typedValue); downloadUrl != "" {
+			return []download{{url: downloadUrl, sha256: getSha256(typedValue)}}
+		}
+		for _, key := range slices.Sorted(maps.Keys(typedValue)) {
+			downloads = append(downloads, getRepoSpecDownloads(typedValue[key])...)
+		}
+	case []any:
+		for _, item := range typedValue {
+			downloads = append(downloads, getRepoSpecDownloads(item)...)
+		}
+	}
+	return downloads
+}
+
+func getFirstUrl(attributes map[string]any) string {
+	if urls, ok := attributes["urls"].([]any); ok && len(urls) > 0 {
+		downloadUrl, _ := urls[0].(string)
+		return downloadUrl
+	}
+	downloadUrl, _ := attributes["url"].(string)
+	return downloadUrl
+}
+
+// Returns the hex SHA-256 checksum of the attributes, from the 'sha256' attribute or from a SHA-256 subresource integrity.
+func getSha256(attributes map[string]any) string {
+	if sha256, ok := attributes["sha256"].(string); ok && sha256 != "" {
+		return sha256
+	}
+	integrity, _ := attributes["integrity"].(string)
+	encoded, found := strings.CutPrefix(integrity, integrityPrefix)
+	if !found {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(decoded)
+}
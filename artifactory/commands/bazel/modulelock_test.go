@@ -0,0 +1,60 @@
+package bazel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModuleLock = `{
+  "lockFileVersion": 13,
+  "registryFileHashes": {
+    "https://bcr.bazel.build/modules/rules_go/0.50.1/MODULE.bazel": "b9aa9bdd4ff4a2e3e0d8ebcb0ed8f5a03e2eb3d1c6ed8b1b0e3c1e8e5bf0a3b1",
+    "https://bcr.bazel.build/modules/rules_go/0.50.1/source.json": "205765fd30216c70321f84c9a967267684bdc74350af3f3c46c857d9f80a4fa2",
+    "https://bcr.bazel.build/modules/missing/1.0/MODULE.bazel": null
+  },
+  "moduleExtensions": {
+    "@@rules_go~//go:extensions.bzl%go_sdk": {
+      "general": {
+        "generatedRepoSpecs": {
+          "go_sdk": {
+            "bzlFile": "@@bazel_tools//tools/build_defs/repo:http.bzl",
+            "ruleClassName": "http_archive",
+            "attributes": {
+              "urls": ["https://dl.google.com/go/go1.23.1.linux-amd64.tar.gz", "https://mirror.acme.io/go1.23.1.linux-amd64.tar.gz"],
+              "sha256": "49bbb517cfa9eee677e1e7897f7cf9cfdbcf49e05f61984a2789136de359f9bd"
+            }
+          },
+          "gazelle_tools": {
+            "bzlFile": "@@bazel_tools//tools/build_defs/repo:http.bzl",
+            "ruleClassName": "http_file",
+            "attributes": {
+              "url": "https://github.com/bazelbuild/bazel-gazelle/releases/download/v0.39.1/gazelle.zip",
+              "integrity": "sha256-q6vv3+4ljYt/gwKeopUqpwtSGgZNbXNyjMTYcVxbnIs="
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestReadModuleLock(t *testing.T) {
+	workspaceDir := t.TempDir()
+	downloads, err := readModuleLock(workspaceDir)
+	require.NoError(t, err)
+	assert.Nil(t, downloads)
+
+	require.NoError(t, os.WriteFile(filepath.Join(workspaceDir, moduleLockFileName), []byte(testModuleLock), 0644))
+	downloads, err = readModuleLock(workspaceDir)
+	require.NoError(t, err)
+	assert.Equal(t, []download{
+		{url: "https://bcr.bazel.build/modules/rules_go/0.50.1/MODULE.bazel", sha256: "b9aa9bdd4ff4a2e3e0d8ebcb0ed8f5a03e2eb3d1c6ed8b1b0e3c1e8e5bf0a3b1"},
+		{url: "https://bcr.bazel.build/modules/rules_go/0.50.1/source.json", sha256: "205765fd30216c70321f84c9a967267684bdc74350af3f3c46c857d9f80a4fa2"},
+		{url: "https://github.com/bazelbuild/bazel-gazelle/releases/download/v0.39.1/gazelle.zip", sha256: "ababefdfee258d8b7f83029ea2952aa70b521a064d6d73728cc4d8715c5b9c8b"},
+		{url: "https://dl.google.com/go/go1.23.1.linux-amd64.tar.gz", sha256: "49bbb517cfa9eee677e1e7897f7cf9cfdbcf49e05f61984a2789136de359f9bd"},
+	}, downloads)
+}
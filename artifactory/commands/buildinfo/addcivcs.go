@@ -0,0 +1,69 @@
+package buildinfo
+
+import (
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	utilsconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/ci"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Adds the VCS revision and branch detected from the CI provider to the build-info.
+// Used by 'build-add-git' when the job has no .git directory, for example when the sources are fetched as an archive.
+type BuildAddCiVcsCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	ciBuild            *ci.BuildDetails
+	serverId           string
+}
+
+func NewBuildAddCiVcsCommand() *BuildAddCiVcsCommand {
+	return &BuildAddCiVcsCommand{}
+}
+
+func (bacv *BuildAddCiVcsCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildAddCiVcsCommand {
+	bacv.buildConfiguration = buildConfiguration
+	return bacv
+}
+
+func (bacv *BuildAddCiVcsCommand) SetCiBuild(ciBuild *ci.BuildDetails) *BuildAddCiVcsCommand {
+	bacv.ciBuild = ciBuild
+	return bacv
+}
+
+func (bacv *BuildAddCiVcsCommand) SetServerId(serverId string) *BuildAddCiVcsCommand {
+	bacv.serverId = serverId
+	return bacv
+}
+
+func (bacv *BuildAddCiVcsCommand) CommandName() string {
+	return "rt_build_add_ci_vcs"
+}
+
+func (bacv *BuildAddCiVcsCommand) ServerDetails() (*utilsconfig.ServerDetails, error) {
+	return utilsconfig.GetSpecificConfig(bacv.serverId, true, false)
+}
+
+func (bacv *BuildAddCiVcsCommand) Run() error {
+	buildName, err := bacv.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bacv.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, bacv.buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.VcsList = append(partial.VcsList, buildinfo.Vcs{
+			Revision: bacv.ciBuild.VcsRevision,
+			Branch:   bacv.ciBuild.VcsBranch,
+		})
+	}
+	if err = build.SavePartialBuildInfo(buildName, buildNumber, bacv.buildConfiguration.GetProject(), populateFunc); err != nil {
+		return err
+	}
+	log.Info("No .git directory was found. Collected revision " + bacv.ciBuild.VcsRevision + " detected from " + string(bacv.ciBuild.Provider) + ".")
+	return nil
+}
@@ -0,0 +1,27 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli/utils/ci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAddCiVcs(t *testing.T) {
+	buildName, buildNumber := "add-ci-vcs-test", "1"
+	require.NoError(t, build.RemoveBuildDir(buildName, buildNumber, ""))
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	buildConfiguration := build.NewBuildConfiguration(buildName, buildNumber, "", "")
+	ciBuild := &ci.BuildDetails{Provider: ci.GitLab, VcsRevision: "0123456789abcdef", VcsBranch: "main"}
+	require.NoError(t, NewBuildAddCiVcsCommand().SetBuildConfiguration(buildConfiguration).SetCiBuild(ciBuild).Run())
+
+	partials, err := build.ReadPartialBuildInfoFiles(buildName, buildNumber, "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, []buildinfo.Vcs{{Revision: "0123456789abcdef", Branch: "main"}}, partials[0].VcsList)
+}
@@ -0,0 +1,225 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	utilsconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/ci"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	artclientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Build-info properties holding the VCS metadata.
+const (
+	PrProviderProp     = "vcs.pr.provider"
+	PrNumberProp       = "vcs.pr.number"
+	PrSourceBranchProp = "vcs.pr.sourceBranch"
+	PrTargetBranchProp = "vcs.pr.targetBranch"
+	PrAuthorProp       = "vcs.pr.author"
+	ChangedFilesProp   = "vcs.changedFiles"
+	// Recorded only when the list of changed files is truncated.
+	ChangedFilesCountProp = "vcs.changedFiles.count"
+)
+
+// Keeps the changed files property, which is stored in the build-info env, to a reasonable size.
+const maxChangedFiles = 500
+
+// Adds pull request metadata and the list of changed files to the build-info.
+// Complements 'build-add-git', which collects the revision, branch, URL and issues.
+type BuildAddVcsMetadataCommand struct {
+	buildConfiguration  *build.BuildConfiguration
+	dotGitPath          string
+	serverId            string
+	collectPullRequest  bool
+	collectChangedFiles bool
+}
+
+func NewBuildAddVcsMetadataCommand() *BuildAddVcsMetadataCommand {
+	return &BuildAddVcsMetadataCommand{}
+}
+
+func (bavc *BuildAddVcsMetadataCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildAddVcsMetadataCommand {
+	bavc.buildConfiguration = buildConfiguration
+	return bavc
+}
+
+func (bavc *BuildAddVcsMetadataCommand) SetDotGitPath(dotGitPath string) *BuildAddVcsMetadataCommand {
+	bavc.dotGitPath = dotGitPath
+	return bavc
+}
+
+func (bavc *BuildAddVcsMetadataCommand) SetServerId(serverId string) *BuildAddVcsMetadataCommand {
+	bavc.serverId = serverId
+	return bavc
+}
+
+func (bavc *BuildAddVcsMetadataCommand) SetCollectPullRequest(collectPullRequest bool) *BuildAddVcsMetadataCommand {
+	bavc.collectPullRequest = collectPullRequest
+	return bavc
+}
+
+func (bavc *BuildAddVcsMetadataCommand) SetCollectChangedFiles(collectChangedFiles bool) *BuildAddVcsMetadataCommand {
+	bavc.collectChangedFiles = collectChangedFiles
+	return bavc
+}
+
+func (bavc *BuildAddVcsMetadataCommand) CommandName() string {
+	return "rt_build_add_vcs_metadata"
+}
+
+func (bavc *BuildAddVcsMetadataCommand) ServerDetails() (*utilsconfig.ServerDetails, error) {
+	return utilsconfig.GetSpecificConfig(bavc.serverId, true, false)
+}
+
+func (bavc *BuildAddVcsMetadataCommand) Run() error {
+	buildName, err := bavc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bavc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, bavc.buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+
+	props := make(map[string]string)
+	if bavc.collectPullRequest {
+		addPullRequestProps(ci.DetectPullRequest(), props)
+	}
+	if bavc.collectChangedFiles {
+		changedFiles, err := bavc.collectFilesChangedSinceLatestBuild(buildName)
+		if err != nil {
+			return err
+		}
+		addChangedFilesProps(changedFiles, props)
+	}
+	if len(props) == 0 {
+		return nil
+	}
+
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Env = props
+	}
+	if err = build.SavePartialBuildInfo(buildName, buildNumber, bavc.buildConfiguration.GetProject(), populateFunc); err != nil {
+		return err
+	}
+	log.Debug("Collected VCS metadata for", buildName+"/"+buildNumber+".")
+	return nil
+}
+
+func addPullRequestProps(pr *ci.PullRequest, props map[string]string) {
+	if pr == nil {
+		log.Info("No pull request was detected in the CI environment. Skipping pull request metadata collection.")
+		return
+	}
+	log.Info("Collected the metadata of pull request #" + pr.Number + " from " + string(pr.Provider) + ".")
+	props[PrProviderProp] = string(pr.Provider)
+	props[PrNumberProp] = pr.Number
+	for key, value := range map[string]string{PrSourceBranchProp: pr.SourceBranch, PrTargetBranchProp: pr.TargetBranch, PrAuthorProp: pr.Author} {
+		if value != "" {
+			props[key] = value
+		}
+	}
+}
+
+// Returns the files changed between the VCS revision recorded in the latest published build and HEAD.
+func (bavc *BuildAddVcsMetadataCommand) collectFilesChangedSinceLatestBuild(buildName string) ([]string, error) {
+	if bavc.dotGitPath == "" {
+		var exists bool
+		var err error
+		bavc.dotGitPath, exists, err = fileutils.FindUpstream(".git", fileutils.Any)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errorutils.CheckErrorf("Could not find .git")
+		}
+	}
+	gitManager := clientutils.NewGitManager(bavc.dotGitPath)
+	if err := gitManager.ReadConfig(); err != nil {
+		return nil, err
+	}
+	latestRevision, err := bavc.getLatestBuildVcsRevision(buildName, gitManager.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+	if latestRevision == "" {
+		log.Info("The latest published build of '" + buildName + "' has no VCS revision of " + gitManager.GetUrl() + ". Skipping changed files collection.")
+		return nil, nil
+	}
+	if latestRevision == gitManager.GetRevision() {
+		return nil, nil
+	}
+	log.Info("Collecting the files changed since revision " + latestRevision + "...")
+	return getChangedFiles(bavc.dotGitPath, latestRevision, gitManager.GetRevision())
+}
+
+func (bavc *BuildAddVcsMetadataCommand) getLatestBuildVcsRevision(buildName, vcsUrl string) (string, error) {
+	serverDetails, err := bavc.ServerDetails()
+	if err != nil {
+		return "", err
+	}
+	sm, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return "", err
+	}
+	buildInfoParams := services.BuildInfoParams{BuildName: buildName, BuildNumber: artclientutils.LatestBuildNumberKey, ProjectKey: bavc.buildConfiguration.GetProject()}
+	publishedBuildInfo, found, err := sm.GetBuildInfo(buildInfoParams)
+	if err != nil || !found {
+		return "", err
+	}
+	for _, vcs := range publishedBuildInfo.BuildInfo.VcsList {
+		if vcs.Url == vcsUrl {
+			return vcs.Revision, nil
+		}
+	}
+	return "", nil
+}
+
+func getChangedFiles(workingDir, fromRevision, toRevision string) ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", "diff", "--name-only", fromRevision, toRevision)
+	cmd.Dir = workingDir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// The revision may be missing from the local clone, for example after a squash or a shallow checkout.
+		log.Warn("Couldn't list the files changed since revision " + fromRevision + ": " + strings.TrimSpace(stderr.String()))
+		return nil, nil
+	}
+	return parseChangedFiles(stdout.String()), nil
+}
+
+func addChangedFilesProps(changedFiles []string, props map[string]string) {
+	if len(changedFiles) == 0 {
+		return
+	}
+	if len(changedFiles) > maxChangedFiles {
+		log.Warn(fmt.Sprintf("%d files changed since the latest build. Only the first %d are recorded in the build-info.", len(changedFiles), maxChangedFiles))
+		props[ChangedFilesCountProp] = strconv.Itoa(len(changedFiles))
+		changedFiles = changedFiles[:maxChangedFiles]
+	}
+	props[ChangedFilesProp] = strings.Join(changedFiles, ",")
+}
+
+func parseChangedFiles(gitDiffOutput string) (changedFiles []string) {
+	for _, line := range strings.Split(gitDiffOutput, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			changedFiles = append(changedFiles, line)
+		}
+	}
+	return
+}
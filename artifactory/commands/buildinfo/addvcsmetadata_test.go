@@ -0,0 +1,42 @@
+package buildinfo
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/ci"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChangedFiles(t *testing.T) {
+	assert.Empty(t, parseChangedFiles(""))
+	assert.Equal(t, []string{"go.mod", "utils/ci/pullrequest.go"}, parseChangedFiles("go.mod\nutils/ci/pullrequest.go\n\n"))
+}
+
+func TestAddPullRequestProps(t *testing.T) {
+	props := make(map[string]string)
+	addPullRequestProps(nil, props)
+	assert.Empty(t, props)
+
+	addPullRequestProps(&ci.PullRequest{Provider: ci.GitLab, Number: "7", SourceBranch: "feature"}, props)
+	assert.Equal(t, map[string]string{PrProviderProp: "GitLab CI", PrNumberProp: "7", PrSourceBranchProp: "feature"}, props)
+}
+
+func TestAddChangedFilesProps(t *testing.T) {
+	props := make(map[string]string)
+	addChangedFilesProps(nil, props)
+	assert.Empty(t, props)
+
+	addChangedFilesProps([]string{"go.mod", "go.sum"}, props)
+	assert.Equal(t, map[string]string{ChangedFilesProp: "go.mod,go.sum"}, props)
+
+	changedFiles := make([]string, maxChangedFiles+1)
+	for i := range changedFiles {
+		changedFiles[i] = "f"
+	}
+	props = make(map[string]string)
+	addChangedFilesProps(changedFiles, props)
+	assert.Equal(t, strconv.Itoa(maxChangedFiles+1), props[ChangedFilesCountProp])
+	assert.Len(t, strings.Split(props[ChangedFilesProp], ","), maxChangedFiles)
+}
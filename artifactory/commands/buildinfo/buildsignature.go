@@ -0,0 +1,257 @@
+package buildinfo
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Properties of the published build-info file, which hold its signature.
+const (
+	SignatureProp       = "build.signature"
+	SignatureTypeProp   = "build.signature.type"
+	SignatureKeyIdProp  = "build.signature.keyId"
+	SignatureSha256Prop = "build.signature.sha256"
+
+	// Passphrase of an encrypted GPG signing key.
+	SigningKeyPassphraseEnv = "JFROG_CLI_SIGNING_KEY_PASSPHRASE"
+)
+
+type SignatureType string
+
+const (
+	Ed25519Signature SignatureType = "ed25519"
+	EcdsaSignature   SignatureType = "ecdsa"
+	GpgSignature     SignatureType = "gpg"
+)
+
+const pgpArmorPrefix = "-----BEGIN PGP"
+
+// A private key loaded from a local file, used to sign the build-info.
+type signingKey struct {
+	signatureType SignatureType
+	keyId         string
+	ed25519Key    ed25519.PrivateKey
+	ecdsaKey      *ecdsa.PrivateKey
+	gpgEntity     *openpgp.Entity
+}
+
+// A public key loaded from a local file, used to verify the build-info signature.
+type verificationKey struct {
+	signatureType SignatureType
+	keyId         string
+	ed25519Key    ed25519.PublicKey
+	ecdsaKey      *ecdsa.PublicKey
+	gpgKeyRing    openpgp.EntityList
+}
+
+// Returns the canonical form of the build-info, which is signed and verified.
+// The build-info is serialized with sorted keys and without insignificant whitespace.
+func canonicalizeBuildInfo(bi *buildinfo.BuildInfo) ([]byte, error) {
+	content, err := json.Marshal(bi)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var generic interface{}
+	if err = decoder.Decode(&generic); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// Maps are serialized with sorted keys.
+	content, err = json.Marshal(generic)
+	return content, errorutils.CheckError(err)
+}
+
+func loadSigningKey(keyPath string) (*signingKey, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(content)), pgpArmorPrefix) {
+		return loadGpgSigningKey(content)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("the signing key at %s should be a PEM encoded Ed25519 or ECDSA private key, or an armored GPG private key", keyPath)
+	}
+	var privateKey interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported signing key type '%s'. Ed25519 and ECDSA private keys are supported", block.Type)
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		keyId, err := getPublicKeyId(key.Public())
+		return &signingKey{signatureType: Ed25519Signature, keyId: keyId, ed25519Key: key}, err
+	case *ecdsa.PrivateKey:
+		keyId, err := getPublicKeyId(key.Public())
+		return &signingKey{signatureType: EcdsaSignature, keyId: keyId, ecdsaKey: key}, err
+	default:
+		return nil, errorutils.CheckErrorf("unsupported signing key. Ed25519 and ECDSA private keys are supported")
+	}
+}
+
+func loadGpgSigningKey(content []byte) (*signingKey, error) {
+	keyRing, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, entity := range keyRing {
+		if entity.PrivateKey == nil {
+			continue
+		}
+		if entity.PrivateKey.Encrypted {
+			passphrase := os.Getenv(SigningKeyPassphraseEnv)
+			if passphrase == "" {
+				return nil, errorutils.CheckErrorf("the GPG signing key is encrypted. Provide its passphrase using the %s environment variable", SigningKeyPassphraseEnv)
+			}
+			if err = entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+		}
+		return &signingKey{signatureType: GpgSignature, keyId: hex.EncodeToString(entity.PrimaryKey.Fingerprint), gpgEntity: entity}, nil
+	}
+	return nil, errorutils.CheckErrorf("no GPG private key was found")
+}
+
+func loadVerificationKey(keyPath string) (*verificationKey, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(content)), pgpArmorPrefix) {
+		keyRing, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if len(keyRing) == 0 {
+			return nil, errorutils.CheckErrorf("no GPG public key was found")
+		}
+		return &verificationKey{signatureType: GpgSignature, keyId: hex.EncodeToString(keyRing[0].PrimaryKey.Fingerprint), gpgKeyRing: keyRing}, nil
+	}
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errorutils.CheckErrorf("the public key at %s should be a PEM encoded Ed25519 or ECDSA public key, or an armored GPG public key", keyPath)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	keyId, err := getPublicKeyId(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return &verificationKey{signatureType: Ed25519Signature, keyId: keyId, ed25519Key: key}, nil
+	case *ecdsa.PublicKey:
+		return &verificationKey{signatureType: EcdsaSignature, keyId: keyId, ecdsaKey: key}, nil
+	default:
+		return nil, errorutils.CheckErrorf("unsupported public key. Ed25519 and ECDSA public keys are supported")
+	}
+}
+
+// The key ID is the SHA-256 of the DER encoded public key.
+func getPublicKeyId(publicKey interface{}) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	checksum := sha256.Sum256(der)
+	return hex.EncodeToString(checksum[:]), nil
+}
+
+// Returns the base64 encoded signature of the content.
+func (sk *signingKey) sign(content []byte) (string, error) {
+	var signature []byte
+	var err error
+	switch sk.signatureType {
+	case Ed25519Signature:
+		signature = ed25519.Sign(sk.ed25519Key, content)
+	case EcdsaSignature:
+		digest := sha256.Sum256(content)
+		signature, err = ecdsa.SignASN1(rand.Reader, sk.ecdsaKey, digest[:])
+	case GpgSignature:
+		buffer := new(bytes.Buffer)
+		err = openpgp.DetachSign(buffer, sk.gpgEntity, bytes.NewReader(content), nil)
+		signature = buffer.Bytes()
+	}
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+func (vk *verificationKey) verify(content []byte, encodedSignature string) error {
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return errorutils.CheckErrorf("the build-info signature isn't valid base64: %s", err.Error())
+	}
+	switch vk.signatureType {
+	case Ed25519Signature:
+		if !ed25519.Verify(vk.ed25519Key, content, signature) {
+			return errorutils.CheckErrorf("the build-info signature doesn't match the build-info content")
+		}
+	case EcdsaSignature:
+		digest := sha256.Sum256(content)
+		if !ecdsa.VerifyASN1(vk.ecdsaKey, digest[:], signature) {
+			return errorutils.CheckErrorf("the build-info signature doesn't match the build-info content")
+		}
+	case GpgSignature:
+		if _, err = openpgp.CheckDetachedSignature(vk.gpgKeyRing, bytes.NewReader(content), bytes.NewReader(signature), nil); err != nil {
+			return errorutils.CheckErrorf("the build-info signature doesn't match the build-info content: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// Returns the latest build-info file of the build run, in the build-info repository.
+// Each publish of a build run is stored as <build name>/<build number>-<timestamp>.json.
+func getLatestBuildInfoFile(sm artifactory.ArtifactoryServicesManager, buildName, buildNumber, project string) (*servicesutils.ResultItem, error) {
+	result := new(servicesutils.AqlSearchResult)
+	if err := artifactoryutils.RunAql(sm, servicesutils.CreateAqlQueryForBuildInfoJson(project, buildName, buildNumber, "*"), result); err != nil {
+		return nil, err
+	}
+	var latest *servicesutils.ResultItem
+	var latestTimestamp int64 = -1
+	for i, item := range result.Results {
+		trimmedName := strings.TrimSuffix(item.Name, ".json")
+		timestamp, err := strconv.ParseInt(trimmedName[strings.LastIndex(trimmedName, "-")+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		if timestamp > latestTimestamp {
+			latestTimestamp = timestamp
+			latest = &result.Results[i]
+		}
+	}
+	if latest == nil {
+		return nil, errorutils.CheckErrorf("the build-info file of %s/%s wasn't found in Artifactory", buildName, buildNumber)
+	}
+	return latest, nil
+}
@@ -0,0 +1,152 @@
+package buildinfo
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInfoSignature(t *testing.T) {
+	tests := []struct {
+		name                  string
+		createKeys            func(t *testing.T, dir string) (privateKeyPath, publicKeyPath string)
+		expectedSignatureType SignatureType
+	}{
+		{"ed25519", createEd25519Keys, Ed25519Signature},
+		{"ecdsa", createEcdsaKeys, EcdsaSignature},
+		{"gpg", createGpgKeys, GpgSignature},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			privateKeyPath, publicKeyPath := test.createKeys(t, t.TempDir())
+			signing, err := loadSigningKey(privateKeyPath)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSignatureType, signing.signatureType)
+			verification, err := loadVerificationKey(publicKeyPath)
+			require.NoError(t, err)
+			assert.Equal(t, signing.keyId, verification.keyId)
+
+			bi := createTestBuildInfo()
+			canonical, err := canonicalizeBuildInfo(bi)
+			require.NoError(t, err)
+			signature, err := signing.sign(canonical)
+			require.NoError(t, err)
+			digest := sha256.Sum256(canonical)
+			props := map[string][]string{
+				SignatureProp:       {signature},
+				SignatureTypeProp:   {string(signing.signatureType)},
+				SignatureKeyIdProp:  {signing.keyId},
+				SignatureSha256Prop: {hex.EncodeToString(digest[:])},
+			}
+			assert.NoError(t, verifyBuildInfoSignature(bi, props, verification))
+
+			// Tampered build-info.
+			bi.Modules[0].Artifacts[0].Sha256 = "tampered"
+			assert.ErrorContains(t, verifyBuildInfoSignature(bi, props, verification), "modified")
+			delete(props, SignatureSha256Prop)
+			assert.ErrorContains(t, verifyBuildInfoSignature(bi, props, verification), "doesn't match")
+
+			// Unsigned build-info.
+			assert.ErrorContains(t, verifyBuildInfoSignature(bi, nil, verification), "isn't signed")
+		})
+	}
+}
+
+func TestBuildInfoSignatureWrongKey(t *testing.T) {
+	privateKeyPath, _ := createEd25519Keys(t, t.TempDir())
+	_, otherPublicKeyPath := createEd25519Keys(t, t.TempDir())
+	_, ecdsaPublicKeyPath := createEcdsaKeys(t, t.TempDir())
+	signing, err := loadSigningKey(privateKeyPath)
+	require.NoError(t, err)
+
+	bi := createTestBuildInfo()
+	canonical, err := canonicalizeBuildInfo(bi)
+	require.NoError(t, err)
+	signature, err := signing.sign(canonical)
+	require.NoError(t, err)
+	props := map[string][]string{SignatureProp: {signature}, SignatureTypeProp: {string(Ed25519Signature)}}
+
+	otherKey, err := loadVerificationKey(otherPublicKeyPath)
+	require.NoError(t, err)
+	assert.ErrorContains(t, verifyBuildInfoSignature(bi, props, otherKey), "doesn't match")
+	ecdsaKey, err := loadVerificationKey(ecdsaPublicKeyPath)
+	require.NoError(t, err)
+	assert.ErrorContains(t, verifyBuildInfoSignature(bi, props, ecdsaKey), "ed25519")
+}
+
+func TestCanonicalizeBuildInfo(t *testing.T) {
+	bi := createTestBuildInfo()
+	canonical, err := canonicalizeBuildInfo(bi)
+	require.NoError(t, err)
+	assert.Equal(t, `{"modules":[{"artifacts":[{"name":"app.jar","sha256":"abc"}],"id":"app"}],"name":"my-build","number":"1","properties":{"a":"1","b":"2"},"started":"2024-01-01T10:00:00.000+0000"}`, string(canonical))
+}
+
+func createTestBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:       "my-build",
+		Number:     "1",
+		Started:    "2024-01-01T10:00:00.000+0000",
+		Properties: map[string]string{"b": "2", "a": "1"},
+		Modules:    []buildinfo.Module{{Id: "app", Artifacts: []buildinfo.Artifact{{Name: "app.jar", Checksum: buildinfo.Checksum{Sha256: "abc"}}}}},
+	}
+}
+
+func createEd25519Keys(t *testing.T, dir string) (string, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return writePemKeys(t, dir, privateKey, publicKey)
+}
+
+func createEcdsaKeys(t *testing.T, dir string) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return writePemKeys(t, dir, privateKey, privateKey.Public())
+}
+
+func writePemKeys(t *testing.T, dir string, privateKey, publicKey interface{}) (string, string) {
+	privateDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDer, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	privateKeyPath, publicKeyPath := filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer}), 0600))
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0600))
+	return privateKeyPath, publicKeyPath
+}
+
+func createGpgKeys(t *testing.T, dir string) (string, string) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+
+	privateKey := new(bytes.Buffer)
+	writer, err := armor.Encode(privateKey, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(writer, nil))
+	require.NoError(t, writer.Close())
+
+	publicKey := new(bytes.Buffer)
+	writer, err = armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(writer))
+	require.NoError(t, writer.Close())
+
+	privateKeyPath, publicKeyPath := filepath.Join(dir, "key.asc"), filepath.Join(dir, "key.pub.asc")
+	require.NoError(t, os.WriteFile(privateKeyPath, privateKey.Bytes(), 0600))
+	require.NoError(t, os.WriteFile(publicKeyPath, publicKey.Bytes(), 0600))
+	return privateKeyPath, publicKeyPath
+}
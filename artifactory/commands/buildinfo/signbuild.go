@@ -0,0 +1,128 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Signs the published build-info with a local private key.
+// The signature is stored in the properties of the build-info file, in the build-info repository.
+type BuildSignCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	keyPath            string
+	key                *signingKey
+}
+
+func NewBuildSignCommand() *BuildSignCommand {
+	return &BuildSignCommand{}
+}
+
+func (bsc *BuildSignCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildSignCommand {
+	bsc.serverDetails = serverDetails
+	return bsc
+}
+
+func (bsc *BuildSignCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildSignCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+func (bsc *BuildSignCommand) SetKeyPath(keyPath string) *BuildSignCommand {
+	bsc.keyPath = keyPath
+	return bsc
+}
+
+// Loads and validates the signing key, so an invalid key is reported before the build-info is published rather than after.
+// Run loads the key if it wasn't loaded already.
+func (bsc *BuildSignCommand) LoadKey() (err error) {
+	bsc.key, err = loadSigningKey(bsc.keyPath)
+	return err
+}
+
+func (bsc *BuildSignCommand) CommandName() string {
+	return "rt_build_sign"
+}
+
+func (bsc *BuildSignCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bsc.serverDetails, nil
+}
+
+func (bsc *BuildSignCommand) Run() (err error) {
+	if bsc.key == nil {
+		if err = bsc.LoadKey(); err != nil {
+			return err
+		}
+	}
+	key := bsc.key
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := bsc.buildConfiguration.GetProject()
+	sm, err := utils.CreateServiceManager(bsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	publishedBuildInfo, found, err := sm.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: project})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s wasn't found in Artifactory", buildName, buildNumber)
+	}
+	canonical, err := canonicalizeBuildInfo(&publishedBuildInfo.BuildInfo)
+	if err != nil {
+		return err
+	}
+	signature, err := key.sign(canonical)
+	if err != nil {
+		return err
+	}
+	buildInfoFile, err := getLatestBuildInfoFile(sm, buildName, buildNumber, project)
+	if err != nil {
+		return err
+	}
+	reader, err := createSingleItemReader(buildInfoFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	digest := sha256.Sum256(canonical)
+	props := fmt.Sprintf("%s=%s;%s=%s;%s=%s;%s=%s", SignatureProp, signature, SignatureTypeProp, key.signatureType,
+		SignatureKeyIdProp, key.keyId, SignatureSha256Prop, hex.EncodeToString(digest[:]))
+	if _, err = sm.SetProps(services.PropsParams{Reader: reader, Props: props}); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Signed build %s/%s with %s key %s.", buildName, buildNumber, key.signatureType, key.keyId))
+	return nil
+}
+
+func createSingleItemReader(item *servicesutils.ResultItem) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	writer.Write(*item)
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
@@ -0,0 +1,122 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Verifies the signature of the published build-info, created by 'build-publish --sign-key', with a local public key.
+type BuildVerifySignatureCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	keyPath            string
+}
+
+func NewBuildVerifySignatureCommand() *BuildVerifySignatureCommand {
+	return &BuildVerifySignatureCommand{}
+}
+
+func (bvsc *BuildVerifySignatureCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildVerifySignatureCommand {
+	bvsc.serverDetails = serverDetails
+	return bvsc
+}
+
+func (bvsc *BuildVerifySignatureCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildVerifySignatureCommand {
+	bvsc.buildConfiguration = buildConfiguration
+	return bvsc
+}
+
+func (bvsc *BuildVerifySignatureCommand) SetKeyPath(keyPath string) *BuildVerifySignatureCommand {
+	bvsc.keyPath = keyPath
+	return bvsc
+}
+
+func (bvsc *BuildVerifySignatureCommand) CommandName() string {
+	return "rt_build_verify_signature"
+}
+
+func (bvsc *BuildVerifySignatureCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bvsc.serverDetails, nil
+}
+
+func (bvsc *BuildVerifySignatureCommand) Run() error {
+	key, err := loadVerificationKey(bvsc.keyPath)
+	if err != nil {
+		return err
+	}
+	buildName, err := bvsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bvsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := bvsc.buildConfiguration.GetProject()
+	sm, err := utils.CreateServiceManager(bvsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	publishedBuildInfo, found, err := sm.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: project})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s wasn't found in Artifactory", buildName, buildNumber)
+	}
+	buildInfoFile, err := getLatestBuildInfoFile(sm, buildName, buildNumber, project)
+	if err != nil {
+		return err
+	}
+	itemProps, err := sm.GetItemProps(buildInfoFile.GetItemRelativePath())
+	if err != nil {
+		return err
+	}
+	var props map[string][]string
+	if itemProps != nil {
+		props = itemProps.Properties
+	}
+	if err = verifyBuildInfoSignature(&publishedBuildInfo.BuildInfo, props, key); err != nil {
+		return fmt.Errorf("build %s/%s failed the signature verification: %w", buildName, buildNumber, err)
+	}
+	log.Info(fmt.Sprintf("The signature of build %s/%s was verified with %s key %s.", buildName, buildNumber, key.signatureType, key.keyId))
+	return nil
+}
+
+func verifyBuildInfoSignature(bi *buildinfo.BuildInfo, props map[string][]string, key *verificationKey) error {
+	signature := getSingleProp(props, SignatureProp)
+	if signature == "" {
+		return errorutils.CheckErrorf("the build-info isn't signed")
+	}
+	if signatureType := getSingleProp(props, SignatureTypeProp); signatureType != string(key.signatureType) {
+		return errorutils.CheckErrorf("the build-info was signed with a %s key, but a %s key was provided", signatureType, key.signatureType)
+	}
+	if keyId := getSingleProp(props, SignatureKeyIdProp); keyId != "" && keyId != key.keyId {
+		return errorutils.CheckErrorf("the build-info was signed with key %s, but key %s was provided", keyId, key.keyId)
+	}
+	canonical, err := canonicalizeBuildInfo(bi)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(canonical)
+	if expectedDigest := getSingleProp(props, SignatureSha256Prop); expectedDigest != "" && expectedDigest != hex.EncodeToString(digest[:]) {
+		return errorutils.CheckErrorf("the build-info content was modified after it was signed")
+	}
+	return key.verify(canonical, signature)
+}
+
+func getSingleProp(props map[string][]string, key string) string {
+	if values := props[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
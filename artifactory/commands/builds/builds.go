@@ -0,0 +1,290 @@
+package builds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	TableFormat = "table"
+	JsonFormat  = "json"
+
+	DefaultLimit = 50
+)
+
+// A single promotion of a build, as recorded by Artifactory.
+type PromotionStatus struct {
+	Status     string `json:"status,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Timestamp  string `json:"timestamp,omitempty"`
+	User       string `json:"user,omitempty"`
+	CiUser     string `json:"ciUser,omitempty"`
+}
+
+// A published build run, summarized for display.
+type BuildRun struct {
+	Name         string            `json:"name"`
+	Number       string            `json:"number"`
+	Started      string            `json:"started,omitempty"`
+	Url          string            `json:"url,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	Promotions   []PromotionStatus `json:"promotions,omitempty"`
+	Artifacts    int               `json:"artifacts"`
+	Dependencies int               `json:"dependencies"`
+	VcsRevisions []string          `json:"vcsRevisions,omitempty"`
+}
+
+type buildRunRow struct {
+	Name         string `col-name:"Build Name"`
+	Number       string `col-name:"Build Number"`
+	Started      string `col-name:"Started"`
+	Status       string `col-name:"Status"`
+	Promotions   string `col-name:"Promotion History"`
+	Artifacts    string `col-name:"Artifacts"`
+	Dependencies string `col-name:"Dependencies"`
+}
+
+// The published build-info, including the promotion statuses which are added by Artifactory.
+type publishedBuild struct {
+	BuildInfo struct {
+		buildinfo.BuildInfo
+		Statuses []PromotionStatus `json:"statuses,omitempty"`
+	} `json:"buildInfo,omitempty"`
+}
+
+// The result of an AQL query in the builds domain.
+type buildsAqlResult struct {
+	Results []struct {
+		Name   string `json:"build.name"`
+		Number string `json:"build.number"`
+	} `json:"results"`
+}
+
+// Identifies a build run stored in the build-info repository.
+type buildRunId struct {
+	name   string
+	number string
+}
+
+// Fields shared by the 'builds list' and 'builds query' commands.
+type buildsCommand struct {
+	serverDetails *config.ServerDetails
+	namePattern   string
+	since         string
+	project       string
+	limit         int
+	format        string
+}
+
+func (bc *buildsCommand) setServerDetails(serverDetails *config.ServerDetails) {
+	bc.serverDetails = serverDetails
+}
+
+func (bc *buildsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bc.serverDetails, nil
+}
+
+func (bc *buildsCommand) validate() error {
+	if bc.format != "" && bc.format != TableFormat && bc.format != JsonFormat {
+		return errorutils.CheckErrorf("unsupported format '%s'. Acceptable values are: %s, %s", bc.format, TableFormat, JsonFormat)
+	}
+	if bc.limit <= 0 {
+		return errorutils.CheckErrorf("the limit must be a positive number")
+	}
+	if bc.since != "" {
+		if _, err := ParseSince(bc.since); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bc *buildsCommand) createServiceManager() (artifactory.ArtifactoryServicesManager, error) {
+	return utils.CreateServiceManager(bc.serverDetails, -1, 0, false)
+}
+
+// Returns the build runs stored in the build-info repository, from the most recent one.
+// Each build-info is stored as <build name>/<build number>-<timestamp>.json in the repository.
+func (bc *buildsCommand) searchBuildRuns(sm artifactory.ArtifactoryServicesManager) ([]buildRunId, error) {
+	criteria := map[string]interface{}{"repo": servicesutils.GetBuildInfoRepositoryByProject(bc.project), "type": "file"}
+	if bc.namePattern != "" {
+		criteria["path"] = map[string]string{"$match": encodeBuildName(bc.namePattern)}
+	}
+	if bc.since != "" {
+		sinceTime, err := ParseSince(bc.since)
+		if err != nil {
+			return nil, err
+		}
+		criteria["created"] = map[string]string{"$gt": sinceTime.UTC().Format(time.RFC3339)}
+	}
+	criteriaJson, err := json.Marshal(criteria)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	query := fmt.Sprintf(`items.find(%s).include("path","name","created").sort({"$desc":["created"]})`, criteriaJson)
+	result := new(servicesutils.AqlSearchResult)
+	if err = artifactoryutils.RunAql(sm, query, result); err != nil {
+		return nil, err
+	}
+	var runs []buildRunId
+	for _, item := range result.Results {
+		if run, ok := parseBuildInfoItem(item.Path, item.Name); ok {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// Returns the published build-info of the build run, or nil if it doesn't exist.
+func (bc *buildsCommand) getBuildRun(sm artifactory.ArtifactoryServicesManager, run buildRunId) (*BuildRun, *publishedBuild, error) {
+	serviceDetails := sm.GetConfig().GetServiceDetails()
+	queryParams := make(map[string]string)
+	if bc.project != "" {
+		queryParams["project"] = bc.project
+	}
+	requestUrl, err := clientutils.BuildUrl(serviceDetails.GetUrl(), "api/build/"+url.PathEscape(run.name)+"/"+url.PathEscape(run.number), queryParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := sm.Client().SendGet(requestUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, nil, err
+	}
+	published := new(publishedBuild)
+	if err = json.Unmarshal(body, published); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	return toBuildRun(published), published, nil
+}
+
+func toBuildRun(published *publishedBuild) *BuildRun {
+	bi := published.BuildInfo
+	run := &BuildRun{
+		Name:       bi.Name,
+		Number:     bi.Number,
+		Started:    bi.Started,
+		Url:        bi.BuildUrl,
+		Promotions: bi.Statuses,
+	}
+	sort.SliceStable(run.Promotions, func(i, j int) bool {
+		return run.Promotions[i].Timestamp < run.Promotions[j].Timestamp
+	})
+	if len(run.Promotions) > 0 {
+		run.Status = run.Promotions[len(run.Promotions)-1].Status
+	}
+	for _, module := range bi.Modules {
+		run.Artifacts += len(module.Artifacts)
+		run.Dependencies += len(module.Dependencies)
+	}
+	for _, vcs := range bi.VcsList {
+		run.VcsRevisions = append(run.VcsRevisions, vcs.Revision)
+	}
+	return run
+}
+
+func printBuildRuns(runs []*BuildRun, format string) error {
+	if format == JsonFormat {
+		if runs == nil {
+			runs = []*BuildRun{}
+		}
+		content, err := json.MarshalIndent(runs, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	var rows []buildRunRow
+	for _, run := range runs {
+		var promotions []string
+		for _, promotion := range run.Promotions {
+			promotions = append(promotions, promotion.Status)
+		}
+		rows = append(rows, buildRunRow{
+			Name:         run.Name,
+			Number:       run.Number,
+			Started:      run.Started,
+			Status:       run.Status,
+			Promotions:   strings.Join(promotions, " -> "),
+			Artifacts:    strconv.Itoa(run.Artifacts),
+			Dependencies: strconv.Itoa(run.Dependencies),
+		})
+	}
+	return coreutils.PrintTable(rows, "Builds", "No builds were found", false)
+}
+
+// Parses the build-info item path and name in the form of <build name>/<build number>-<timestamp>.json.
+func parseBuildInfoItem(itemPath, itemName string) (buildRunId, bool) {
+	trimmedName, isJson := strings.CutSuffix(itemName, ".json")
+	separator := strings.LastIndex(trimmedName, "-")
+	if !isJson || separator <= 0 {
+		return buildRunId{}, false
+	}
+	name, err := url.PathUnescape(itemPath)
+	if err != nil {
+		return buildRunId{}, false
+	}
+	number, err := url.PathUnescape(trimmedName[:separator])
+	if err != nil {
+		return buildRunId{}, false
+	}
+	return buildRunId{name: name, number: number}, true
+}
+
+// Slashes in build names are encoded in the build-info repository paths.
+func encodeBuildName(buildName string) string {
+	return strings.ReplaceAll(buildName, "/", "%2F")
+}
+
+var sincePattern = regexp.MustCompile(`^(\d+)(mo|[hdwy])$`)
+
+// Parses a relative period such as 12h, 30d, 2w, 6mo or 1y, and returns the time it started at.
+func ParseSince(since string) (time.Time, error) {
+	groups := sincePattern.FindStringSubmatch(since)
+	if groups == nil {
+		return time.Time{}, errorutils.CheckErrorf("invalid period '%s'. The period should be a number followed by one of the units: h, d, w, mo or y. For example: 30d", since)
+	}
+	amount, err := strconv.Atoi(groups[1])
+	if err != nil {
+		return time.Time{}, errorutils.CheckError(err)
+	}
+	now := time.Now()
+	switch groups[2] {
+	case "h":
+		return now.Add(-time.Duration(amount) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, -amount), nil
+	case "w":
+		return now.AddDate(0, 0, -7*amount), nil
+	case "mo":
+		return now.AddDate(0, -amount, 0), nil
+	default:
+		return now.AddDate(-amount, 0, 0), nil
+	}
+}
@@ -0,0 +1,143 @@
+package builds
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const artifactSha256 = "4bf5122f344554c53bde2ebb8cd2b7e3d1600ad631c385a5d7cce23c7785459a"
+
+func TestParseBuildInfoItem(t *testing.T) {
+	tests := []struct {
+		path, name string
+		expected   buildRunId
+		ok         bool
+	}{
+		{"my-build", "12-1700000000000.json", buildRunId{name: "my-build", number: "12"}, true},
+		{"team%2Fbuild", "1.0-rc-1700000000000.json", buildRunId{name: "team/build", number: "1.0-rc"}, true},
+		{"my-build", "12.txt", buildRunId{}, false},
+		{"my-build", "1700000000000.json", buildRunId{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.path+"/"+test.name, func(t *testing.T) {
+			runId, ok := parseBuildInfoItem(test.path, test.name)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, runId)
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	sinceTime, err := ParseSince("30d")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), sinceTime, time.Minute)
+
+	sinceTime, err = ParseSince("6mo")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, -6, 0), sinceTime, time.Minute)
+
+	for _, invalid := range []string{"", "30", "d", "30m", "-1d"} {
+		_, err = ParseSince(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestToBuildRun(t *testing.T) {
+	published := new(publishedBuild)
+	require.NoError(t, json.Unmarshal([]byte(getBuildInfoJson("my-build", "2")), published))
+	run := toBuildRun(published)
+	assert.Equal(t, "my-build", run.Name)
+	assert.Equal(t, "2", run.Number)
+	assert.Equal(t, "Released", run.Status)
+	assert.Len(t, run.Promotions, 2)
+	assert.Equal(t, 1, run.Artifacts)
+	assert.Equal(t, 2, run.Dependencies)
+	assert.Equal(t, []string{"abcdef123456"}, run.VcsRevisions)
+}
+
+func TestBuildsQueryIsMatch(t *testing.T) {
+	published := new(publishedBuild)
+	require.NoError(t, json.Unmarshal([]byte(getBuildInfoJson("my-build", "2")), published))
+	run := toBuildRun(published)
+
+	assert.True(t, NewBuildsQueryCommand().isMatch(run, published))
+	assert.True(t, NewBuildsQueryCommand().SetPromotionStatus("released").isMatch(run, published))
+	assert.False(t, NewBuildsQueryCommand().SetPromotionStatus("Staged").isMatch(run, published))
+	assert.True(t, NewBuildsQueryCommand().SetVcsRevision("abcdef1").isMatch(run, published))
+	assert.False(t, NewBuildsQueryCommand().SetVcsRevision("123456").isMatch(run, published))
+	assert.True(t, NewBuildsQueryCommand().SetProperty("vcs.pr.number=7").isMatch(run, published))
+	assert.False(t, NewBuildsQueryCommand().SetProperty("vcs.pr.number=8").isMatch(run, published))
+	// The runs found by checksum are already matched by AQL, so build-infos whose artifacts have no SHA-256 aren't dropped.
+	assert.True(t, NewBuildsQueryCommand().SetSha256(strings.Repeat("0", 64)).isMatch(run, published))
+}
+
+func TestBuildsQueryBySha256(t *testing.T) {
+	var aqlQueries, buildRuns []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			aqlQueries = append(aqlQueries, string(body))
+			_, err = w.Write([]byte(`{"results":[{"build.name":"my-build","build.number":"2"},{"build.name":"my-build","build.number":"2"},{"build.name":"other-build","build.number":"7"}]}`))
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/api/build/"):
+			buildRuns = append(buildRuns, r.URL.Path)
+			_, err := w.Write([]byte(getBuildInfoJson("my-build", "2")))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+	queryCmd := NewBuildsQueryCommand().SetServerDetails(serverDetails).SetSha256(artifactSha256).SetFormat(JsonFormat)
+	assert.NoError(t, queryCmd.Run())
+	if assert.Len(t, aqlQueries, 1) {
+		assert.Contains(t, aqlQueries[0], `builds.find({"module.artifact.item.sha256":"`+artifactSha256+`"})`)
+	}
+	// Each build run is read once.
+	assert.Equal(t, []string{"/api/build/my-build/2", "/api/build/other-build/7"}, buildRuns)
+
+	// Invalid checksums are rejected before searching.
+	queryCmd.SetSha256("invalid")
+	assert.Error(t, queryCmd.Run())
+}
+
+func TestBuildsValidateLimit(t *testing.T) {
+	assert.NoError(t, NewBuildsListCommand().SetLimit(1).validate())
+	assert.ErrorContains(t, NewBuildsListCommand().SetLimit(0).validate(), "positive")
+	assert.ErrorContains(t, NewBuildsQueryCommand().SetLimit(-1).validate(), "positive")
+}
+
+func getBuildInfoJson(name, number string) string {
+	return `{
+  "buildInfo": {
+    "name": "` + name + `",
+    "number": "` + number + `",
+    "started": "2024-01-01T10:00:00.000+0000",
+    "properties": {"vcs.pr.number": "7"},
+    "vcs": [{"url": "https://github.com/jfrog/jfrog-cli.git", "revision": "abcdef123456"}],
+    "modules": [{
+      "id": "app",
+      "artifacts": [{"name": "app.jar", "sha256": "` + artifactSha256 + `"}],
+      "dependencies": [{"id": "dep1"}, {"id": "dep2"}]
+    }],
+    "statuses": [
+      {"status": "Released", "timestamp": "2024-01-03T10:00:00.000+0000"},
+      {"status": "Staged", "timestamp": "2024-01-02T10:00:00.000+0000"}
+    ]
+  },
+  "uri": "http://localhost/api/build/` + name + `/` + number + `"
+}`
+}
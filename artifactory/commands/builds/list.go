@@ -0,0 +1,78 @@
+package builds
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Lists the published build runs, with their promotion status and artifact counts.
+type BuildsListCommand struct {
+	buildsCommand
+}
+
+func NewBuildsListCommand() *BuildsListCommand {
+	return &BuildsListCommand{buildsCommand{limit: DefaultLimit, format: TableFormat}}
+}
+
+func (blc *BuildsListCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildsListCommand {
+	blc.setServerDetails(serverDetails)
+	return blc
+}
+
+func (blc *BuildsListCommand) SetNamePattern(namePattern string) *BuildsListCommand {
+	blc.namePattern = namePattern
+	return blc
+}
+
+func (blc *BuildsListCommand) SetSince(since string) *BuildsListCommand {
+	blc.since = since
+	return blc
+}
+
+func (blc *BuildsListCommand) SetProject(project string) *BuildsListCommand {
+	blc.project = project
+	return blc
+}
+
+func (blc *BuildsListCommand) SetLimit(limit int) *BuildsListCommand {
+	blc.limit = limit
+	return blc
+}
+
+func (blc *BuildsListCommand) SetFormat(format string) *BuildsListCommand {
+	blc.format = format
+	return blc
+}
+
+func (blc *BuildsListCommand) CommandName() string {
+	return "rt_builds_list"
+}
+
+func (blc *BuildsListCommand) Run() error {
+	if err := blc.validate(); err != nil {
+		return err
+	}
+	sm, err := blc.createServiceManager()
+	if err != nil {
+		return err
+	}
+	runIds, err := blc.searchBuildRuns(sm)
+	if err != nil {
+		return err
+	}
+	if len(runIds) > blc.limit {
+		log.Info("Showing the", blc.limit, "most recent builds out of", len(runIds), "found.")
+		runIds = runIds[:blc.limit]
+	}
+	var runs []*BuildRun
+	for _, runId := range runIds {
+		run, _, err := blc.getBuildRun(sm, runId)
+		if err != nil {
+			return err
+		}
+		if run != nil {
+			runs = append(runs, run)
+		}
+	}
+	return printBuildRuns(runs, blc.format)
+}
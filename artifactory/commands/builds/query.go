@@ -0,0 +1,204 @@
+package builds
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The maximum number of build runs whose build-info is read to match the filters.
+const maxScannedRuns = 500
+
+var sha256Pattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// Searches the published build runs matching all the provided filters.
+type BuildsQueryCommand struct {
+	buildsCommand
+	property        string
+	promotionStatus string
+	vcsRevision     string
+	sha256          string
+}
+
+func NewBuildsQueryCommand() *BuildsQueryCommand {
+	return &BuildsQueryCommand{buildsCommand: buildsCommand{limit: DefaultLimit, format: TableFormat}}
+}
+
+func (bqc *BuildsQueryCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildsQueryCommand {
+	bqc.setServerDetails(serverDetails)
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetNamePattern(namePattern string) *BuildsQueryCommand {
+	bqc.namePattern = namePattern
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetSince(since string) *BuildsQueryCommand {
+	bqc.since = since
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetProject(project string) *BuildsQueryCommand {
+	bqc.project = project
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetLimit(limit int) *BuildsQueryCommand {
+	bqc.limit = limit
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetFormat(format string) *BuildsQueryCommand {
+	bqc.format = format
+	return bqc
+}
+
+// The property should be in the form of key=value.
+func (bqc *BuildsQueryCommand) SetProperty(property string) *BuildsQueryCommand {
+	bqc.property = property
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetPromotionStatus(promotionStatus string) *BuildsQueryCommand {
+	bqc.promotionStatus = promotionStatus
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetVcsRevision(vcsRevision string) *BuildsQueryCommand {
+	bqc.vcsRevision = vcsRevision
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) SetSha256(sha256 string) *BuildsQueryCommand {
+	bqc.sha256 = strings.ToLower(sha256)
+	return bqc
+}
+
+func (bqc *BuildsQueryCommand) CommandName() string {
+	return "rt_builds_query"
+}
+
+func (bqc *BuildsQueryCommand) Run() error {
+	if err := bqc.validate(); err != nil {
+		return err
+	}
+	if bqc.property != "" && !strings.Contains(bqc.property, "=") {
+		return errorutils.CheckErrorf("the property '%s' should be in the form of key=value", bqc.property)
+	}
+	if bqc.sha256 != "" && !sha256Pattern.MatchString(bqc.sha256) {
+		return errorutils.CheckErrorf("'%s' is not a valid SHA-256 checksum", bqc.sha256)
+	}
+	sm, err := bqc.createServiceManager()
+	if err != nil {
+		return err
+	}
+	var runIds []buildRunId
+	if bqc.sha256 != "" {
+		runIds, err = bqc.searchBuildRunsBySha256(sm)
+	} else {
+		runIds, err = bqc.searchBuildRuns(sm)
+	}
+	if err != nil {
+		return err
+	}
+	// The promotion status, property and VCS revision filters are matched against the build-info of each run,
+	// so the number of runs read is capped.
+	var runs []*BuildRun
+	for i, runId := range runIds {
+		if len(runs) == bqc.limit {
+			break
+		}
+		if i == maxScannedRuns {
+			log.Warn("Stopped after reading the", maxScannedRuns, "most recent builds out of", len(runIds), "found. Use the --name or --since options to narrow down the search.")
+			break
+		}
+		run, published, err := bqc.getBuildRun(sm, runId)
+		if err != nil {
+			return err
+		}
+		if run != nil && bqc.isMatch(run, published) {
+			runs = append(runs, run)
+		}
+	}
+	return printBuildRuns(runs, bqc.format)
+}
+
+// The builds domain of AQL finds the build runs whose artifacts have the checksum, without reading every published build-info.
+// Each result is a single build run, unlike the build.name and build.number properties of the artifact, which hold the values of all the builds which deployed it.
+func (bqc *BuildsQueryCommand) searchBuildRunsBySha256(sm artifactory.ArtifactoryServicesManager) ([]buildRunId, error) {
+	criteria := map[string]interface{}{"module.artifact.item.sha256": bqc.sha256}
+	if bqc.namePattern != "" {
+		criteria["name"] = map[string]string{"$match": bqc.namePattern}
+	}
+	criteriaJson, err := json.Marshal(criteria)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	query := fmt.Sprintf(`builds.find(%s).include("name","number","created").sort({"$desc":["created"]})`, criteriaJson)
+	result := new(buildsAqlResult)
+	if err = artifactoryutils.RunAql(sm, query, result); err != nil {
+		return nil, err
+	}
+	var runIds []buildRunId
+	found := make(map[buildRunId]bool)
+	for _, build := range result.Results {
+		runId := buildRunId{name: build.Name, number: build.Number}
+		if !found[runId] {
+			found[runId] = true
+			runIds = append(runIds, runId)
+		}
+	}
+	return runIds, nil
+}
+
+func (bqc *BuildsQueryCommand) isMatch(run *BuildRun, published *publishedBuild) bool {
+	if bqc.since != "" && !isStartedSince(run.Started, bqc.since) {
+		return false
+	}
+	if bqc.promotionStatus != "" && !strings.EqualFold(run.Status, bqc.promotionStatus) {
+		return false
+	}
+	if bqc.property != "" {
+		key, value, _ := strings.Cut(bqc.property, "=")
+		if published.BuildInfo.Properties[key] != value {
+			return false
+		}
+	}
+	if bqc.vcsRevision != "" && !hasVcsRevision(run.VcsRevisions, bqc.vcsRevision) {
+		return false
+	}
+	return true
+}
+
+// Runs searched by name are already filtered by the build-info creation time, but runs searched by checksum are not.
+func isStartedSince(started, since string) bool {
+	sinceTime, err := ParseSince(since)
+	if err != nil {
+		return true
+	}
+	startedTime, err := time.Parse(buildinfo.TimeFormat, started)
+	if err != nil {
+		return true
+	}
+	return startedTime.After(sinceTime)
+}
+
+// Short revisions are matched by prefix.
+func hasVcsRevision(revisions []string, revision string) bool {
+	for _, candidate := range revisions {
+		if strings.HasPrefix(candidate, revision) {
+			return true
+		}
+	}
+	return false
+}
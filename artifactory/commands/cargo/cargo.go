@@ -0,0 +1,294 @@
+package cargo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ToolName       = "cargo"
+	executableName = "cargo"
+
+	ModuleType buildinfo.ModuleType = "cargo"
+
+	// The names of the registries configured by the command.
+	resolverRegistryName = "jfrog"
+	deployerRegistryName = "jfrog-deploy"
+)
+
+// Runs any cargo command, resolving the crates from an Artifactory Cargo repository instead of crates.io.
+// The dependencies are collected from the Cargo.lock file into the build-info, and the published crates are recorded as artifacts.
+type CargoCommand struct {
+	cmdName            string
+	configFilePath     string
+	cargoArgs          []string
+	resolverDetails    *config.ServerDetails
+	resolverRepo       string
+	deployerDetails    *config.ServerDetails
+	deployerRepo       string
+	workingDirectory   string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewCargoCommand() *CargoCommand {
+	return &CargoCommand{}
+}
+
+func (cc *CargoCommand) SetCmdName(cmdName string) *CargoCommand {
+	cc.cmdName = cmdName
+	return cc
+}
+
+func (cc *CargoCommand) SetConfigFilePath(configFilePath string) *CargoCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CargoCommand) SetArgs(args []string) *CargoCommand {
+	cc.cargoArgs = args
+	return cc
+}
+
+func (cc *CargoCommand) CommandName() string {
+	return "rt_cargo_" + cc.cmdName
+}
+
+func (cc *CargoCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.isPublish() {
+		return cc.deployerDetails, nil
+	}
+	return cc.resolverDetails, nil
+}
+
+// Reads the resolver and deployer configuration and extracts the build-info options from the cargo arguments.
+func (cc *CargoCommand) Init() (err error) {
+	if cc.cargoArgs, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.cargoArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(cc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig != nil {
+		if cc.resolverDetails, err = resolverConfig.ServerDetails(); err != nil {
+			return err
+		}
+		cc.resolverRepo = resolverConfig.TargetRepo()
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(cc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig != nil {
+		if cc.deployerDetails, err = deployerConfig.ServerDetails(); err != nil {
+			return err
+		}
+		cc.deployerRepo = deployerConfig.TargetRepo()
+	}
+	if cc.isPublish() && cc.deployerDetails == nil {
+		return errorutils.CheckErrorf("the deployer repository is missing from the config file (%s). Please run 'jf cargo-config' with the --repo-deploy option", cc.configFilePath)
+	}
+	return nil
+}
+
+func (cc *CargoCommand) isPublish() bool {
+	return cc.cmdName == "publish"
+}
+
+func (cc *CargoCommand) Run() (err error) {
+	if cc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	args, env, err := cc.prepareCargoArgs()
+	if err != nil {
+		return err
+	}
+	if err = runCargo(cc.workingDirectory, env, args...); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	if err = cc.collectDependencies(); err != nil {
+		return err
+	}
+	if cc.isPublish() {
+		return cc.collectPublishedCrates()
+	}
+	return nil
+}
+
+// Returns the cargo arguments and environment variables, which configure the Artifactory registries.
+// crates.io is replaced by the resolver registry. On publish, the crate is published to the deployer registry, unless another registry was requested.
+func (cc *CargoCommand) prepareCargoArgs() (args, env []string, err error) {
+	if cc.resolverDetails != nil {
+		registryArgs, registryEnv, err := registryConfig(resolverRegistryName, cc.resolverDetails, cc.resolverRepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, registryArgs...)
+		args = append(args, "--config", `source.crates-io.replace-with="`+resolverRegistryName+`"`)
+		env = append(env, registryEnv...)
+	}
+	cmdArgs := append([]string{cc.cmdName}, cc.cargoArgs...)
+	if cc.isPublish() && !hasFlag(cc.cargoArgs, "--registry", "--index") {
+		registryArgs, registryEnv, err := registryConfig(deployerRegistryName, cc.deployerDetails, cc.deployerRepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, registryArgs...)
+		env = append(env, registryEnv...)
+		cmdArgs = append(cmdArgs, "--registry", deployerRegistryName)
+	}
+	return append(args, cmdArgs...), env, nil
+}
+
+// Returns the --config arguments of a sparse registry of an Artifactory Cargo repository, and the environment variable of its token.
+func registryConfig(registryName string, serverDetails *config.ServerDetails, repo string) (args, env []string, err error) {
+	index := "sparse+" + strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/cargo/" + repo + "/index/"
+	args = []string{"--config", "registries." + registryName + `.index="` + index + `"`}
+	token, err := registryToken(serverDetails)
+	if err != nil || token == "" {
+		return args, nil, err
+	}
+	// Cargo requires a credential provider for registries which require authentication, and the cargo:token provider reads the token from the environment.
+	args = append(args, "--config", "registries."+registryName+`.credential-provider="cargo:token"`)
+	tokenEnv := "CARGO_REGISTRIES_" + strings.ToUpper(strings.ReplaceAll(registryName, "-", "_")) + "_TOKEN"
+	return args, []string{tokenEnv + "=" + token}, nil
+}
+
+// Cargo sends the registry token as is in the Authorization header.
+func registryToken(serverDetails *config.ServerDetails) (string, error) {
+	switch {
+	case serverDetails.GetAccessToken() != "":
+		return "Bearer " + serverDetails.GetAccessToken(), nil
+	case serverDetails.GetUser() != "" && serverDetails.GetPassword() != "":
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(serverDetails.GetUser()+":"+serverDetails.GetPassword())), nil
+	case serverDetails.SshKeyPath != "":
+		return "", errorutils.CheckErrorf("SSH authentication is not supported in this command")
+	}
+	return "", nil
+}
+
+func hasFlag(args []string, flags ...string) bool {
+	for _, arg := range args {
+		for _, flag := range flags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Collects the dependencies of each package of the workspace from the Cargo.lock file.
+func (cc *CargoCommand) collectDependencies() error {
+	lockfileDir, exists, err := fileutils.FindUpstream(LockfileName, fileutils.File)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Warn(LockfileName + " was not found, and therefore the dependencies are not included in the build-info.")
+		return nil
+	}
+	content, err := os.ReadFile(filepath.Join(lockfileDir, LockfileName))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockfile, err := parseLockfile(content)
+	if err != nil {
+		return err
+	}
+	for _, module := range lockfile.getModules() {
+		if err = buildinfoutils.SaveDependencies(cc.buildConfiguration, module.id, ModuleType, module.dependencies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type cargoMetadata struct {
+	Packages []struct {
+		Name         string `json:"name"`
+		Version      string `json:"version"`
+		ManifestPath string `json:"manifest_path"`
+	} `json:"packages"`
+}
+
+// Records the published crates as artifacts of their packages.
+func (cc *CargoCommand) collectPublishedCrates() error {
+	output, err := exec.Command(executableName, "metadata", "--no-deps", "--format-version", "1").Output()
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read the cargo metadata: %s", err.Error())
+	}
+	metadata := new(cargoMetadata)
+	if err = json.Unmarshal(output, metadata); err != nil {
+		return errorutils.CheckError(err)
+	}
+	packages := getPublishedPackages(cc.cargoArgs)
+	for _, pkg := range metadata.Packages {
+		published := hasFlag(cc.cargoArgs, "--workspace") || packages[pkg.Name]
+		if len(packages) == 0 && !published {
+			published = filepath.Dir(pkg.ManifestPath) == cc.workingDirectory
+		}
+		if !published {
+			continue
+		}
+		// The crates are stored in the repository under crates/<name>/<name>-<version>.crate.
+		artifacts, err := buildinfoutils.GetDeployedArtifacts(cc.deployerDetails, cc.buildConfiguration, cc.deployerRepo, "*/"+pkg.Name+"-"+pkg.Version+".crate")
+		if err != nil {
+			return err
+		}
+		for i := range artifacts {
+			artifacts[i].Type = crateType
+		}
+		if err = buildinfoutils.SaveArtifacts(cc.buildConfiguration, pkg.Name+":"+pkg.Version, ModuleType, artifacts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the packages selected by the -p and --package options.
+func getPublishedPackages(args []string) map[string]bool {
+	packages := make(map[string]bool)
+	for i, arg := range args {
+		switch {
+		case (arg == "-p" || arg == "--package") && i+1 < len(args):
+			packages[args[i+1]] = true
+		case strings.HasPrefix(arg, "--package="):
+			packages[strings.TrimPrefix(arg, "--package=")] = true
+		}
+	}
+	return packages
+}
+
+func runCargo(workingDir string, env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the cargo executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Dir = workingDir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
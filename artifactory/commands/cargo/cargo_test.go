@@ -0,0 +1,151 @@
+package cargo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientTestUtils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareCargoArgs(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}
+	cargoCmd := NewCargoCommand().SetCmdName("publish").SetArgs([]string{"--allow-dirty"})
+	cargoCmd.resolverDetails, cargoCmd.resolverRepo = serverDetails, "cargo-virtual"
+	cargoCmd.deployerDetails, cargoCmd.deployerRepo = &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "pass"}, "cargo-local"
+
+	args, env, err := cargoCmd.prepareCargoArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"--config", `registries.jfrog.index="sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"`,
+		"--config", `registries.jfrog.credential-provider="cargo:token"`,
+		"--config", `source.crates-io.replace-with="jfrog"`,
+		"--config", `registries.jfrog-deploy.index="sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-local/index/"`,
+		"--config", `registries.jfrog-deploy.credential-provider="cargo:token"`,
+		"publish", "--allow-dirty", "--registry", "jfrog-deploy",
+	}, args)
+	assert.Equal(t, []string{"CARGO_REGISTRIES_JFROG_TOKEN=Bearer token", "CARGO_REGISTRIES_JFROG_DEPLOY_TOKEN=Basic dXNlcjpwYXNz"}, env)
+
+	// A registry requested by the user isn't replaced.
+	cargoCmd.SetArgs([]string{"--registry", "other"})
+	args, _, err = cargoCmd.prepareCargoArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"publish", "--registry", "other"}, args[6:])
+}
+
+func TestGetPublishedPackages(t *testing.T) {
+	assert.Equal(t, map[string]bool{"app": true, "common": true}, getPublishedPackages([]string{"-p", "app", "--package=common", "--dry-run"}))
+	assert.Empty(t, getPublishedPackages([]string{"--allow-dirty"}))
+}
+
+// Runs cargo fetch against a local stub of an Artifactory sparse registry, and verifies the dependencies saved in the build-info.
+func TestCargoFetchFromRegistryStub(t *testing.T) {
+	if _, err := exec.LookPath(executableName); err != nil {
+		t.Skip("cargo was not found in the system PATH")
+	}
+	crates := map[string][]byte{
+		"leftpad-1.0.0": createTestCrate(t, "leftpad", "1.0.0", "[dependencies]\nspaces = \"0.1\"\n"),
+		"spaces-0.1.0":  createTestCrate(t, "spaces", "0.1.0", ""),
+	}
+	leftpadChecksum, spacesChecksum := sha256Of(crates["leftpad-1.0.0"]), sha256Of(crates["spaces-0.1.0"])
+	indexFiles := map[string]string{
+		"le/ft/leftpad": `{"name":"leftpad","vers":"1.0.0","deps":[{"name":"spaces","req":"^0.1","features":[],"optional":false,"default_features":true,"target":null,"kind":"normal"}],"cksum":"` + leftpadChecksum + `","features":{},"yanked":false}`,
+		"sp/ac/spaces":  `{"name":"spaces","vers":"0.1.0","deps":[],"cksum":"` + spacesChecksum + `","features":{},"yanked":false}`,
+	}
+	const repoPath = "/artifactory/api/cargo/cargo-virtual/"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath := strings.TrimPrefix(r.URL.Path, repoPath)
+		if requestPath == "index/config.json" {
+			_, _ = fmt.Fprintf(w, `{"dl":"http://%s%sv1/crates","api":"http://%s%s","auth-required":true}`, r.Host, repoPath, r.Host, repoPath)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if indexFile, found := indexFiles[strings.TrimPrefix(requestPath, "index/")]; found {
+			_, _ = w.Write([]byte(indexFile + "\n"))
+			return
+		}
+		// The crates are downloaded from <dl>/<name>/<version>/download.
+		if segments := strings.Split(strings.TrimPrefix(requestPath, "v1/crates/"), "/"); len(segments) == 3 && segments[2] == "download" {
+			if crate, found := crates[segments[0]+"-"+segments[1]]; found {
+				_, _ = w.Write(crate)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	projectDir := t.TempDir()
+	writeTestFile(t, filepath.Join(projectDir, "Cargo.toml"), "[package]\nname = \"app\"\nversion = \"0.1.0\"\nedition = \"2021\"\n\n[dependencies]\nleftpad = \"1.0.0\"\n")
+	writeTestFile(t, filepath.Join(projectDir, "src", "main.rs"), "fn main() {}\n")
+	t.Setenv("CARGO_HOME", t.TempDir())
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallback := clientTestUtils.ChangeDirWithCallback(t, wd, projectDir)
+	defer chdirCallback()
+
+	// The partial build-info is saved in the persistent temp dir of the CLI.
+	require.NoError(t, build.RemoveBuildDir("cargo-build", "1", ""))
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir("cargo-build", "1", ""))
+	}()
+	cargoCmd := NewCargoCommand().SetCmdName("fetch")
+	cargoCmd.resolverDetails, cargoCmd.resolverRepo = &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/", AccessToken: "token"}, "cargo-virtual"
+	cargoCmd.buildConfiguration = build.NewBuildConfiguration("cargo-build", "1", "", "")
+	require.NoError(t, cargoCmd.Run())
+
+	partials, err := build.ReadPartialBuildInfoFiles("cargo-build", "1", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, "app:0.1.0", partials[0].ModuleId)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "leftpad:1.0.0", Type: crateType, Checksum: buildinfo.Checksum{Sha256: leftpadChecksum}, RequestedBy: [][]string{{"app:0.1.0"}}},
+		{Id: "spaces:0.1.0", Type: crateType, Checksum: buildinfo.Checksum{Sha256: spacesChecksum}, RequestedBy: [][]string{{"leftpad:1.0.0", "app:0.1.0"}}},
+	}, partials[0].Dependencies)
+}
+
+// Returns a .crate file, which is a gzipped tarball of the crate sources under <name>-<version>/.
+func createTestCrate(t *testing.T, name, version, dependencies string) []byte {
+	content := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(content)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for filePath, fileContent := range map[string]string{
+		"Cargo.toml": "[package]\nname = \"" + name + "\"\nversion = \"" + version + "\"\nedition = \"2021\"\n\n" + dependencies,
+		"src/lib.rs": "",
+	} {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name + "-" + version + "/" + filePath, Mode: 0644, Size: int64(len(fileContent))}))
+		_, err := tarWriter.Write([]byte(fileContent))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return content.Bytes()
+}
+
+func sha256Of(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeTestFile(t *testing.T, filePath, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+}
@@ -0,0 +1,105 @@
+package cargo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	LockfileName = "Cargo.lock"
+	crateType    = "crate"
+)
+
+type cargoLockfile struct {
+	Packages []*lockPackage `toml:"package"`
+}
+
+type lockPackage struct {
+	Name     string `toml:"name"`
+	Version  string `toml:"version"`
+	Source   string `toml:"source"`
+	Checksum string `toml:"checksum"`
+	// Each dependency is referenced by its name, followed by its version and source if the name is ambiguous.
+	Dependencies []string `toml:"dependencies"`
+}
+
+func (lp *lockPackage) id() string {
+	return lp.Name + ":" + lp.Version
+}
+
+// Packages without a source are the packages of the workspace, or local path dependencies.
+func (lp *lockPackage) isLocal() bool {
+	return lp.Source == ""
+}
+
+// The dependencies of a single package of the workspace.
+type lockfileModule struct {
+	id           string
+	dependencies []buildinfo.Dependency
+}
+
+func parseLockfile(content []byte) (*cargoLockfile, error) {
+	lockfile := new(cargoLockfile)
+	if _, err := toml.Decode(string(content), lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	return lockfile, nil
+}
+
+// Returns the package referenced by a dependency of another package.
+func (cl *cargoLockfile) findPackage(reference string) *lockPackage {
+	fields := strings.Fields(reference)
+	if len(fields) == 0 {
+		return nil
+	}
+	for _, pkg := range cl.Packages {
+		if pkg.Name != fields[0] {
+			continue
+		}
+		if len(fields) > 1 && pkg.Version != fields[1] {
+			continue
+		}
+		if len(fields) > 2 && "("+pkg.Source+")" != fields[2] {
+			continue
+		}
+		return pkg
+	}
+	return nil
+}
+
+// Calculates the dependencies of each local package in the lockfile.
+// Local packages are saved as separate modules, and are therefore not included in the dependencies of other packages.
+func (cl *cargoLockfile) getModules() []*lockfileModule {
+	var modules []*lockfileModule
+	for _, pkg := range cl.Packages {
+		if pkg.isLocal() {
+			modules = append(modules, &lockfileModule{id: pkg.id(), dependencies: cl.getPackageDependencies(pkg)})
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].id < modules[j].id
+	})
+	return modules
+}
+
+// Walks the dependency graph of a local package.
+// Each dependency is requested by the shortest path to the package, through each of its dependents.
+func (cl *cargoLockfile) getPackageDependencies(root *lockPackage) []buildinfo.Dependency {
+	walker := buildinfoutils.NewDependencyWalker(func(pkg *lockPackage) buildinfo.Dependency {
+		return buildinfo.Dependency{Id: pkg.id(), Type: crateType, Checksum: buildinfo.Checksum{Sha256: pkg.Checksum}}
+	})
+	walker.Enqueue(root, []string{root.id()})
+	walker.Walk(func(pkg *lockPackage, pathToRoot []string) {
+		for _, reference := range pkg.Dependencies {
+			if child := cl.findPackage(reference); child != nil && !child.isLocal() {
+				walker.Visit(child, pathToRoot)
+			}
+		}
+	})
+	return walker.Dependencies()
+}
@@ -0,0 +1,104 @@
+package cargo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cargoLock = `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "common",
+ "rand 0.8.5",
+ "serde",
+]
+
+[[package]]
+name = "common"
+version = "0.2.0"
+dependencies = [
+ "rand 0.7.3",
+ "serde",
+]
+
+[[package]]
+name = "libc"
+version = "0.2.149"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "a08173bc88b7955d1b3145aa561539096c421ac8debde8cbc3612ec635fee29b"
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "6a6b1679d49b24bbfe0c803429aa1874472f50d9b363131f0e89fc356b544d03"
+dependencies = [
+ "libc",
+]
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "34af8d1a0e25924bc5b7c43c6e7b2f9e8a1b9b0d6b9b6a3c5d7bde3c8d6e5f41"
+dependencies = [
+ "libc",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.188"
+source = "git+https://github.com/serde-rs/serde?rev=abc#abc"
+`
+
+func TestCargoLockfileModules(t *testing.T) {
+	lockfile, err := parseLockfile([]byte(cargoLock))
+	require.NoError(t, err)
+	modules := lockfile.getModules()
+	require.Len(t, modules, 2)
+
+	assert.Equal(t, "app:0.1.0", modules[0].id)
+	dependencies := toDependenciesMap(modules[0].dependencies)
+	// The local common package is a separate module.
+	assert.Len(t, dependencies, 3)
+	assert.Equal(t, "34af8d1a0e25924bc5b7c43c6e7b2f9e8a1b9b0d6b9b6a3c5d7bde3c8d6e5f41", dependencies["rand:0.8.5"].Sha256)
+	assert.Equal(t, "crate", dependencies["rand:0.8.5"].Type)
+	assert.Equal(t, [][]string{{"rand:0.8.5", "app:0.1.0"}}, dependencies["libc:0.2.149"].RequestedBy)
+	// Git dependencies have no checksum.
+	assert.Empty(t, dependencies["serde:1.0.188"].Sha256)
+
+	assert.Equal(t, "common:0.2.0", modules[1].id)
+	dependencies = toDependenciesMap(modules[1].dependencies)
+	assert.Len(t, dependencies, 3)
+	assert.Contains(t, dependencies, "rand:0.7.3")
+	assert.Equal(t, [][]string{{"common:0.2.0"}}, dependencies["rand:0.7.3"].RequestedBy)
+}
+
+func TestFindPackageEmptyReference(t *testing.T) {
+	lockfile, err := parseLockfile([]byte("[[package]]\nname = \"app\"\nversion = \"0.1.0\"\ndependencies = [\"\", \" \"]\n"))
+	require.NoError(t, err)
+	assert.Nil(t, lockfile.findPackage(""))
+	modules := lockfile.getModules()
+	require.Len(t, modules, 1)
+	assert.Empty(t, modules[0].dependencies)
+}
+
+func TestParseInvalidLockfile(t *testing.T) {
+	_, err := parseLockfile([]byte("[[package]\nname = "))
+	assert.Error(t, err)
+}
+
+func toDependenciesMap(dependencies []buildinfo.Dependency) map[string]buildinfo.Dependency {
+	dependenciesMap := make(map[string]buildinfo.Dependency)
+	for _, dependency := range dependencies {
+		dependenciesMap[dependency.Id] = dependency
+	}
+	return dependenciesMap
+}
@@ -0,0 +1,251 @@
+package composer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ToolName       = "composer"
+	executableName = "composer"
+
+	ModuleType buildinfo.ModuleType = "composer"
+
+	composerAuthEnv     = "COMPOSER_AUTH"
+	composerHomeEnv     = "COMPOSER_HOME"
+	composerCacheDirEnv = "COMPOSER_CACHE_DIR"
+)
+
+// Runs 'composer install' or 'composer update', which resolves the packages from an Artifactory Composer repository instead of packagist.org.
+// The repository is added to a temporary copy of the global Composer configuration, and its credentials are added to COMPOSER_AUTH,
+// so that composer.json isn't modified. The resolved packages are collected from composer.lock into the build-info.
+type ComposerCommand struct {
+	cmdName            string
+	configFilePath     string
+	composerArgs       []string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewComposerCommand() *ComposerCommand {
+	return &ComposerCommand{}
+}
+
+func (cc *ComposerCommand) SetCmdName(cmdName string) *ComposerCommand {
+	cc.cmdName = cmdName
+	return cc
+}
+
+func (cc *ComposerCommand) SetConfigFilePath(configFilePath string) *ComposerCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *ComposerCommand) SetArgs(args []string) *ComposerCommand {
+	cc.composerArgs = args
+	return cc
+}
+
+func (cc *ComposerCommand) CommandName() string {
+	return "rt_composer_" + cc.cmdName
+}
+
+func (cc *ComposerCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the build-info options from the composer arguments.
+func (cc *ComposerCommand) Init() (err error) {
+	if cc.composerArgs, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.composerArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(cc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig == nil {
+		return errorutils.CheckErrorf("the resolver repository is missing from the config file (%s). Please run 'jf composer-config' with the --repo-resolve option", cc.configFilePath)
+	}
+	if cc.serverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	cc.repo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (cc *ComposerCommand) Run() (err error) {
+	composerHome, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(composerHome))
+	}()
+	env, err := cc.createResolverEnv(composerHome)
+	if err != nil {
+		return err
+	}
+	if err = runComposer(env, append([]string{cc.cmdName}, cc.composerArgs...)...); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return cc.collectDependencies()
+}
+
+// Returns the environment variables, which configure composer to resolve the packages from the repository.
+// The temporary Composer home includes the global configuration of the user, and the cache directory of the user is kept.
+func (cc *ComposerCommand) createResolverEnv(composerHome string) ([]string, error) {
+	userHome, err := getComposerConfig(composerHomeEnv, "home")
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := getComposerConfig(composerCacheDirEnv, "cache-dir")
+	if err != nil {
+		return nil, err
+	}
+	if err = writeGlobalConfig(filepath.Join(userHome, "config.json"), composerHome, repositoryUrl(cc.serverDetails, cc.repo)); err != nil {
+		return nil, err
+	}
+	composerAuth, err := createComposerAuth(os.Getenv(composerAuthEnv), cc.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	return []string{composerHomeEnv + "=" + composerHome, composerCacheDirEnv + "=" + cacheDir, composerAuthEnv + "=" + composerAuth}, nil
+}
+
+// Returns the URL of an Artifactory Composer repository.
+func repositoryUrl(serverDetails *config.ServerDetails, repo string) string {
+	return strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/composer/" + repo
+}
+
+// Returns the value of a global composer configuration, from its environment variable or from composer.
+func getComposerConfig(envName, key string) (string, error) {
+	if value := os.Getenv(envName); value != "" {
+		return value, nil
+	}
+	output, err := exec.Command(executableName, "config", "--global", key).Output()
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to get the composer %s configuration: %s", key, err.Error())
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Writes the global configuration of the user into the temporary Composer home, with the repository replacing packagist.org.
+func writeGlobalConfig(userConfigPath, composerHome, repoUrl string) error {
+	globalConfig := make(map[string]interface{})
+	content, err := os.ReadFile(userConfigPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	if err == nil {
+		if err = json.Unmarshal(content, &globalConfig); err != nil {
+			return errorutils.CheckErrorf("failed to parse the global composer configuration %s: %s", userConfigPath, err.Error())
+		}
+	}
+	globalConfig["repositories"] = []interface{}{
+		map[string]interface{}{"type": "composer", "url": repoUrl},
+		map[string]interface{}{"packagist.org": false},
+	}
+	if content, err = json.MarshalIndent(globalConfig, "", "    "); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(composerHome, "config.json"), content, 0644))
+}
+
+// Adds the credentials of the server to the COMPOSER_AUTH json of the user.
+// When only an access token is configured, it's used as a bearer token.
+func createComposerAuth(userAuth string, serverDetails *config.ServerDetails) (string, error) {
+	composerAuth := make(map[string]map[string]interface{})
+	if userAuth != "" {
+		if err := json.Unmarshal([]byte(userAuth), &composerAuth); err != nil {
+			return "", errorutils.CheckErrorf("failed to parse %s: %s", composerAuthEnv, err.Error())
+		}
+	}
+	parsedUrl, err := url.Parse(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	host := parsedUrl.Host
+	addAuth := func(authType string, value interface{}) {
+		if composerAuth[authType] == nil {
+			composerAuth[authType] = make(map[string]interface{})
+		}
+		composerAuth[authType][host] = value
+	}
+	switch {
+	case serverDetails.GetAccessToken() != "" && serverDetails.GetPassword() == "":
+		addAuth("bearer", serverDetails.GetAccessToken())
+	case serverDetails.GetPassword() != "":
+		addAuth("http-basic", map[string]string{"username": serverDetails.GetUser(), "password": serverDetails.GetPassword()})
+	case serverDetails.SshKeyPath != "":
+		return "", errorutils.CheckErrorf("SSH authentication is not supported in this command")
+	}
+	content, err := json.Marshal(composerAuth)
+	return string(content), errorutils.CheckError(err)
+}
+
+// Collects the locked packages of the project. The package, or the project directory, is the build-info module.
+func (cc *ComposerCommand) collectDependencies() error {
+	projectDir, exists, err := fileutils.FindUpstream(ManifestName, fileutils.File)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("could not find %s in the working directory or in its parent directories", ManifestName)
+	}
+	content, err := os.ReadFile(filepath.Join(projectDir, ManifestName))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	manifest, err := parseManifest(content)
+	if err != nil {
+		return err
+	}
+	content, err = os.ReadFile(filepath.Join(projectDir, LockfileName))
+	if os.IsNotExist(err) {
+		log.Warn(LockfileName + " was not found, and therefore the dependencies are not included in the build-info.")
+		return nil
+	}
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockfile, err := parseLockfile(content)
+	if err != nil {
+		return err
+	}
+	moduleId := manifest.moduleId(filepath.Base(projectDir))
+	return buildinfoutils.SaveDependencies(cc.buildConfiguration, moduleId, ModuleType, lockfile.getDependencies(manifest, moduleId))
+}
+
+func runComposer(env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the composer executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
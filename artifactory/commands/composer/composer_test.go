@@ -0,0 +1,46 @@
+package composer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGlobalConfig(t *testing.T) {
+	userHome, composerHome := t.TempDir(), t.TempDir()
+	userConfigPath := filepath.Join(userHome, "config.json")
+	require.NoError(t, os.WriteFile(userConfigPath, []byte(`{"config": {"process-timeout": 600}, "repositories": [{"type": "vcs", "url": "https://github.com/acme/lib"}]}`), 0644))
+	require.NoError(t, writeGlobalConfig(userConfigPath, composerHome, "https://acme.jfrog.io/artifactory/api/composer/php-virtual"))
+
+	content, err := os.ReadFile(filepath.Join(composerHome, "config.json"))
+	require.NoError(t, err)
+	var globalConfig map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &globalConfig))
+	assert.Equal(t, map[string]interface{}{"process-timeout": float64(600)}, globalConfig["config"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "composer", "url": "https://acme.jfrog.io/artifactory/api/composer/php-virtual"},
+		map[string]interface{}{"packagist.org": false},
+	}, globalConfig["repositories"])
+
+	// A missing global configuration of the user is allowed.
+	require.NoError(t, writeGlobalConfig(filepath.Join(userHome, "missing.json"), composerHome, "https://acme.jfrog.io/artifactory/api/composer/php-virtual"))
+}
+
+func TestCreateComposerAuth(t *testing.T) {
+	userAuth := `{"github-oauth": {"github.com": "gh-token"}}`
+	composerAuth, err := createComposerAuth(userAuth, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "pass"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"github-oauth": {"github.com": "gh-token"}, "http-basic": {"acme.jfrog.io": {"username": "user", "password": "pass"}}}`, composerAuth)
+
+	composerAuth, err = createComposerAuth("", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bearer": {"acme.jfrog.io": "token"}}`, composerAuth)
+
+	_, err = createComposerAuth("not json", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"})
+	assert.Error(t, err)
+}
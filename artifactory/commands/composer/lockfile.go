@@ -0,0 +1,120 @@
+package composer
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	ManifestName   = "composer.json"
+	LockfileName   = "composer.lock"
+	dependencyType = "composer"
+)
+
+// The fields of composer.json which are used by the commands.
+type composerManifest struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// Returns the module ID of the package, or the default module ID if the package has no name.
+func (cm *composerManifest) moduleId(defaultModuleId string) string {
+	switch {
+	case cm.Name == "":
+		return defaultModuleId
+	case cm.Version == "":
+		return cm.Name
+	}
+	return cm.Name + ":" + cm.Version
+}
+
+type composerLockfile struct {
+	Packages    []*lockedPackage `json:"packages"`
+	PackagesDev []*lockedPackage `json:"packages-dev"`
+}
+
+type lockedPackage struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Require map[string]string `json:"require"`
+	Dist    struct {
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+}
+
+func (lp *lockedPackage) id() string {
+	return lp.Name + ":" + lp.Version
+}
+
+func parseManifest(content []byte) (*composerManifest, error) {
+	manifest := new(composerManifest)
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", ManifestName, err.Error())
+	}
+	return manifest, nil
+}
+
+func parseLockfile(content []byte) (*composerLockfile, error) {
+	lockfile := new(composerLockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	return lockfile, nil
+}
+
+// Platform requirements, such as php, ext-json and composer-plugin-api, aren't vendor packages.
+func isPlatformPackage(name string) bool {
+	return !strings.Contains(name, "/")
+}
+
+// Walks the dependency graph from the requirements of the manifest.
+// The packages required by 'require' have the prod scope, and the packages which are only required by 'require-dev' have the dev scope.
+// Each dependency is requested by the shortest path to the module, through each of its dependents.
+func (cl *composerLockfile) getDependencies(manifest *composerManifest, moduleId string) []buildinfo.Dependency {
+	packages := make(map[string]*lockedPackage)
+	for _, pkg := range append(append([]*lockedPackage{}, cl.Packages...), cl.PackagesDev...) {
+		packages[strings.ToLower(pkg.Name)] = pkg
+	}
+	var scope string
+	walker := buildinfoutils.NewDependencyWalker(func(pkg *lockedPackage) buildinfo.Dependency {
+		dependency := buildinfo.Dependency{Id: pkg.id(), Type: dependencyType, Scopes: []string{scope}}
+		if pkg.Dist.Shasum != "" {
+			dependency.Checksum = buildinfo.Checksum{Sha1: pkg.Dist.Shasum}
+		}
+		return dependency
+	})
+	visit := func(require map[string]string, pathToRoot []string) {
+		for _, name := range sortedKeys(require) {
+			if pkg := packages[strings.ToLower(name)]; pkg != nil && !isPlatformPackage(name) {
+				walker.Visit(pkg, pathToRoot)
+			}
+		}
+	}
+	for _, scopeRequire := range []struct {
+		name    string
+		require map[string]string
+	}{{"prod", manifest.Require}, {"dev", manifest.RequireDev}} {
+		scope = scopeRequire.name
+		visit(scopeRequire.require, []string{moduleId})
+		walker.Walk(func(pkg *lockedPackage, pathToRoot []string) {
+			visit(pkg.Require, pathToRoot)
+		})
+	}
+	return walker.Dependencies()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
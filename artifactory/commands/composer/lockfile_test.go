@@ -0,0 +1,91 @@
+package composer
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `{
+    "name": "acme/app",
+    "version": "1.2.0",
+    "require": {
+        "php": ">=8.1",
+        "ext-json": "*",
+        "Monolog/Monolog": "^3.0"
+    },
+    "require-dev": {
+        "phpunit/phpunit": "^10.0"
+    }
+}`
+
+const testLockfile = `{
+    "packages": [
+        {
+            "name": "monolog/monolog",
+            "version": "3.5.0",
+            "require": {"php": ">=8.1", "psr/log": "^2.0 || ^3.0"},
+            "dist": {"type": "zip", "shasum": "c915e2634718dbc8a4a15c61b0e62e7a44e14448"}
+        },
+        {
+            "name": "psr/log",
+            "version": "3.0.0",
+            "dist": {"type": "zip", "shasum": ""}
+        }
+    ],
+    "packages-dev": [
+        {
+            "name": "phpunit/phpunit",
+            "version": "10.5.9",
+            "require": {"psr/log": "^3.0", "sebastian/diff": "^5.0"}
+        },
+        {
+            "name": "sebastian/diff",
+            "version": "5.1.0"
+        }
+    ]
+}`
+
+func TestGetDependencies(t *testing.T) {
+	manifest, err := parseManifest([]byte(testManifest))
+	require.NoError(t, err)
+	lockfile, err := parseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	moduleId := manifest.moduleId("app")
+	assert.Equal(t, "acme/app:1.2.0", moduleId)
+
+	assert.Equal(t, []buildinfo.Dependency{
+		{
+			Id:          "monolog/monolog:3.5.0",
+			Type:        dependencyType,
+			Scopes:      []string{"prod"},
+			Checksum:    buildinfo.Checksum{Sha1: "c915e2634718dbc8a4a15c61b0e62e7a44e14448"},
+			RequestedBy: [][]string{{moduleId}},
+		},
+		{
+			Id:          "psr/log:3.0.0",
+			Type:        dependencyType,
+			Scopes:      []string{"prod"},
+			RequestedBy: [][]string{{"monolog/monolog:3.5.0", moduleId}, {"phpunit/phpunit:10.5.9", moduleId}},
+		},
+		{
+			Id:          "phpunit/phpunit:10.5.9",
+			Type:        dependencyType,
+			Scopes:      []string{"dev"},
+			RequestedBy: [][]string{{moduleId}},
+		},
+		{
+			Id:          "sebastian/diff:5.1.0",
+			Type:        dependencyType,
+			Scopes:      []string{"dev"},
+			RequestedBy: [][]string{{"phpunit/phpunit:10.5.9", moduleId}},
+		},
+	}, lockfile.getDependencies(manifest, moduleId))
+}
+
+func TestModuleId(t *testing.T) {
+	assert.Equal(t, "project-dir", (&composerManifest{}).moduleId("project-dir"))
+	assert.Equal(t, "acme/app", (&composerManifest{Name: "acme/app"}).moduleId("project-dir"))
+}
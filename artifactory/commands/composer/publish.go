@@ -0,0 +1,183 @@
+package composer
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The property from which Artifactory reads the version of a package, whose composer.json has no version.
+const versionPropKey = "composer.version"
+
+// Packs the project with 'composer archive' and deploys the archive to an Artifactory Composer repository, which indexes it.
+// The version is taken from composer.json, or from the --version option.
+type ComposerPublishCommand struct {
+	configFilePath     string
+	composerArgs       []string
+	version            string
+	detailedSummary    bool
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+	result             *commandsutils.Result
+}
+
+func NewComposerPublishCommand() *ComposerPublishCommand {
+	return &ComposerPublishCommand{result: new(commandsutils.Result)}
+}
+
+func (cpc *ComposerPublishCommand) SetConfigFilePath(configFilePath string) *ComposerPublishCommand {
+	cpc.configFilePath = configFilePath
+	return cpc
+}
+
+func (cpc *ComposerPublishCommand) SetArgs(args []string) *ComposerPublishCommand {
+	cpc.composerArgs = args
+	return cpc
+}
+
+func (cpc *ComposerPublishCommand) SetDetailedSummary(detailedSummary bool) *ComposerPublishCommand {
+	cpc.detailedSummary = detailedSummary
+	return cpc
+}
+
+func (cpc *ComposerPublishCommand) IsDetailedSummary() bool {
+	return cpc.detailedSummary
+}
+
+func (cpc *ComposerPublishCommand) Result() *commandsutils.Result {
+	return cpc.result
+}
+
+func (cpc *ComposerPublishCommand) CommandName() string {
+	return "rt_composer_publish"
+}
+
+func (cpc *ComposerPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cpc.serverDetails, nil
+}
+
+// Reads the deployer configuration and extracts the JFrog CLI options from the arguments.
+func (cpc *ComposerPublishCommand) Init() (err error) {
+	if cpc.composerArgs, cpc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cpc.composerArgs); err != nil {
+		return err
+	}
+	if cpc.composerArgs, cpc.detailedSummary, err = coreutils.ExtractDetailedSummaryFromArgs(cpc.composerArgs); err != nil {
+		return err
+	}
+	if cpc.composerArgs, cpc.version, err = coreutils.ExtractStringOptionFromArgs(cpc.composerArgs, "version"); err != nil {
+		return err
+	}
+	if len(cpc.composerArgs) > 0 {
+		return errorutils.CheckErrorf("unexpected arguments: %v. Usage: jf composer publish [--version=<version>]", cpc.composerArgs)
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(cpc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig == nil {
+		return errorutils.CheckErrorf("the deployer repository is missing from the config file (%s). Please run 'jf composer-config' with the --repo-deploy option", cpc.configFilePath)
+	}
+	if cpc.serverDetails, err = deployerConfig.ServerDetails(); err != nil {
+		return err
+	}
+	cpc.repo = deployerConfig.TargetRepo()
+	return nil
+}
+
+func (cpc *ComposerPublishCommand) Run() (err error) {
+	content, err := os.ReadFile(ManifestName)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read %s from the working directory: %s", ManifestName, err.Error())
+	}
+	manifest, err := parseManifest(content)
+	if err != nil {
+		return err
+	}
+	if manifest.Name == "" {
+		return errorutils.CheckErrorf("the package name is missing from %s", ManifestName)
+	}
+	if cpc.version == "" {
+		cpc.version = manifest.Version
+	}
+	if cpc.version == "" {
+		return errorutils.CheckErrorf("the package version is missing from %s. Please provide it with the --version option", ManifestName)
+	}
+	archiveDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(archiveDir))
+	}()
+	archiveName := path.Base(manifest.Name) + "-" + cpc.version
+	if err = runComposer(nil, "archive", "--format=zip", "--dir="+archiveDir, "--file="+archiveName); err != nil {
+		return err
+	}
+	manifest.Version = cpc.version
+	return cpc.deploy(filepath.Join(archiveDir, archiveName+".zip"), manifest)
+}
+
+// Deploys the archive to <repo>/<vendor>/<name>/<name>-<version>.zip.
+func (cpc *ComposerPublishCommand) deploy(archivePath string, manifest *composerManifest) (err error) {
+	collectBuildInfo, err := cpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(cpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	up := services.NewUploadParams()
+	up.CommonParams = &specutils.CommonParams{Pattern: archivePath, Target: cpc.repo + "/" + manifest.Name + "/" + filepath.Base(archivePath)}
+	if up.TargetProps, err = specutils.ParseProperties(versionPropKey + "=" + cpc.version); err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if up.BuildProps, err = build.CreateBuildPropsFromConfiguration(cpc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	log.Info("Deploying the composer package to", up.Target)
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, up)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close())
+	}()
+	cpc.result.SetFailCount(summary.TotalFailed)
+	cpc.result.SetSuccessCount(summary.TotalSucceeded)
+	if cpc.detailedSummary {
+		cpc.result.SetReader(summary.TransferDetailsReader)
+	} else if err = summary.TransferDetailsReader.Close(); err != nil {
+		return err
+	}
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to upload the composer package to Artifactory. See Artifactory logs for more details")
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveArtifacts(cpc.buildConfiguration, manifest.moduleId(""), ModuleType, artifacts)
+}
@@ -0,0 +1,405 @@
+package conan
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	biutils "github.com/jfrog/build-info-go/utils"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ToolName       = "conan"
+	executableName = "conan"
+
+	ModuleType buildinfo.ModuleType = "conan"
+
+	// The names of the remotes configured by the command.
+	resolverRemoteName = "jfrog"
+	deployerRemoteName = "jfrog-deploy"
+
+	conanHomeEnv = "CONAN_HOME"
+	// The package storage of the Conan home, which is shared with the temporary Conan home rather than copied.
+	storageDirName  = "p"
+	globalConfName  = "global.conf"
+	storagePathConf = "core.cache:storage_path"
+)
+
+// Runs 'conan install', 'conan create' or 'conan upload' with Artifactory Conan remotes.
+// The resolver and deployer repositories are added to the remotes of a temporary Conan home, which is a copy of the configuration of the user's Conan home,
+// and shares its package storage. The credentials are passed to Conan by environment variables.
+// The dependencies are collected from the json graph printed by Conan, or from the lockfile if another output format was requested.
+// The uploaded recipes and packages are recorded as artifacts.
+type ConanCommand struct {
+	cmdName            string
+	configFilePath     string
+	conanArgs          []string
+	resolverDetails    *config.ServerDetails
+	resolverRepo       string
+	deployerDetails    *config.ServerDetails
+	deployerRepo       string
+	detailedSummary    bool
+	buildConfiguration *build.BuildConfiguration
+	result             *commandsutils.Result
+}
+
+func NewConanCommand() *ConanCommand {
+	return &ConanCommand{result: new(commandsutils.Result)}
+}
+
+func (cc *ConanCommand) SetCmdName(cmdName string) *ConanCommand {
+	cc.cmdName = cmdName
+	return cc
+}
+
+func (cc *ConanCommand) SetConfigFilePath(configFilePath string) *ConanCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *ConanCommand) SetArgs(args []string) *ConanCommand {
+	cc.conanArgs = args
+	return cc
+}
+
+func (cc *ConanCommand) IsDetailedSummary() bool {
+	return cc.detailedSummary
+}
+
+func (cc *ConanCommand) SetDetailedSummary(detailedSummary bool) *ConanCommand {
+	cc.detailedSummary = detailedSummary
+	return cc
+}
+
+func (cc *ConanCommand) Result() *commandsutils.Result {
+	return cc.result
+}
+
+func (cc *ConanCommand) CommandName() string {
+	return "rt_conan_" + cc.cmdName
+}
+
+func (cc *ConanCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.isUpload() {
+		return cc.deployerDetails, nil
+	}
+	return cc.resolverDetails, nil
+}
+
+// Reads the resolver and deployer configuration and extracts the build-info and summary options from the conan arguments.
+func (cc *ConanCommand) Init() (err error) {
+	if cc.conanArgs, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.conanArgs); err != nil {
+		return err
+	}
+	if cc.conanArgs, cc.detailedSummary, err = coreutils.ExtractDetailedSummaryFromArgs(cc.conanArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(cc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig != nil {
+		if cc.resolverDetails, err = resolverConfig.ServerDetails(); err != nil {
+			return err
+		}
+		cc.resolverRepo = resolverConfig.TargetRepo()
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(cc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig != nil {
+		if cc.deployerDetails, err = deployerConfig.ServerDetails(); err != nil {
+			return err
+		}
+		cc.deployerRepo = deployerConfig.TargetRepo()
+	}
+	if cc.isUpload() && cc.deployerDetails == nil {
+		return errorutils.CheckErrorf("the deployer repository is missing from the config file (%s). Please run 'jf conan-config' with the --repo-deploy option", cc.configFilePath)
+	}
+	return nil
+}
+
+func (cc *ConanCommand) isUpload() bool {
+	return cc.cmdName == "upload"
+}
+
+func (cc *ConanCommand) Run() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	args, env, err := cc.prepareConanArgs(tempDir)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	// The json output is needed for the build-info, and for the summary of the uploaded files.
+	captureOutput := collectBuildInfo || cc.isUpload()
+	userFormat := getFlagValue(cc.conanArgs, "-f", "--format")
+	if captureOutput && userFormat == "" {
+		args = append(args, "--format=json")
+	}
+	captureOutput = captureOutput && (userFormat == "" || userFormat == "json")
+	var output []byte
+	if output, err = runConan(env, captureOutput, args...); err != nil {
+		return err
+	}
+	if userFormat == "json" {
+		// The output was requested by the user.
+		if _, err = os.Stdout.Write(output); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	if cc.isUpload() {
+		if !captureOutput {
+			log.Warn("The uploaded files can't be collected with the '" + userFormat + "' output format, and therefore are not included in the build-info.")
+			return nil
+		}
+		return cc.collectUploadedFiles(output)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	return cc.collectDependencies(output)
+}
+
+// Returns the conan arguments, and the environment variables of the temporary Conan home and of the remote credentials.
+// The Artifactory remotes are added to the remotes of the temporary Conan home, and are used by the command, unless another remote was requested.
+func (cc *ConanCommand) prepareConanArgs(tempDir string) (args, env []string, err error) {
+	args = append([]string{cc.cmdName}, cc.conanArgs...)
+	if cc.resolverDetails == nil && !cc.isUpload() {
+		return args, nil, nil
+	}
+	homeEnv, err := createConanHome(tempDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	env = []string{homeEnv}
+	userRemote := getFlagValue(cc.conanArgs, "-r", "--remote") != ""
+	if cc.resolverDetails != nil {
+		resolverEnv, err := addRemote(homeEnv, resolverRemoteName, cc.resolverDetails, cc.resolverRepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		env = append(env, resolverEnv...)
+		if !cc.isUpload() && !userRemote {
+			args = append(args, "--remote", resolverRemoteName)
+		}
+	}
+	if cc.isUpload() && !userRemote {
+		deployerEnv, err := addRemote(homeEnv, deployerRemoteName, cc.deployerDetails, cc.deployerRepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		env = append(env, deployerEnv...)
+		args = append(args, "--remote", deployerRemoteName)
+	}
+	return args, env, nil
+}
+
+// Creates a Conan home in the temporary directory, with the configuration of the user's Conan home, so the remotes of the user's Conan home
+// aren't changed by the command. The temporary Conan home uses the package storage of the user's Conan home.
+// Returns the environment variable which sets the temporary Conan home.
+func createConanHome(tempDir string) (string, error) {
+	output, err := runConan(nil, true, "config", "home")
+	if err != nil {
+		return "", err
+	}
+	userHome := strings.TrimSpace(string(output))
+	conanHome := filepath.Join(tempDir, "conan-home")
+	if err = writeConanHome(userHome, conanHome); err != nil {
+		return "", err
+	}
+	return conanHomeEnv + "=" + conanHome, nil
+}
+
+// Copies the configuration of the user's Conan home to the Conan home, and sets the package storage of the user's Conan home in its global.conf,
+// unless the storage is already set there.
+func writeConanHome(userHome, conanHome string) error {
+	exists, err := fileutils.IsDirExists(userHome, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err = errorutils.CheckError(biutils.CopyDir(userHome, conanHome, true, []string{storageDirName})); err != nil {
+			return err
+		}
+	} else if err = errorutils.CheckError(os.MkdirAll(conanHome, 0700)); err != nil {
+		return err
+	}
+	globalConfPath := filepath.Join(conanHome, globalConfName)
+	globalConf, err := os.ReadFile(globalConfPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	if strings.Contains(string(globalConf), storagePathConf) {
+		return nil
+	}
+	if len(globalConf) > 0 && !bytes.HasSuffix(globalConf, []byte("\n")) {
+		globalConf = append(globalConf, '\n')
+	}
+	globalConf = append(globalConf, storagePathConf+"="+filepath.Join(userHome, storageDirName)+"\n"...)
+	return errorutils.CheckError(os.WriteFile(globalConfPath, globalConf, 0600))
+}
+
+// Adds a conan remote of an Artifactory Conan repository to the Conan home. Returns the environment variables of the remote credentials.
+func addRemote(homeEnv, remoteName string, serverDetails *config.ServerDetails, repo string) ([]string, error) {
+	if _, err := runConan([]string{homeEnv}, true, "remote", "add", remoteName, remoteUrl(serverDetails, repo), "--force"); err != nil {
+		return nil, err
+	}
+	return remoteCredentialsEnv(remoteName, serverDetails)
+}
+
+func remoteUrl(serverDetails *config.ServerDetails, repo string) string {
+	return strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/conan/" + repo
+}
+
+// Conan reads the credentials of a remote from the CONAN_LOGIN_USERNAME_<REMOTE> and CONAN_PASSWORD_<REMOTE> environment variables.
+// When only an access token is configured, the username is extracted from the token.
+func remoteCredentialsEnv(remoteName string, serverDetails *config.ServerDetails) ([]string, error) {
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil || password == "" {
+		return nil, err
+	}
+	envSuffix := strings.ToUpper(strings.ReplaceAll(remoteName, "-", "_"))
+	return []string{"CONAN_LOGIN_USERNAME_" + envSuffix + "=" + username, "CONAN_PASSWORD_" + envSuffix + "=" + password}, nil
+}
+
+// Returns the value of a flag, or an empty string if the flag isn't provided.
+func getFlagValue(args []string, flags ...string) string {
+	for i, arg := range args {
+		for _, flag := range flags {
+			if arg == flag && i+1 < len(args) {
+				return args[i+1]
+			}
+			if strings.HasPrefix(arg, flag+"=") {
+				return strings.TrimPrefix(arg, flag+"=")
+			}
+		}
+	}
+	return ""
+}
+
+// Collects the dependencies from the json graph, or from the lockfile if the graph wasn't captured.
+func (cc *ConanCommand) collectDependencies(output []byte) error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	module := &conanModule{id: filepath.Base(workingDir)}
+	if output != nil {
+		graph, err := parseGraph(output)
+		if err != nil {
+			return err
+		}
+		module = graph.getModule(cc.cmdName == "create", module.id)
+	} else {
+		lockfilePath := getFlagValue(cc.conanArgs, "--lockfile-out")
+		if lockfilePath == "" {
+			lockfilePath = LockfileName
+		}
+		lockfile, err := os.ReadFile(lockfilePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Warn("The dependencies can't be collected with the requested output format, and " + LockfileName + " was not found. Therefore, the dependencies are not included in the build-info.")
+				return nil
+			}
+			return errorutils.CheckError(err)
+		}
+		if module.dependencies, err = parseLockfile(lockfile); err != nil {
+			return err
+		}
+	}
+	return buildinfoutils.SaveDependencies(cc.buildConfiguration, module.id, ModuleType, module.dependencies)
+}
+
+// Records the uploaded files of each recipe as artifacts, and sets the result of the command.
+func (cc *ConanCommand) collectUploadedFiles(output []byte) error {
+	modules, err := parseUploadedList(output)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	var transferDetails []clientutils.FileTransferDetails
+	for _, module := range modules {
+		artifacts, err := buildinfoutils.GetDeployedArtifacts(cc.deployerDetails, cc.buildConfiguration, cc.deployerRepo, module.paths...)
+		if err != nil {
+			return err
+		}
+		for _, artifact := range artifacts {
+			transferDetails = append(transferDetails, clientutils.FileTransferDetails{
+				TargetPath: path.Join(artifact.OriginalDeploymentRepo, artifact.Path),
+				RtUrl:      cc.deployerDetails.GetArtifactoryUrl(),
+				Sha256:     artifact.Sha256,
+			})
+		}
+		if collectBuildInfo {
+			if err = buildinfoutils.SaveArtifacts(cc.buildConfiguration, module.id, ModuleType, artifacts); err != nil {
+				return err
+			}
+		}
+	}
+	cc.result.SetSuccessCount(len(transferDetails))
+	if !cc.detailedSummary {
+		return nil
+	}
+	tempFile, err := clientutils.SaveFileTransferDetailsInTempFile(&transferDetails)
+	if err != nil {
+		return err
+	}
+	cc.result.SetReader(content.NewContentReader(tempFile, "files"))
+	return nil
+}
+
+// Runs conan. If captureOutput is true, the standard output is returned instead of being printed.
+func runConan(env []string, captureOutput bool, args ...string) ([]byte, error) {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("could not find the conan executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	output := new(bytes.Buffer)
+	if captureOutput {
+		cmd.Stdout = output
+	} else {
+		cmd.Stdout = os.Stdout
+	}
+	if err = cmd.Run(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if !captureOutput {
+		return nil, nil
+	}
+	return output.Bytes(), nil
+}
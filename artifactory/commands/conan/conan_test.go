@@ -0,0 +1,58 @@
+package conan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteCredentialsEnv(t *testing.T) {
+	env, err := remoteCredentialsEnv("jfrog-deploy", &config.ServerDetails{User: "user", Password: "pass"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CONAN_LOGIN_USERNAME_JFROG_DEPLOY=user", "CONAN_PASSWORD_JFROG_DEPLOY=pass"}, env)
+
+	env, err = remoteCredentialsEnv("jfrog", &config.ServerDetails{User: "admin", AccessToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CONAN_LOGIN_USERNAME_JFROG=admin", "CONAN_PASSWORD_JFROG=token"}, env)
+
+	env, err = remoteCredentialsEnv("jfrog", &config.ServerDetails{})
+	require.NoError(t, err)
+	assert.Empty(t, env)
+}
+
+func TestGetFlagValue(t *testing.T) {
+	assert.Equal(t, "json", getFlagValue([]string{".", "-f", "json"}, "-f", "--format"))
+	assert.Equal(t, "html", getFlagValue([]string{"--format=html", "."}, "-f", "--format"))
+	assert.Empty(t, getFlagValue([]string{"."}, "-r", "--remote"))
+}
+
+func TestWriteConanHome(t *testing.T) {
+	userHome := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(userHome, "profiles"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(userHome, storageDirName), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(userHome, "profiles", "default"), []byte("[settings]\nos=Linux\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(userHome, storageDirName, "cache.sqlite3"), []byte("db"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(userHome, globalConfName), []byte("core:non_interactive=True"), 0644))
+
+	conanHome := filepath.Join(t.TempDir(), "conan-home")
+	require.NoError(t, writeConanHome(userHome, conanHome))
+	assert.FileExists(t, filepath.Join(conanHome, "profiles", "default"))
+	// The package storage is shared rather than copied.
+	assert.NoDirExists(t, filepath.Join(conanHome, storageDirName))
+	globalConf, err := os.ReadFile(filepath.Join(conanHome, globalConfName))
+	require.NoError(t, err)
+	assert.Equal(t, "core:non_interactive=True\n"+storagePathConf+"="+filepath.Join(userHome, storageDirName)+"\n", string(globalConf))
+	// The user's Conan home isn't changed.
+	globalConf, err = os.ReadFile(filepath.Join(userHome, globalConfName))
+	require.NoError(t, err)
+	assert.Equal(t, "core:non_interactive=True", string(globalConf))
+
+	// A Conan home which doesn't exist yet.
+	conanHome = filepath.Join(t.TempDir(), "conan-home")
+	require.NoError(t, writeConanHome(filepath.Join(t.TempDir(), "missing"), conanHome))
+	assert.FileExists(t, filepath.Join(conanHome, globalConfName))
+}
@@ -0,0 +1,190 @@
+package conan
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	LockfileName   = "conan.lock"
+	dependencyType = "conan"
+	// The binary status of packages, which are in the graph but aren't needed by the build.
+	skippedBinary = "Skip"
+)
+
+// The dependency graph printed by 'conan install' and 'conan create' with the json format.
+type conanGraph struct {
+	Graph struct {
+		Nodes map[string]*graphNode `json:"nodes"`
+		Root  map[string]string     `json:"root"`
+	} `json:"graph"`
+}
+
+type graphNode struct {
+	// The reference of the recipe, including its revision.
+	Ref       string `json:"ref"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	User      string `json:"user"`
+	Channel   string `json:"channel"`
+	Rrev      string `json:"rrev"`
+	PackageId string `json:"package_id"`
+	Prev      string `json:"prev"`
+	Context   string `json:"context"`
+	Binary    string `json:"binary"`
+	// The transitive dependencies of the node, by node ID.
+	Dependencies map[string]struct {
+		Direct bool `json:"direct"`
+	} `json:"dependencies"`
+}
+
+// Returns the recipe reference without the revision, e.g. zlib/1.3 or mylib/1.0@acme/stable.
+func (gn *graphNode) recipeRef() string {
+	return recipeRef(gn.Name, gn.Version, gn.User, gn.Channel)
+}
+
+// Returns the full package reference, including the recipe revision, the package ID and the package revision.
+func (gn *graphNode) packageRef() string {
+	ref := gn.recipeRef()
+	if gn.Rrev != "" {
+		ref += "#" + gn.Rrev
+	}
+	if gn.PackageId != "" {
+		ref += ":" + gn.PackageId
+		if gn.Prev != "" {
+			ref += "#" + gn.Prev
+		}
+	}
+	return ref
+}
+
+func (gn *graphNode) directDependencies() []string {
+	var ids []string
+	for id, dependency := range gn.Dependencies {
+		if dependency.Direct {
+			ids = append(ids, id)
+		}
+	}
+	// Node IDs are numeric, and are sorted by their order in the graph.
+	sort.Slice(ids, func(i, j int) bool {
+		first, _ := strconv.Atoi(ids[i])
+		second, _ := strconv.Atoi(ids[j])
+		return first < second
+	})
+	return ids
+}
+
+func recipeRef(name, version, user, channel string) string {
+	ref := name + "/" + version
+	if user != "" {
+		ref += "@" + user + "/" + channel
+	}
+	return ref
+}
+
+// The dependencies of a single module.
+type conanModule struct {
+	id           string
+	dependencies []buildinfo.Dependency
+}
+
+func parseGraph(content []byte) (*conanGraph, error) {
+	graph := new(conanGraph)
+	if err := json.Unmarshal(content, graph); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the conan graph: %s", err.Error())
+	}
+	if len(graph.Graph.Nodes) == 0 {
+		return nil, errorutils.CheckErrorf("the conan graph is empty")
+	}
+	return graph, nil
+}
+
+func (cg *conanGraph) rootId() string {
+	for id := range cg.Graph.Root {
+		return id
+	}
+	return "0"
+}
+
+// Returns the module of the graph.
+// If the root of the graph is a named recipe, it's the module. Otherwise, the root is a conanfile.txt or the virtual node of 'conan create',
+// and the module is the recipe created by the command, or the default module ID if nothing was created.
+func (cg *conanGraph) getModule(created bool, defaultModuleId string) *conanModule {
+	rootId := cg.rootId()
+	root := cg.Graph.Nodes[rootId]
+	switch {
+	case root != nil && root.Name != "":
+		return &conanModule{id: root.recipeRef(), dependencies: cg.getDependencies(rootId)}
+	case root != nil && created:
+		for _, id := range root.directDependencies() {
+			if node := cg.Graph.Nodes[id]; node != nil && node.Context != "build" {
+				return &conanModule{id: node.recipeRef(), dependencies: cg.getDependencies(id)}
+			}
+		}
+	}
+	return &conanModule{id: defaultModuleId, dependencies: cg.getDependencies(rootId)}
+}
+
+// Walks the graph from a node. Each dependency is requested by the shortest path to the node, through each of its dependents.
+// The nodes are identified by their recipe reference in the requested-by paths.
+func (cg *conanGraph) getDependencies(rootId string) []buildinfo.Dependency {
+	walker := buildinfoutils.NewDependencyWalker(func(id string) buildinfo.Dependency {
+		node := cg.Graph.Nodes[id]
+		return buildinfo.Dependency{Id: node.packageRef(), Type: dependencyType, Scopes: getScopes(node.Context)}
+	}).SetPathId(func(id string, _ *buildinfo.Dependency) string {
+		return cg.Graph.Nodes[id].recipeRef()
+	})
+	var rootPath []string
+	if root := cg.Graph.Nodes[rootId]; root.Name != "" {
+		rootPath = []string{root.recipeRef()}
+	}
+	// The children of a conanfile.txt or a virtual node, which isn't a dependency, aren't requested by it.
+	walker.Enqueue(rootId, rootPath)
+	walker.Walk(func(id string, pathToRoot []string) {
+		for _, childId := range cg.Graph.Nodes[id].directDependencies() {
+			if child := cg.Graph.Nodes[childId]; child != nil && child.Binary != skippedBinary {
+				walker.Visit(childId, pathToRoot)
+			}
+		}
+	})
+	return walker.Dependencies()
+}
+
+func getScopes(context string) []string {
+	if context == "" {
+		return nil
+	}
+	return []string{context}
+}
+
+// The Conan 2 lockfile. Each reference includes the recipe revision and timestamp, e.g. zlib/1.3#<revision>%<timestamp>.
+type conanLockfile struct {
+	Requires       []string `json:"requires"`
+	BuildRequires  []string `json:"build_requires"`
+	PythonRequires []string `json:"python_requires"`
+}
+
+// Returns the dependencies locked in a lockfile. The lockfile doesn't include the package IDs and revisions, nor the dependency graph.
+func parseLockfile(content []byte) ([]buildinfo.Dependency, error) {
+	lockfile := new(conanLockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	var dependencies []buildinfo.Dependency
+	for _, requires := range []struct {
+		refs  []string
+		scope string
+	}{{lockfile.Requires, "host"}, {lockfile.BuildRequires, "build"}, {lockfile.PythonRequires, "python"}} {
+		for _, ref := range requires.refs {
+			ref, _, _ = strings.Cut(ref, "%")
+			dependencies = append(dependencies, buildinfo.Dependency{Id: ref, Type: dependencyType, Scopes: []string{requires.scope}})
+		}
+	}
+	return dependencies, nil
+}
@@ -0,0 +1,79 @@
+package conan
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The graph of a conanfile.txt, which requires openssl and cmake as a tool.
+// openssl requires zlib, and the binary of a test dependency is skipped.
+const installGraph = `{
+  "graph": {
+    "nodes": {
+      "0": {"ref": "conanfile", "id": "0", "name": null, "version": null, "user": null, "channel": null, "context": "host", "binary": null,
+            "dependencies": {"1": {"ref": "openssl/3.2.1", "direct": true}, "2": {"ref": "zlib/1.3.1", "direct": false}, "3": {"ref": "cmake/3.28.1", "direct": true}}},
+      "1": {"ref": "openssl/3.2.1#a1b2", "name": "openssl", "version": "3.2.1", "rrev": "a1b2", "package_id": "p1", "prev": "r1", "context": "host", "binary": "Download",
+            "dependencies": {"2": {"ref": "zlib/1.3.1", "direct": true}, "4": {"ref": "gtest/1.14.0", "direct": true}}},
+      "2": {"ref": "zlib/1.3.1#c3d4", "name": "zlib", "version": "1.3.1", "rrev": "c3d4", "package_id": "p2", "prev": "r2", "context": "host", "binary": "Cache", "dependencies": {}},
+      "3": {"ref": "cmake/3.28.1#e5f6", "name": "cmake", "version": "3.28.1", "rrev": "e5f6", "package_id": "p3", "prev": "r3", "context": "build", "binary": "Download", "dependencies": {}},
+      "4": {"ref": "gtest/1.14.0#a7b8", "name": "gtest", "version": "1.14.0", "rrev": "a7b8", "package_id": "p4", "prev": null, "context": "host", "binary": "Skip", "dependencies": {}}
+    },
+    "root": {"0": "None"}
+  }
+}`
+
+// The graph of 'conan create', whose root is a virtual node requiring the created recipe.
+const createGraph = `{
+  "graph": {
+    "nodes": {
+      "0": {"ref": "", "name": null, "version": null, "context": "host", "dependencies": {"1": {"ref": "mylib/1.0@acme/stable", "direct": true}}},
+      "1": {"ref": "mylib/1.0@acme/stable#f1", "name": "mylib", "version": "1.0", "user": "acme", "channel": "stable", "rrev": "f1", "package_id": "p5", "prev": "r5", "context": "host", "binary": "Build",
+            "dependencies": {"2": {"ref": "zlib/1.3.1", "direct": true}}},
+      "2": {"ref": "zlib/1.3.1#c3d4", "name": "zlib", "version": "1.3.1", "rrev": "c3d4", "package_id": "p2", "prev": "r2", "context": "host", "binary": "Cache", "dependencies": {}}
+    },
+    "root": {"0": "None"}
+  }
+}`
+
+func TestGetModuleOfInstall(t *testing.T) {
+	graph, err := parseGraph([]byte(installGraph))
+	require.NoError(t, err)
+
+	module := graph.getModule(false, "app")
+	assert.Equal(t, "app", module.id)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "openssl/3.2.1#a1b2:p1#r1", Type: dependencyType, Scopes: []string{"host"}},
+		{Id: "cmake/3.28.1#e5f6:p3#r3", Type: dependencyType, Scopes: []string{"build"}},
+		{Id: "zlib/1.3.1#c3d4:p2#r2", Type: dependencyType, Scopes: []string{"host"}, RequestedBy: [][]string{{"openssl/3.2.1"}}},
+	}, module.dependencies)
+}
+
+func TestGetModuleOfCreate(t *testing.T) {
+	graph, err := parseGraph([]byte(createGraph))
+	require.NoError(t, err)
+
+	module := graph.getModule(true, "app")
+	assert.Equal(t, "mylib/1.0@acme/stable", module.id)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "zlib/1.3.1#c3d4:p2#r2", Type: dependencyType, Scopes: []string{"host"}, RequestedBy: [][]string{{"mylib/1.0@acme/stable"}}},
+	}, module.dependencies)
+}
+
+func TestParseLockfile(t *testing.T) {
+	dependencies, err := parseLockfile([]byte(`{
+    "version": "0.5",
+    "requires": ["zlib/1.3.1#c3d4%1700000000.123", "openssl/3.2.1#a1b2%1700000001.0"],
+    "build_requires": ["cmake/3.28.1#e5f6%1700000002.0"],
+    "python_requires": [],
+    "config_requires": []
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "zlib/1.3.1#c3d4", Type: dependencyType, Scopes: []string{"host"}},
+		{Id: "openssl/3.2.1#a1b2", Type: dependencyType, Scopes: []string{"host"}},
+		{Id: "cmake/3.28.1#e5f6", Type: dependencyType, Scopes: []string{"build"}},
+	}, dependencies)
+}
@@ -0,0 +1,78 @@
+package conan
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The package list printed by 'conan upload' with the json format, by remote name and recipe reference.
+type uploadedList map[string]map[string]*uploadedRecipe
+
+type uploadedRecipe struct {
+	Revisions map[string]*struct {
+		Packages map[string]*struct {
+			Revisions map[string]interface{} `json:"revisions"`
+		} `json:"packages"`
+	} `json:"revisions"`
+}
+
+// A recipe uploaded by 'conan upload', and the paths of its uploaded files in the Artifactory Conan repository.
+type uploadedModule struct {
+	id    string
+	paths []string
+}
+
+// Returns the uploaded recipes, and the paths of their recipe and package revisions in the repository.
+// Artifactory stores the recipe revisions under <user>/<name>/<version>/<channel>/<revision>/export,
+// and the package revisions under <user>/<name>/<version>/<channel>/<revision>/package/<package ID>/<package revision>.
+// A missing user and channel are stored as '_'.
+func parseUploadedList(content []byte) ([]*uploadedModule, error) {
+	list := make(uploadedList)
+	if err := json.Unmarshal(content, &list); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the output of conan upload: %s", err.Error())
+	}
+	var modules []*uploadedModule
+	for _, recipes := range list {
+		for ref, recipe := range recipes {
+			if recipe == nil {
+				continue
+			}
+			module := &uploadedModule{id: ref}
+			recipePath := recipeRepoPath(ref)
+			for rrev, revision := range recipe.Revisions {
+				module.paths = append(module.paths, recipePath+"/"+rrev+"/export/*")
+				if revision == nil {
+					continue
+				}
+				for packageId, pkg := range revision.Packages {
+					if pkg == nil {
+						continue
+					}
+					for prev := range pkg.Revisions {
+						module.paths = append(module.paths, recipePath+"/"+rrev+"/package/"+packageId+"/"+prev+"/*")
+					}
+				}
+			}
+			sort.Strings(module.paths)
+			modules = append(modules, module)
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].id < modules[j].id
+	})
+	return modules, nil
+}
+
+// Converts a recipe reference, such as zlib/1.3 or mylib/1.0@acme/stable, to its path in the repository.
+func recipeRepoPath(ref string) string {
+	nameVersion, userChannel, _ := strings.Cut(ref, "@")
+	name, version, _ := strings.Cut(nameVersion, "/")
+	user, channel := "_", "_"
+	if userChannel != "" {
+		user, channel, _ = strings.Cut(userChannel, "/")
+	}
+	return user + "/" + name + "/" + version + "/" + channel
+}
@@ -0,0 +1,34 @@
+package conan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUploadedList(t *testing.T) {
+	modules, err := parseUploadedList([]byte(`{
+  "jfrog-deploy": {
+    "zlib/1.3.1": {
+      "revisions": {
+        "c3d4": {
+          "timestamp": 1700000000.0,
+          "packages": {
+            "p2": {"info": {"settings": {"os": "Linux"}}, "revisions": {"r2": {"timestamp": 1700000001.0}}}
+          }
+        }
+      }
+    },
+    "mylib/1.0@acme/stable": {
+      "revisions": {"f1": {"timestamp": 1700000002.0}}
+    }
+  }
+}`))
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+	assert.Equal(t, "mylib/1.0@acme/stable", modules[0].id)
+	assert.Equal(t, []string{"acme/mylib/1.0/stable/f1/export/*"}, modules[0].paths)
+	assert.Equal(t, "zlib/1.3.1", modules[1].id)
+	assert.Equal(t, []string{"_/zlib/1.3.1/_/c3d4/export/*", "_/zlib/1.3.1/_/c3d4/package/p2/r2/*"}, modules[1].paths)
+}
@@ -0,0 +1,301 @@
+package conda
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ToolName       = "conda"
+	executableName = "conda"
+
+	ModuleType buildinfo.ModuleType = "conda"
+
+	defaultEnvironment = "base"
+
+	condarcEnv            = "CONDARC"
+	condarcFileName       = "condarc"
+	customChannelsKey     = "custom_channels"
+	credentialsEnv        = "JFROG_CLI_CONDA_CREDENTIALS"
+	condarcFilePermission = 0600
+)
+
+// The conda commands which resolve packages into the environment.
+var resolveCommands = []string{"install", "create", "update", "upgrade"}
+
+// Runs a conda command. The install, create and update commands resolve the packages from an Artifactory Conda repository,
+// which replaces the configured channels unless channels are passed to the command.
+// The packages of the environment are then collected into the build-info from 'conda list --explicit --md5', which includes their checksums.
+type CondaCommand struct {
+	cmdName            string
+	configFilePath     string
+	condaArgs          []string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewCondaCommand() *CondaCommand {
+	return &CondaCommand{}
+}
+
+func (cc *CondaCommand) SetCmdName(cmdName string) *CondaCommand {
+	cc.cmdName = cmdName
+	return cc
+}
+
+func (cc *CondaCommand) SetConfigFilePath(configFilePath string) *CondaCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CondaCommand) SetArgs(args []string) *CondaCommand {
+	cc.condaArgs = args
+	return cc
+}
+
+func (cc *CondaCommand) CommandName() string {
+	return "rt_conda_" + cc.cmdName
+}
+
+func (cc *CondaCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the build-info options from the conda arguments.
+func (cc *CondaCommand) Init() (err error) {
+	if cc.condaArgs, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.condaArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(cc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig == nil {
+		return errorutils.CheckErrorf("the resolver repository is missing from the config file (%s). Please run 'jf conda-config' with the --repo-resolve option", cc.configFilePath)
+	}
+	if cc.serverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	cc.repo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (cc *CondaCommand) Run() (err error) {
+	args := append([]string{cc.cmdName}, cc.condaArgs...)
+	if !isResolveCommand(cc.cmdName) {
+		return runConda(nil, args...)
+	}
+	var env []string
+	if !hasFlag(cc.condaArgs, "-c", "--channel", "--override-channels") {
+		var tempDir string
+		if tempDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+		}()
+		if env, err = createChannelEnv(tempDir, cc.serverDetails, cc.repo); err != nil {
+			return err
+		}
+		args = append(args, "--override-channels", "--channel", cc.repo)
+	}
+	if err = runConda(env, args...); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return cc.collectDependencies()
+}
+
+func isResolveCommand(cmdName string) bool {
+	for _, resolveCommand := range resolveCommands {
+		if cmdName == resolveCommand {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the environment variables which configure conda to resolve the repository channel from Artifactory.
+// The channel is added to the custom channels of a temporary condarc file, which includes the configuration of the user's CONDARC file.
+// The credentials are kept out of the file and the command line. Conda expands them from an environment variable when it loads the file.
+func createChannelEnv(tempDir string, serverDetails *config.ServerDetails, repo string) ([]string, error) {
+	credentials, err := getCredentials(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	condarc, err := readUserCondarc()
+	if err != nil {
+		return nil, err
+	}
+	channelsUrl := getChannelsUrl(serverDetails, credentials != "")
+	condarc = setCustomChannel(condarc, repo, channelsUrl)
+	content, err := yaml.Marshal(condarc)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	condarcPath := filepath.Join(tempDir, condarcFileName)
+	if err = os.WriteFile(condarcPath, content, condarcFilePermission); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	env := []string{condarcEnv + "=" + condarcPath}
+	if credentials != "" {
+		env = append(env, credentialsEnv+"="+credentials)
+	}
+	return env, nil
+}
+
+// Returns the base URL of the Artifactory Conda repositories. The URL refers to the credentials environment variable if the server has credentials.
+func getChannelsUrl(serverDetails *config.ServerDetails, withCredentials bool) string {
+	channelsUrl := strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/conda"
+	if !withCredentials {
+		return channelsUrl
+	}
+	scheme, rest, found := strings.Cut(channelsUrl, "://")
+	if !found {
+		return channelsUrl
+	}
+	return scheme + "://${" + credentialsEnv + "}@" + rest
+}
+
+// Returns the escaped user info of the server credentials, or an empty string if the server has no credentials.
+func getCredentials(serverDetails *config.ServerDetails) (string, error) {
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil || password == "" {
+		return "", err
+	}
+	return url.UserPassword(username, password).String(), nil
+}
+
+// Reads the condarc file which the CONDARC environment variable points to, since the temporary file replaces it.
+func readUserCondarc() (yaml.MapSlice, error) {
+	condarcPath := os.Getenv(condarcEnv)
+	if condarcPath == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(condarcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var condarc yaml.MapSlice
+	if err = yaml.Unmarshal(content, &condarc); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the conda configuration file %s: %s", condarcPath, err.Error())
+	}
+	return condarc, nil
+}
+
+// Adds the channel to the custom channels of the condarc configuration, which maps the channel name to the base URL of the channel.
+func setCustomChannel(condarc yaml.MapSlice, channel, channelsUrl string) yaml.MapSlice {
+	for i, item := range condarc {
+		if item.Key != customChannelsKey {
+			continue
+		}
+		customChannels, _ := item.Value.(yaml.MapSlice)
+		condarc[i].Value = append(slices.DeleteFunc(customChannels, func(customChannel yaml.MapItem) bool {
+			return customChannel.Key == channel
+		}), yaml.MapItem{Key: channel, Value: channelsUrl})
+		return condarc
+	}
+	return append(condarc, yaml.MapItem{Key: customChannelsKey, Value: yaml.MapSlice{{Key: channel, Value: channelsUrl}}})
+}
+
+// Collects the packages of the environment of the command. The environment is the build-info module.
+func (cc *CondaCommand) collectDependencies() error {
+	envArgs, moduleId := getEnvironment(cc.condaArgs)
+	output, err := exec.Command(executableName, append([]string{"list", "--explicit", "--md5"}, envArgs...)...).Output()
+	if err != nil {
+		return errorutils.CheckErrorf("failed to list the packages of the conda environment %s: %s", moduleId, err.Error())
+	}
+	dependencies, err := parseExplicitList(output)
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveDependencies(cc.buildConfiguration, moduleId, ModuleType, dependencies)
+}
+
+// Returns the arguments which select the environment of the command, and the name of the environment.
+// Without these arguments, the command uses the active environment.
+func getEnvironment(args []string) (envArgs []string, envName string) {
+	if prefix := getFlagValue(args, "-p", "--prefix"); prefix != "" {
+		return []string{"--prefix", prefix}, filepath.Base(prefix)
+	}
+	if name := getFlagValue(args, "-n", "--name"); name != "" {
+		return []string{"--name", name}, name
+	}
+	if envName = os.Getenv("CONDA_DEFAULT_ENV"); envName == "" {
+		envName = defaultEnvironment
+	}
+	return nil, envName
+}
+
+// Returns the value of a flag, which is passed as '<short> <value>', '<long> <value>' or '<long>=<value>'.
+func getFlagValue(args []string, shortFlag, longFlag string) string {
+	for i, arg := range args {
+		switch {
+		case (arg == shortFlag || arg == longFlag) && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, longFlag+"="):
+			return strings.TrimPrefix(arg, longFlag+"=")
+		}
+	}
+	return ""
+}
+
+func hasFlag(args []string, flags ...string) bool {
+	for _, arg := range args {
+		for _, flag := range flags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func runConda(env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the conda executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, redactCredentials(args))
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+// Replaces the passwords of the URLs in the arguments, so that they can be logged.
+func redactCredentials(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		if parsedUrl, err := url.Parse(arg); err == nil && parsedUrl.User != nil {
+			redacted[i] = parsedUrl.Redacted()
+		}
+	}
+	return redacted
+}
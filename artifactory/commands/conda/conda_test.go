@@ -0,0 +1,52 @@
+package conda
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEnvironment(t *testing.T) {
+	envArgs, envName := getEnvironment([]string{"numpy", "-n", "analytics", "-y"})
+	assert.Equal(t, []string{"--name", "analytics"}, envArgs)
+	assert.Equal(t, "analytics", envName)
+
+	envArgs, envName = getEnvironment([]string{"--prefix=/opt/envs/analytics", "numpy"})
+	assert.Equal(t, []string{"--prefix", "/opt/envs/analytics"}, envArgs)
+	assert.Equal(t, "analytics", envName)
+
+	t.Setenv("CONDA_DEFAULT_ENV", "")
+	envArgs, envName = getEnvironment([]string{"numpy"})
+	assert.Nil(t, envArgs)
+	assert.Equal(t, defaultEnvironment, envName)
+}
+
+func TestCreateChannelEnv(t *testing.T) {
+	userCondarcPath := filepath.Join(t.TempDir(), ".condarc")
+	require.NoError(t, os.WriteFile(userCondarcPath, []byte("ssl_verify: false\ncustom_channels:\n  internal: https://conda.acme.io\n  conda-virtual: https://old.acme.io\n"), 0600))
+	t.Setenv(condarcEnv, userCondarcPath)
+
+	tempDir := t.TempDir()
+	env, err := createChannelEnv(tempDir, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "p@ss"}, "conda-virtual")
+	require.NoError(t, err)
+	condarcPath := filepath.Join(tempDir, condarcFileName)
+	assert.Equal(t, []string{condarcEnv + "=" + condarcPath, credentialsEnv + "=user:p%40ss"}, env)
+
+	content, err := os.ReadFile(condarcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "ssl_verify: false\ncustom_channels:\n  internal: https://conda.acme.io\n  conda-virtual: https://${"+credentialsEnv+"}@acme.jfrog.io/artifactory/api/conda\n", string(content))
+	assert.NotContains(t, string(content), "p%40ss")
+
+	// Without credentials, and without a user condarc file.
+	t.Setenv(condarcEnv, "")
+	env, err = createChannelEnv(tempDir, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, "conda-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, []string{condarcEnv + "=" + condarcPath}, env)
+	content, err = os.ReadFile(condarcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "custom_channels:\n  conda-virtual: https://acme.jfrog.io/artifactory/api/conda\n", string(content))
+}
@@ -0,0 +1,84 @@
+package conda
+
+import (
+	"bufio"
+	"bytes"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	explicitMarker = "@EXPLICIT"
+	dependencyType = "conda"
+)
+
+var md5Regexp = regexp.MustCompile(`^[a-fA-F0-9]{32}$`)
+
+// Parses the output of 'conda list --explicit --md5', which lists the URL of each package of the environment, followed by its checksum.
+// Packages which were installed by pip aren't listed.
+func parseExplicitList(output []byte) ([]buildinfo.Dependency, error) {
+	var dependencies []buildinfo.Dependency
+	explicit := false
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !explicit {
+			explicit = line == explicitMarker
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		packageUrl, checksum, _ := strings.Cut(line, "#")
+		parsedUrl, err := url.Parse(packageUrl)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the conda package URL %s: %s", packageUrl, err.Error())
+		}
+		dependency, ok := parseArchiveName(path.Base(parsedUrl.Path))
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(checksum, "sha256:"):
+			dependency.Checksum = buildinfo.Checksum{Sha256: strings.TrimPrefix(checksum, "sha256:")}
+		case md5Regexp.MatchString(checksum):
+			dependency.Checksum = buildinfo.Checksum{Md5: checksum}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if !explicit {
+		return nil, errorutils.CheckErrorf("unexpected output of 'conda list --explicit': %s is missing", explicitMarker)
+	}
+	return dependencies, nil
+}
+
+// Returns the dependency of a package archive, which is named <name>-<version>-<build>.conda or <name>-<version>-<build>.tar.bz2.
+// The ID of the dependency is <name>:<version>-<build>.
+func parseArchiveName(archiveName string) (buildinfo.Dependency, bool) {
+	var dependency buildinfo.Dependency
+	baseName, found := strings.CutSuffix(archiveName, ".conda")
+	if !found {
+		if baseName, found = strings.CutSuffix(archiveName, ".tar.bz2"); !found {
+			return dependency, false
+		}
+	}
+	buildIndex := strings.LastIndex(baseName, "-")
+	if buildIndex <= 0 {
+		return dependency, false
+	}
+	versionIndex := strings.LastIndex(baseName[:buildIndex], "-")
+	if versionIndex <= 0 {
+		return dependency, false
+	}
+	dependency.Id = baseName[:versionIndex] + ":" + baseName[versionIndex+1:]
+	dependency.Type = dependencyType
+	return dependency, true
+}
@@ -0,0 +1,31 @@
+package conda
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExplicitList = `# This file may be used to create an environment using:
+# $ conda create --name <env> --file <this file>
+# platform: linux-64
+@EXPLICIT
+https://acme.jfrog.io/artifactory/api/conda/conda-virtual/conda-forge/linux-64/_libgcc_mutex-0.1-conda_forge.tar.bz2#d7c89558ba9fa0495403155b64376d81
+https://acme.jfrog.io/artifactory/api/conda/conda-virtual/conda-forge/noarch/python-dateutil-2.9.0.post0-pyhff2d567_1.conda#5ba79d7c71f03c678c8ead841f347d6e
+https://acme.jfrog.io/artifactory/api/conda/conda-virtual/conda-forge/linux-64/numpy-2.1.3-py312h58c1407_0.conda#sha256:b7d56a0d4b5a96b5b0f3d36e9ab0b5a5f1f0b0f6d1b9a4f5e6c8f0e6a7d3b2c1
+`
+
+func TestParseExplicitList(t *testing.T) {
+	dependencies, err := parseExplicitList([]byte(testExplicitList))
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "_libgcc_mutex:0.1-conda_forge", Type: dependencyType, Checksum: buildinfo.Checksum{Md5: "d7c89558ba9fa0495403155b64376d81"}},
+		{Id: "python-dateutil:2.9.0.post0-pyhff2d567_1", Type: dependencyType, Checksum: buildinfo.Checksum{Md5: "5ba79d7c71f03c678c8ead841f347d6e"}},
+		{Id: "numpy:2.1.3-py312h58c1407_0", Type: dependencyType, Checksum: buildinfo.Checksum{Sha256: "b7d56a0d4b5a96b5b0f3d36e9ab0b5a5f1f0b0f6d1b9a4f5e6c8f0e6a7d3b2c1"}},
+	}, dependencies)
+
+	_, err = parseExplicitList([]byte("# packages in environment\nnumpy 2.1.3\n"))
+	assert.Error(t, err)
+}
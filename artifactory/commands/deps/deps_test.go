@@ -0,0 +1,164 @@
+package deps
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Of(content string) string {
+	checksum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func TestCollectEntries(t *testing.T) {
+	bi := &buildinfo.BuildInfo{Modules: []buildinfo.Module{
+		{Type: buildinfo.Gradle, Dependencies: []buildinfo.Dependency{
+			{Id: "org.acme:lib:1.0", Checksum: buildinfo.Checksum{Sha1: sha1Of("lib")}},
+			{Id: "external.tar.gz"},
+		}},
+		{Type: buildinfo.Maven, Dependencies: []buildinfo.Dependency{
+			{Id: "org.acme:lib:1.0", Checksum: buildinfo.Checksum{Sha1: sha1Of("lib")}},
+			{Id: "lib-1.0.jar", Checksum: buildinfo.Checksum{Sha1: sha1Of("lib")}},
+		}},
+		{Dependencies: []buildinfo.Dependency{
+			{Id: "tool.zip", Checksum: buildinfo.Checksum{Sha1: sha1Of("tool"), Md5: "md5"}},
+		}},
+	}}
+	entries, missing := collectEntries(bi)
+	assert.Equal(t, []*Entry{
+		{PackageType: "maven", Sha1: sha1Of("lib"), Ids: []string{"org.acme:lib:1.0", "lib-1.0.jar"}},
+		{PackageType: "generic", Sha1: sha1Of("tool"), Md5: "md5", Ids: []string{"tool.zip"}},
+	}, entries)
+	assert.Equal(t, []string{"external.tar.gz"}, missing)
+}
+
+// Answers the AQL queries by sha1 from a list of files.
+type testAqlRunner []servicesutils.ResultItem
+
+func (tar testAqlRunner) Aql(query string) (io.ReadCloser, error) {
+	result := servicesutils.AqlSearchResult{}
+	for _, item := range tar {
+		if strings.Contains(query, `"actual_sha1":"`+item.Actual_Sha1+`"`) {
+			result.Results = append(result.Results, item)
+		}
+	}
+	content, err := json.Marshal(result)
+	return io.NopCloser(bytes.NewReader(content)), err
+}
+
+func TestLocateEntries(t *testing.T) {
+	runner := testAqlRunner{
+		{Repo: "maven-remote-cache", Path: "org/acme/lib/1.0", Name: "lib-1.0.jar", Actual_Sha1: sha1Of("lib"), Size: 3},
+		{Repo: "libs-release-local", Path: "org/acme/lib/1.0", Name: "lib-1.0.jar", Actual_Sha1: sha1Of("lib"), Size: 3},
+	}
+	newEntries := func() []*Entry {
+		return []*Entry{{PackageType: "maven", Sha1: sha1Of("lib")}, {PackageType: "generic", Sha1: sha1Of("tool")}}
+	}
+	located, notFound, err := locateEntries(runner, newEntries(), nil)
+	require.NoError(t, err)
+	require.Len(t, located, 1)
+	assert.Equal(t, "libs-release-local", located[0].SourceRepo)
+	assert.Equal(t, "org/acme/lib/1.0/lib-1.0.jar", located[0].Path)
+	assert.Equal(t, int64(3), located[0].Size)
+	require.Len(t, notFound, 1)
+	assert.Equal(t, sha1Of("tool"), notFound[0].Sha1)
+
+	// The files of a remote repository are in its cache.
+	located, _, err = locateEntries(runner, newEntries(), []string{"maven-remote"})
+	require.NoError(t, err)
+	require.Len(t, located, 1)
+	assert.Equal(t, "maven-remote-cache", located[0].SourceRepo)
+}
+
+// Serves files from memory, by their repository paths.
+type testRemoteFiles map[string]string
+
+func (trf testRemoteFiles) ReadRemoteFile(readPath string) (io.ReadCloser, error) {
+	content, exists := trf[readPath]
+	if !exists {
+		return nil, errors.New("404 Not Found")
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func newTestManifest() *Manifest {
+	return &Manifest{Version: manifestVersion, Build: Build{Name: "app", Number: "1"}, Dependencies: []*Entry{
+		{PackageType: "maven", SourceRepo: "maven-remote-cache", Path: "org/acme/lib/1.0/lib-1.0.jar", Size: 3, Sha1: sha1Of("lib"), Ids: []string{"org.acme:lib:1.0"}},
+		{PackageType: "npm", SourceRepo: "npm-remote-cache", Path: "left-pad/-/left-pad-1.3.0.tgz", Size: 3, Sha1: sha1Of("pad"), Ids: []string{"left-pad:1.3.0"}},
+	}}
+}
+
+func TestExportAndExtractArchive(t *testing.T) {
+	remoteFiles := testRemoteFiles{
+		"maven-remote-cache/org/acme/lib/1.0/lib-1.0.jar": "lib",
+		"npm-remote-cache/left-pad/-/left-pad-1.3.0.tgz":  "pad",
+	}
+	archive := new(bytes.Buffer)
+	require.NoError(t, writeArchive(archive, newTestManifest(), remoteFiles))
+
+	// An archive with unmapped package types isn't extracted.
+	destDir := t.TempDir()
+	_, err := extractArchive(bytes.NewReader(archive.Bytes()), destDir, map[string]string{"maven": "libs-local"})
+	assert.ErrorContains(t, err, "not mapped to a target repository: npm")
+
+	manifest, err := extractArchive(bytes.NewReader(archive.Bytes()), destDir, map[string]string{"maven": "libs-local", "npm": "npm-local"})
+	require.NoError(t, err)
+	assert.Equal(t, newTestManifest(), manifest)
+	content, err := os.ReadFile(filepath.Join(destDir, sha1Of("pad")))
+	require.NoError(t, err)
+	assert.Equal(t, "pad", string(content))
+}
+
+func TestGetUploadParams(t *testing.T) {
+	manifest := &Manifest{Version: manifestVersion, Dependencies: []*Entry{
+		{PackageType: "generic", Path: "tools/(beta)/tool-{1}-*.zip", Sha1: sha1Of("tool")},
+	}}
+	uploadParams := getUploadParams(manifest, "/tmp/extracted", map[string]string{"generic": "generic-local"})
+	require.Len(t, uploadParams, 1)
+	// The pattern is the extracted file, named by its sha1, so the wildcard characters of the path aren't interpreted.
+	assert.Equal(t, filepath.Join("/tmp/extracted", sha1Of("tool")), uploadParams[0].Pattern)
+	assert.Equal(t, "generic-local/tools/(beta)/tool-{1}-*.zip", uploadParams[0].Target)
+	assert.True(t, uploadParams[0].Flat)
+}
+
+func TestExportChecksumMismatch(t *testing.T) {
+	remoteFiles := testRemoteFiles{
+		"maven-remote-cache/org/acme/lib/1.0/lib-1.0.jar": "lib",
+		"npm-remote-cache/left-pad/-/left-pad-1.3.0.tgz":  "bad",
+	}
+	err := writeArchive(io.Discard, newTestManifest(), remoteFiles)
+	assert.ErrorContains(t, err, "doesn't match its sha1 in the build-info")
+}
+
+func TestParseRepoMap(t *testing.T) {
+	repoMap, err := ParseRepoMap("maven=libs-local, NPM=npm-local")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"maven": "libs-local", "npm": "npm-local"}, repoMap)
+	for _, invalid := range []string{"", "maven", "maven=", "=libs-local"} {
+		_, err = ParseRepoMap(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	manifest := newTestManifest()
+	assert.NoError(t, manifest.validate())
+	manifest.Dependencies[0].Path = "../../etc/passwd"
+	assert.Error(t, manifest.validate())
+	manifest = newTestManifest()
+	manifest.Version = 2
+	assert.Error(t, manifest.validate())
+}
@@ -0,0 +1,237 @@
+package deps
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha1" // #nosec G505 -- sha1 is the checksum by which the dependencies are recorded in the build-info.
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The number of checksums searched by a single AQL query.
+const aqlBatchSize = 100
+
+// Downloads the dependencies recorded in a published build-info into a portable archive, which can be imported to another Artifactory
+// with 'jf deps import'. The dependencies are located in Artifactory by their sha1, and keep their paths in the repositories
+// from which they were resolved, which follow the layout of their package type.
+type ExportCommand struct {
+	serverDetails *config.ServerDetails
+	build         Build
+	repos         []string
+	archivePath   string
+}
+
+func NewExportCommand() *ExportCommand {
+	return &ExportCommand{}
+}
+
+func (ec *ExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *ExportCommand {
+	ec.serverDetails = serverDetails
+	return ec
+}
+
+func (ec *ExportCommand) SetBuild(name, number, project string) *ExportCommand {
+	ec.build = Build{Name: name, Number: number, Project: project}
+	return ec
+}
+
+// Limits the repositories from which the dependencies are exported. By default, the dependencies are exported from any repository.
+func (ec *ExportCommand) SetRepos(repos []string) *ExportCommand {
+	ec.repos = repos
+	return ec
+}
+
+func (ec *ExportCommand) SetArchivePath(archivePath string) *ExportCommand {
+	ec.archivePath = archivePath
+	return ec
+}
+
+func (ec *ExportCommand) CommandName() string {
+	return "deps_export"
+}
+
+func (ec *ExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ec.serverDetails, nil
+}
+
+func (ec *ExportCommand) Run() (err error) {
+	servicesManager, err := utils.CreateServiceManager(ec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	params := services.NewBuildInfoParams()
+	params.BuildName, params.BuildNumber, params.ProjectKey = ec.build.Name, ec.build.Number, ec.build.Project
+	published, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("the build %s/%s was not found in Artifactory", ec.build.Name, ec.build.Number)
+	}
+	entries, missing := collectEntries(&published.BuildInfo)
+	located, notFound, err := locateEntries(servicesManager, entries, ec.repos)
+	if err != nil {
+		return err
+	}
+	for _, entry := range notFound {
+		missing = append(missing, entry.Ids...)
+	}
+	for _, id := range missing {
+		log.Warn("The dependency", id, "was not found in Artifactory, and therefore is not exported.")
+	}
+	manifest := &Manifest{Version: manifestVersion, Build: ec.build, Dependencies: located, Missing: missing}
+	archive, err := os.Create(ec.archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	if err = writeArchive(archive, manifest, servicesManager); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Exported %d dependencies of the build %s/%s to %s.", len(located), ec.build.Name, ec.build.Number, ec.archivePath))
+	return nil
+}
+
+// Locates the files of the entries in Artifactory by their sha1, and sets their repositories and paths.
+// Returns the located entries, and the entries which were not found.
+func locateEntries(runner artifactoryutils.AqlRunner, entries []*Entry, repos []string) (located, notFound []*Entry, err error) {
+	for start := 0; start < len(entries); start += aqlBatchSize {
+		batch := entries[start:min(start+aqlBatchSize, len(entries))]
+		items, err := searchBySha1(runner, batch)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range batch {
+			item := selectItem(items[entry.Sha1], repos)
+			if item == nil {
+				notFound = append(notFound, entry)
+				continue
+			}
+			entry.SourceRepo, entry.Path, entry.Size = item.Repo, path.Join(item.Path, item.Name), item.Size
+			if entry.Md5 == "" {
+				entry.Md5 = item.Actual_Md5
+			}
+			if entry.Sha256 == "" {
+				entry.Sha256 = item.Sha256
+			}
+			located = append(located, entry)
+		}
+	}
+	return located, notFound, nil
+}
+
+// Returns the files whose sha1 is one of the entries, by their sha1.
+func searchBySha1(runner artifactoryutils.AqlRunner, entries []*Entry) (map[string][]servicesutils.ResultItem, error) {
+	var checksums []map[string]string
+	for _, entry := range entries {
+		checksums = append(checksums, map[string]string{"actual_sha1": entry.Sha1})
+	}
+	criteria, err := json.Marshal(map[string]any{"type": "file", "$or": checksums})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	query := fmt.Sprintf(`items.find(%s).include("repo","path","name","actual_sha1","actual_md5","sha256","size")`, criteria)
+	result := new(servicesutils.AqlSearchResult)
+	if err = artifactoryutils.RunAql(runner, query, result); err != nil {
+		return nil, err
+	}
+	items := make(map[string][]servicesutils.ResultItem)
+	for _, item := range result.Results {
+		items[item.Actual_Sha1] = append(items[item.Actual_Sha1], item)
+	}
+	return items, nil
+}
+
+// Selects the file of a dependency among the files with its sha1, which are identical. The first by repository and path is selected,
+// so the export is deterministic. If repositories are provided, only their files are selected.
+func selectItem(items []servicesutils.ResultItem, repos []string) *servicesutils.ResultItem {
+	var candidates []servicesutils.ResultItem
+	for _, item := range items {
+		if len(repos) == 0 || isRepoIncluded(item.Repo, repos) {
+			candidates = append(candidates, item)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Repo != candidates[j].Repo {
+			return candidates[i].Repo < candidates[j].Repo
+		}
+		return path.Join(candidates[i].Path, candidates[i].Name) < path.Join(candidates[j].Path, candidates[j].Name)
+	})
+	return &candidates[0]
+}
+
+// The files of a remote repository are cached in its -cache repository.
+func isRepoIncluded(repo string, repos []string) bool {
+	for _, included := range repos {
+		if repo == included || repo == included+"-cache" {
+			return true
+		}
+	}
+	return false
+}
+
+// Writes the manifest and the files of its entries to a gzipped tar archive. The content of each file is verified against its sha1.
+func writeArchive(writer io.Writer, manifest *Manifest, reader artifactoryutils.RemoteFileReader) (err error) {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(tarWriter.Close()), errorutils.CheckError(gzipWriter.Close()))
+	}()
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = tarWriter.WriteHeader(&tar.Header{Name: manifestFileName, Mode: 0644, Size: int64(len(content))}); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if _, err = tarWriter.Write(content); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, entry := range manifest.Dependencies {
+		if err = writeEntry(tarWriter, entry, reader); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeEntry(tarWriter *tar.Writer, entry *Entry, reader artifactoryutils.RemoteFileReader) (err error) {
+	log.Debug("Exporting", entry.SourceRepo+"/"+entry.Path)
+	stream, err := reader.ReadRemoteFile(entry.SourceRepo + "/" + entry.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	if err = tarWriter.WriteHeader(&tar.Header{Name: entry.archivePath(), Mode: 0644, Size: entry.Size}); err != nil {
+		return errorutils.CheckError(err)
+	}
+	hash := sha1.New() // #nosec G401 -- sha1 is the checksum by which the dependencies are recorded in the build-info.
+	if _, err = io.Copy(tarWriter, io.TeeReader(stream, hash)); err != nil {
+		return errorutils.CheckErrorf("failed to export %s/%s: %s", entry.SourceRepo, entry.Path, err.Error())
+	}
+	if actualSha1 := hex.EncodeToString(hash.Sum(nil)); actualSha1 != entry.Sha1 {
+		return errorutils.CheckErrorf("the sha1 of %s/%s (%s) doesn't match its sha1 in the build-info (%s)", entry.SourceRepo, entry.Path, actualSha1, entry.Sha1)
+	}
+	return nil
+}
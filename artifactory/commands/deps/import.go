@@ -0,0 +1,190 @@
+package deps
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha1" // #nosec G505 -- sha1 is the checksum by which the dependencies are recorded in the build-info.
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Uploads the dependencies of an archive created by 'jf deps export' to local repositories, by their package types.
+// Each dependency keeps its path in the repository from which it was exported, which follows the layout of its package type.
+type ImportCommand struct {
+	serverDetails *config.ServerDetails
+	archivePath   string
+	repoMap       map[string]string
+}
+
+func NewImportCommand() *ImportCommand {
+	return &ImportCommand{}
+}
+
+func (ic *ImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *ImportCommand {
+	ic.serverDetails = serverDetails
+	return ic
+}
+
+func (ic *ImportCommand) SetArchivePath(archivePath string) *ImportCommand {
+	ic.archivePath = archivePath
+	return ic
+}
+
+// Sets the target repository of each package type.
+func (ic *ImportCommand) SetRepoMap(repoMap map[string]string) *ImportCommand {
+	ic.repoMap = repoMap
+	return ic
+}
+
+func (ic *ImportCommand) CommandName() string {
+	return "deps_import"
+}
+
+func (ic *ImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ic.serverDetails, nil
+}
+
+func (ic *ImportCommand) Run() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	archive, err := os.Open(ic.archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	manifest, err := extractArchive(archive, tempDir, ic.repoMap)
+	if err != nil {
+		return err
+	}
+	if len(manifest.Dependencies) == 0 {
+		log.Info("The archive doesn't include any dependency.")
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(ic.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, getUploadParams(manifest, tempDir, ic.repoMap)...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close(), summary.TransferDetailsReader.Close())
+	}()
+	log.Info(fmt.Sprintf("Imported %d dependencies of the build %s/%s.", summary.TotalSucceeded, manifest.Build.Name, manifest.Build.Number))
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to import %d dependencies to Artifactory. See Artifactory logs for more details", summary.TotalFailed)
+	}
+	return nil
+}
+
+// Returns the upload of each extracted file to the path of its dependency in the target repository of its package type.
+func getUploadParams(manifest *Manifest, destDir string, repoMap map[string]string) []services.UploadParams {
+	var uploadParams []services.UploadParams
+	for _, entry := range manifest.Dependencies {
+		up := services.NewUploadParams()
+		up.CommonParams = &specutils.CommonParams{Pattern: entry.extractedPath(destDir), Target: repoMap[entry.PackageType] + "/" + entry.Path}
+		up.Flat = true
+		uploadParams = append(uploadParams, up)
+	}
+	return uploadParams
+}
+
+// Extracts the files of the archive to the directory, and returns its manifest. The manifest is read first,
+// so an archive whose package types aren't all mapped to target repositories is rejected before it's extracted.
+// The content of each file is verified against its sha1.
+func extractArchive(reader io.Reader, destDir string, repoMap map[string]string) (*Manifest, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the file is not a dependencies archive: %s", err.Error())
+	}
+	tarReader := tar.NewReader(gzipReader)
+	header, err := tarReader.Next()
+	if err != nil || header.Name != manifestFileName {
+		return nil, errorutils.CheckErrorf("the archive doesn't start with %s", manifestFileName)
+	}
+	manifest := new(Manifest)
+	if err = json.NewDecoder(tarReader).Decode(manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read %s: %s", manifestFileName, err.Error())
+	}
+	if err = manifest.validate(); err != nil {
+		return nil, err
+	}
+	if err = validateRepoMap(manifest, repoMap); err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*Entry)
+	for _, entry := range manifest.Dependencies {
+		entries[entry.archivePath()] = entry
+	}
+	for {
+		header, err = tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		entry, exists := entries[header.Name]
+		if !exists {
+			return nil, errorutils.CheckErrorf("the file %s of the archive is missing from its manifest", header.Name)
+		}
+		if err = extractEntry(tarReader, entry.extractedPath(destDir), entry); err != nil {
+			return nil, err
+		}
+		delete(entries, header.Name)
+	}
+	if len(entries) > 0 {
+		var missing []string
+		for archivePath := range entries {
+			missing = append(missing, archivePath)
+		}
+		slices.Sort(missing)
+		return nil, errorutils.CheckErrorf("the following files of the manifest are missing from the archive: %s", strings.Join(missing, ", "))
+	}
+	return manifest, nil
+}
+
+func extractEntry(reader io.Reader, localPath string, entry *Entry) (err error) {
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hash := sha1.New() // #nosec G401 -- sha1 is the checksum by which the dependencies are recorded in the build-info.
+	if _, err = io.Copy(io.MultiWriter(file, hash), reader); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if actualSha1 := hex.EncodeToString(hash.Sum(nil)); actualSha1 != entry.Sha1 {
+		return errorutils.CheckErrorf("the sha1 of %s (%s) doesn't match its sha1 in the manifest (%s)", entry.Path, actualSha1, entry.Sha1)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package deps
+
+import (
+	"crypto/sha1" // #nosec G505 -- sha1 is the checksum by which the dependencies are recorded in the build-info.
+	"encoding/hex"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	manifestFileName = "manifest.json"
+	manifestVersion  = 1
+	// The files are stored in the archive under files/<package type>/<path in the source repository>.
+	filesDir = "files"
+
+	genericPackageType = "generic"
+)
+
+// The build-info module types whose dependencies are stored in the layout of another package type.
+var layoutPackageTypes = map[string]string{
+	string(buildinfo.Gradle): string(buildinfo.Maven),
+	"sbt":                    string(buildinfo.Maven),
+	"ivy":                    string(buildinfo.Maven),
+}
+
+// Describes the content of a dependencies archive. It's the first file of the archive, so the archive can be imported as a stream.
+type Manifest struct {
+	Version      int      `json:"version"`
+	Build        Build    `json:"build"`
+	Dependencies []*Entry `json:"dependencies"`
+	// The IDs of the dependencies which were not found in Artifactory, and therefore are missing from the archive.
+	Missing []string `json:"missing,omitempty"`
+}
+
+type Build struct {
+	Name    string `json:"name"`
+	Number  string `json:"number"`
+	Project string `json:"project,omitempty"`
+}
+
+// A file of the archive, and the dependencies of the build-info which it is.
+type Entry struct {
+	PackageType string   `json:"packageType"`
+	SourceRepo  string   `json:"sourceRepo"`
+	Path        string   `json:"path"`
+	Size        int64    `json:"size"`
+	Sha1        string   `json:"sha1"`
+	Md5         string   `json:"md5,omitempty"`
+	Sha256      string   `json:"sha256,omitempty"`
+	Ids         []string `json:"ids"`
+}
+
+// Returns the path of the file in the archive.
+func (e *Entry) archivePath() string {
+	return filesDir + "/" + e.PackageType + "/" + e.Path
+}
+
+// Returns the path to which the file is extracted. The file is named by its sha1, since the upload pattern has wildcard semantics,
+// and the path of the dependency may have characters which would be interpreted by it.
+func (e *Entry) extractedPath(destDir string) string {
+	return filepath.Join(destDir, e.Sha1)
+}
+
+// Returns the files of the build-info dependencies, one per sha1, in the order of the modules.
+// Dependencies without a sha1 can't be located in Artifactory, and are returned as missing.
+func collectEntries(bi *buildinfo.BuildInfo) (entries []*Entry, missing []string) {
+	bySha1 := make(map[string]*Entry)
+	for _, module := range bi.Modules {
+		packageType := getPackageType(module.Type)
+		for _, dependency := range module.Dependencies {
+			if dependency.Sha1 == "" {
+				missing = append(missing, dependency.Id)
+				continue
+			}
+			entry, exists := bySha1[dependency.Sha1]
+			if !exists {
+				entry = &Entry{PackageType: packageType, Sha1: dependency.Sha1, Md5: dependency.Md5, Sha256: dependency.Sha256}
+				bySha1[dependency.Sha1] = entry
+				entries = append(entries, entry)
+			}
+			if !slices.Contains(entry.Ids, dependency.Id) {
+				entry.Ids = append(entry.Ids, dependency.Id)
+			}
+		}
+	}
+	return entries, missing
+}
+
+func getPackageType(moduleType buildinfo.ModuleType) string {
+	if moduleType == "" {
+		return genericPackageType
+	}
+	if packageType, exists := layoutPackageTypes[string(moduleType)]; exists {
+		return packageType
+	}
+	return string(moduleType)
+}
+
+// Parses the mapping of package types to target repositories: <package type>=<repository>[,<package type>=<repository>...]
+func ParseRepoMap(repoMap string) (map[string]string, error) {
+	repos := make(map[string]string)
+	for _, mapping := range strings.Split(repoMap, ",") {
+		if mapping = strings.TrimSpace(mapping); mapping == "" {
+			continue
+		}
+		packageType, repo, found := strings.Cut(mapping, "=")
+		if !found || packageType == "" || repo == "" {
+			return nil, errorutils.CheckErrorf("invalid repository mapping '%s'. The expected format is <package type>=<repository>", mapping)
+		}
+		repos[strings.ToLower(packageType)] = repo
+	}
+	if len(repos) == 0 {
+		return nil, errorutils.CheckErrorf("the repository mapping is empty")
+	}
+	return repos, nil
+}
+
+// Verifies that each package type of the manifest is mapped to a target repository.
+func validateRepoMap(manifest *Manifest, repoMap map[string]string) error {
+	var unmapped []string
+	for _, entry := range manifest.Dependencies {
+		if _, exists := repoMap[entry.PackageType]; !exists && !slices.Contains(unmapped, entry.PackageType) {
+			unmapped = append(unmapped, entry.PackageType)
+		}
+	}
+	if len(unmapped) == 0 {
+		return nil
+	}
+	slices.Sort(unmapped)
+	return errorutils.CheckErrorf("the archive includes dependencies of package types which are not mapped to a target repository: %s", strings.Join(unmapped, ", "))
+}
+
+func (m *Manifest) validate() error {
+	if m.Version != manifestVersion {
+		return errorutils.CheckErrorf("unsupported version of the archive manifest: %d", m.Version)
+	}
+	for _, entry := range m.Dependencies {
+		if entry.PackageType == "" || strings.ContainsAny(entry.PackageType, `/\`) || entry.Path == "" || !isSha1(entry.Sha1) || strings.Contains(entry.Path, "..") || strings.HasPrefix(entry.Path, "/") {
+			return errorutils.CheckErrorf("invalid entry in the archive manifest: %+v", *entry)
+		}
+	}
+	return nil
+}
+
+// Since the extracted files are named by their sha1, it must be a hex sha1.
+func isSha1(checksum string) bool {
+	decoded, err := hex.DecodeString(checksum)
+	return err == nil && len(decoded) == sha1.Size
+}
@@ -0,0 +1,101 @@
+package execute
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const certificateValidity = 24 * time.Hour
+
+// An ephemeral certificate authority, which issues the certificates the proxy presents for the Artifactory host.
+// It's trusted only by the wrapped command, through the CA bundle in its environment, and it's discarded when the command ends.
+type certificateAuthority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	mutex       sync.Mutex
+	leaves      map[string]*tls.Certificate
+}
+
+func newCertificateAuthority() (*certificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	template, err := newCertificateTemplate("JFrog CLI exec proxy CA")
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &certificateAuthority{certificate: certificate, key: key, leaves: make(map[string]*tls.Certificate)}, nil
+}
+
+func newCertificateTemplate(commonName string) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certificateValidity),
+	}, nil
+}
+
+// Returns the certificate of the CA in PEM format.
+func (ca *certificateAuthority) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.certificate.Raw})
+}
+
+// Returns a certificate for the host, signed by the CA. The certificates are issued once per host.
+func (ca *certificateAuthority) leafCertificate(host string) (*tls.Certificate, error) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	if leaf, exists := ca.leaves[host]; exists {
+		return leaf, nil
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	template, err := newCertificateTemplate(host)
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	leaf := &tls.Certificate{Certificate: [][]byte{der, ca.certificate.Raw}, PrivateKey: key}
+	ca.leaves[host] = leaf
+	return leaf, nil
+}
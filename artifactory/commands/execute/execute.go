@@ -0,0 +1,307 @@
+package execute
+
+import (
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	caFileName       = "exec-ca.pem"
+	caBundleFileName = "exec-ca-bundle.pem"
+)
+
+// The environment variables through which common tools read the proxy.
+var proxyEnvVars = []string{"HTTP_PROXY", "HTTPS_PROXY", "http_proxy", "https_proxy"}
+
+// The environment variables through which common tools read a CA bundle, which replaces the system CA certificates.
+var caBundleEnvVars = []string{"SSL_CERT_FILE", "REQUESTS_CA_BUNDLE", "CURL_CA_BUNDLE", "PIP_CERT", "GIT_SSL_CAINFO", "CARGO_HTTP_CAINFO"}
+
+// The environment variables through which common tools read the hosts which they connect to directly, rather than through the proxy.
+var noProxyEnvVars = []string{"NO_PROXY", "no_proxy"}
+
+// Runs any command with a local proxy in its environment, for tools which JFrog CLI doesn't support natively.
+// The files the command downloads from Artifactory through the proxy are collected into the build-info dependencies,
+// and after the command succeeds, the files which match the artifacts patterns are uploaded to the target and added to the build-info.
+type ExecCommand struct {
+	args               []string
+	artifacts          []string
+	target             string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewExecCommand() *ExecCommand {
+	return &ExecCommand{}
+}
+
+func (ec *ExecCommand) SetArgs(args []string) *ExecCommand {
+	ec.args = args
+	return ec
+}
+
+// Sets the wildcard patterns of the local files, which are uploaded to the target after the command succeeds.
+func (ec *ExecCommand) SetArtifacts(artifacts []string) *ExecCommand {
+	ec.artifacts = artifacts
+	return ec
+}
+
+// Sets the target of the artifacts, in the form of <repository>/<path>.
+func (ec *ExecCommand) SetTarget(target string) *ExecCommand {
+	ec.target = target
+	return ec
+}
+
+func (ec *ExecCommand) SetServerDetails(serverDetails *config.ServerDetails) *ExecCommand {
+	ec.serverDetails = serverDetails
+	return ec
+}
+
+func (ec *ExecCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *ExecCommand {
+	ec.buildConfiguration = buildConfiguration
+	return ec
+}
+
+func (ec *ExecCommand) CommandName() string {
+	return "rt_exec"
+}
+
+func (ec *ExecCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ec.serverDetails, nil
+}
+
+func (ec *ExecCommand) Run() (err error) {
+	if len(ec.args) == 0 {
+		return errorutils.CheckErrorf("the command to run is missing")
+	}
+	if len(ec.artifacts) > 0 && ec.target == "" {
+		return errorutils.CheckErrorf("the artifacts can't be uploaded without a target. Please provide the --target option")
+	}
+	collectBuildInfo, err := ec.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	var env []string
+	var p *proxy
+	if collectBuildInfo {
+		var tempDir string
+		if tempDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+		}()
+		if p, err = newProxy(ec.serverDetails.ArtifactoryUrl, ec.serverDetails.InsecureTls); err != nil {
+			return err
+		}
+		if err = p.start(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, p.close())
+		}()
+		if env, err = p.environment(tempDir); err != nil {
+			return err
+		}
+	}
+	if err = runCommand(env, ec.args); err != nil {
+		return err
+	}
+	moduleId, err := ec.getModuleId()
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		dependencies := p.getDependencies()
+		log.Info("Collected", len(dependencies), "dependencies downloaded from Artifactory")
+		if err = buildinfoutils.SaveDependencies(ec.buildConfiguration, moduleId, buildinfo.Generic, dependencies); err != nil {
+			return err
+		}
+	}
+	if len(ec.artifacts) == 0 {
+		return nil
+	}
+	return ec.upload(moduleId, collectBuildInfo)
+}
+
+// Writes the CA bundle of the command, and returns the environment variables which direct the command to the proxy.
+// The CA bundle includes the system CA certificates, so the command still trusts the hosts whose connections are tunneled.
+func (p *proxy) environment(tempDir string) ([]string, error) {
+	caPath := filepath.Join(tempDir, caFileName)
+	if err := os.WriteFile(caPath, p.ca.pem(), 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	systemCaBundle, err := readSystemCaBundle()
+	if err != nil {
+		return nil, err
+	}
+	caBundlePath := filepath.Join(tempDir, caBundleFileName)
+	caBundle := append(systemCaBundle, '\n')
+	if err = os.WriteFile(caBundlePath, append(caBundle, p.ca.pem()...), 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var env []string
+	for _, name := range proxyEnvVars {
+		env = append(env, name+"="+p.url())
+	}
+	if noProxy := p.getNoProxy(); noProxy != "" {
+		for _, name := range noProxyEnvVars {
+			env = append(env, name+"="+noProxy)
+		}
+	}
+	for _, name := range caBundleEnvVars {
+		env = append(env, name+"="+caBundlePath)
+	}
+	// Node.js adds the extra CA certificates to its own bundle.
+	return append(env, "NODE_EXTRA_CA_CERTS="+caPath), nil
+}
+
+// Returns the inherited NO_PROXY value of the command, without the entries which match the Artifactory host,
+// so the downloads from Artifactory still pass through the proxy.
+func (p *proxy) getNoProxy() string {
+	var entries []string
+	for _, name := range noProxyEnvVars {
+		if value := os.Getenv(name); value != "" {
+			entries = strings.Split(value, ",")
+			break
+		}
+	}
+	var kept []string
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if matchesNoProxyEntry(p.artifactoryUrl.Hostname(), entry) {
+			log.Debug("The NO_PROXY entry '" + entry + "' is removed from the environment of the command, since it matches the Artifactory host.")
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	return strings.Join(kept, ",")
+}
+
+// Returns true if the host matches a NO_PROXY entry: '*', a domain and its subdomains, a host with a port, or an IP range.
+func matchesNoProxyEntry(host, entry string) bool {
+	if entry == "*" {
+		return true
+	}
+	if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+		ip := net.ParseIP(host)
+		return ip != nil && ipNet.Contains(ip)
+	}
+	if entryHost, _, err := net.SplitHostPort(entry); err == nil {
+		entry = entryHost
+	}
+	entry = strings.ToLower(strings.TrimPrefix(entry, "."))
+	host = strings.ToLower(host)
+	return host == entry || strings.HasSuffix(host, "."+entry)
+}
+
+// Reads the CA bundle the command would otherwise use: the bundle of SSL_CERT_FILE if it's set, or the CA certificates of the system.
+func readSystemCaBundle() ([]byte, error) {
+	if sslCertFile := os.Getenv("SSL_CERT_FILE"); sslCertFile != "" {
+		return readCaBundleFile(sslCertFile)
+	}
+	caBundle, err := readSystemCaCertificates()
+	if err != nil {
+		return nil, err
+	}
+	if len(caBundle) == 0 {
+		log.Debug("The system CA certificates were not found. The command will trust only the CA of the proxy.")
+	}
+	return caBundle, nil
+}
+
+// Reads a CA bundle file. Returns nil if the file doesn't exist.
+func readCaBundleFile(path string) ([]byte, error) {
+	exists, err := fileutils.IsFileExists(path, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	return content, errorutils.CheckError(err)
+}
+
+// The module is the build configuration module, or the name of the working directory.
+func (ec *ExecCommand) getModuleId() (string, error) {
+	if module := ec.buildConfiguration.GetModule(); module != "" {
+		return module, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Base(wd), nil
+}
+
+// Uploads the files which match the artifacts patterns to the target, and saves them as build-info artifacts.
+func (ec *ExecCommand) upload(moduleId string, collectBuildInfo bool) (err error) {
+	target := ec.target
+	if !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+	var uploadParams []services.UploadParams
+	for _, pattern := range ec.artifacts {
+		up := services.NewUploadParams()
+		up.CommonParams = &specutils.CommonParams{Pattern: pattern, Target: target, Recursive: true}
+		up.Flat = true
+		if collectBuildInfo {
+			if up.BuildProps, err = build.CreateBuildPropsFromConfiguration(ec.buildConfiguration); err != nil {
+				return err
+			}
+		}
+		uploadParams = append(uploadParams, up)
+	}
+	servicesManager, err := utils.CreateServiceManager(ec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close(), summary.TransferDetailsReader.Close())
+	}()
+	log.Info("Uploaded", summary.TotalSucceeded, "artifacts to", ec.target)
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to upload %d artifacts to Artifactory. See Artifactory logs for more details", summary.TotalFailed)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveArtifacts(ec.buildConfiguration, moduleId, buildinfo.Generic, artifacts)
+}
+
+func runCommand(env, args []string) error {
+	executablePath, err := exec.LookPath(args[0])
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the executable %s: %s", args[0], err.Error())
+	}
+	log.Debug("Running command:", executablePath, args[1:])
+	cmd := exec.Command(executablePath, args[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
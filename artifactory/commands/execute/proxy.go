@@ -0,0 +1,414 @@
+package execute
+
+import (
+	"bufio"
+	"crypto/md5"  // #nosec G501 -- md5 is one of the build-info checksums.
+	"crypto/sha1" // #nosec G505 -- sha1 is one of the build-info checksums.
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	sha1Header     = "X-Checksum-Sha1"
+	connectedReply = "HTTP/1.1 200 Connection Established\r\n\r\n"
+
+	readHeaderTimeout = time.Minute
+)
+
+// The headers which apply to a single connection, and therefore aren't forwarded.
+var hopByHopHeaders = []string{"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization", "Proxy-Connection", "Te", "Trailer", "Upgrade"}
+
+// A local HTTP(S) proxy, which observes the files the wrapped command downloads from Artifactory.
+// The HTTPS connections to the Artifactory host are terminated with a certificate of an ephemeral CA, so their requests can be observed,
+// while the connections to other hosts are tunneled as is.
+// A download is recorded as a dependency once its whole content passed through the proxy, and its sha1 matches the checksum Artifactory reported.
+type proxy struct {
+	artifactoryUrl  *url.URL
+	artifactoryHost string
+	ca              *certificateAuthority
+	transport       http.RoundTripper
+	// Returns the upstream proxy of a request, which the tunneled connections pass through too.
+	upstreamProxy func(*http.Request) (*url.URL, error)
+	listener      net.Listener
+	server        *http.Server
+	mutex         sync.Mutex
+	dependencies  []buildinfo.Dependency
+	recorded      map[string]bool
+}
+
+func newProxy(artifactoryUrl string, insecureTls bool) (*proxy, error) {
+	parsedUrl, err := url.Parse(artifactoryUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if parsedUrl.Host == "" {
+		return nil, errorutils.CheckErrorf("the Artifactory URL '%s' is invalid", artifactoryUrl)
+	}
+	if !strings.HasSuffix(parsedUrl.Path, "/") {
+		parsedUrl.Path += "/"
+	}
+	ca, err := newCertificateAuthority()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// The content is passed to the command as is, so it can be compared to the checksums of Artifactory.
+	transport.DisableCompression = true
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureTls} // #nosec G402 -- Follows the insecure TLS configuration of the server.
+	return &proxy{
+		artifactoryUrl:  parsedUrl,
+		artifactoryHost: hostWithPort(parsedUrl),
+		ca:              ca,
+		transport:       transport,
+		upstreamProxy:   transport.Proxy,
+		recorded:        make(map[string]bool),
+	}, nil
+}
+
+// Returns the host of the URL, with the default port of its scheme if it has no port.
+func hostWithPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if u.Scheme == "http" {
+		return net.JoinHostPort(u.Hostname(), "80")
+	}
+	return net.JoinHostPort(u.Hostname(), "443")
+}
+
+// Starts listening on a random local port.
+func (p *proxy) start() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	p.listener = listener
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: readHeaderTimeout}
+	go func() {
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Debug("The proxy stopped:", err.Error())
+		}
+	}()
+	return nil
+}
+
+// Returns the URL of the proxy.
+func (p *proxy) url() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+func (p *proxy) close() error {
+	if p.server == nil {
+		return nil
+	}
+	return errorutils.CheckError(p.server.Close())
+}
+
+// Returns the dependencies recorded so far, in the order of their downloads.
+func (p *proxy) getDependencies() []buildinfo.Dependency {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]buildinfo.Dependency(nil), p.dependencies...)
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.handleConnect(w, r)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "the request isn't a proxy request", http.StatusBadRequest)
+		return
+	}
+	resp, err := p.roundTrip(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		log.Debug("Failed to forward the response of", r.URL.String()+":", err.Error())
+	}
+}
+
+func (p *proxy) handleConnect(w http.ResponseWriter, r *http.Request) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "the connection can't be tunneled", http.StatusInternalServerError)
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		log.Debug("Failed to tunnel the connection to", r.Host+":", err.Error())
+		return
+	}
+	if _, err = io.WriteString(conn, connectedReply); err != nil {
+		_ = conn.Close()
+		return
+	}
+	if r.Host == p.artifactoryHost {
+		p.intercept(conn, r.Host)
+		return
+	}
+	p.tunnel(conn, r.Host)
+}
+
+// Terminates the TLS connection to the Artifactory host, and forwards its requests one after the other.
+func (p *proxy) intercept(conn net.Conn, host string) {
+	defer func() {
+		_ = conn.Close()
+	}()
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return
+	}
+	tlsConn := tls.Server(conn, &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return p.ca.leafCertificate(hostname)
+		},
+	})
+	if err = tlsConn.Handshake(); err != nil {
+		log.Debug("The TLS handshake with the command failed. Please make sure the command trusts the CA bundle of jf exec:", err.Error())
+		return
+	}
+	reader := bufio.NewReader(tlsConn)
+	for {
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Debug("Failed to read a request to", host+":", err.Error())
+			}
+			return
+		}
+		req.URL.Scheme = "https"
+		req.URL.Host = req.Host
+		if req.URL.Host == "" {
+			req.URL.Host = host
+		}
+		resp, err := p.roundTrip(req)
+		if err != nil {
+			resp = newErrorResponse(req, err)
+		}
+		err = resp.Write(tlsConn)
+		_ = resp.Body.Close()
+		if err != nil || req.Close || resp.Close {
+			return
+		}
+	}
+}
+
+// Forwards the request, and records the response if it's a download from Artifactory.
+func (p *proxy) roundTrip(r *http.Request) (*http.Response, error) {
+	req := r.Clone(r.Context())
+	req.RequestURI = ""
+	for _, header := range hopByHopHeaders {
+		req.Header.Del(header)
+	}
+	resp, err := p.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, header := range hopByHopHeaders {
+		resp.Header.Del(header)
+	}
+	if req.Method == http.MethodGet && resp.StatusCode == http.StatusOK && resp.Header.Get(sha1Header) != "" {
+		if repoPath := p.getRepoPath(req.URL); repoPath != "" {
+			resp.Body = p.newRecordingBody(resp.Body, repoPath, resp.Header.Get(sha1Header))
+		}
+	}
+	return resp, nil
+}
+
+// Returns the path of the downloaded file in Artifactory, including its repository, or an empty string if the URL isn't of the Artifactory server.
+// The repositories of package managers are also served under api/<package type>/, which isn't part of the path.
+func (p *proxy) getRepoPath(u *url.URL) string {
+	if hostWithPort(u) != p.artifactoryHost {
+		return ""
+	}
+	repoPath, found := strings.CutPrefix(u.Path, p.artifactoryUrl.Path)
+	if !found {
+		return ""
+	}
+	if apiPath, isApi := strings.CutPrefix(repoPath, "api/"); isApi {
+		_, repoPath, _ = strings.Cut(apiPath, "/")
+	}
+	return repoPath
+}
+
+func (p *proxy) addDependency(dependency buildinfo.Dependency) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.recorded[dependency.Id] {
+		return
+	}
+	p.recorded[dependency.Id] = true
+	p.dependencies = append(p.dependencies, dependency)
+}
+
+// Calculates the checksums of a download while the command reads it.
+type recordingBody struct {
+	body         io.ReadCloser
+	proxy        *proxy
+	repoPath     string
+	expectedSha1 string
+	sha1         hash.Hash
+	md5          hash.Hash
+	sha256       hash.Hash
+	writer       io.Writer
+	done         bool
+}
+
+func (p *proxy) newRecordingBody(body io.ReadCloser, repoPath, expectedSha1 string) *recordingBody {
+	rb := &recordingBody{body: body, proxy: p, repoPath: repoPath, expectedSha1: expectedSha1,
+		sha1: sha1.New(), md5: md5.New(), sha256: sha256.New()} // #nosec G401 -- md5 and sha1 are build-info checksums.
+	rb.writer = io.MultiWriter(rb.sha1, rb.md5, rb.sha256)
+	return rb
+}
+
+func (rb *recordingBody) Read(b []byte) (int, error) {
+	n, err := rb.body.Read(b)
+	if n > 0 {
+		_, _ = rb.writer.Write(b[:n])
+	}
+	if errors.Is(err, io.EOF) && !rb.done {
+		rb.done = true
+		rb.record()
+	}
+	return n, err
+}
+
+func (rb *recordingBody) Close() error {
+	return rb.body.Close()
+}
+
+// Records the download, if its content matches the checksum Artifactory reported.
+func (rb *recordingBody) record() {
+	actualSha1 := hex.EncodeToString(rb.sha1.Sum(nil))
+	if !strings.EqualFold(actualSha1, rb.expectedSha1) {
+		log.Debug("The sha1 of the download", rb.repoPath, "doesn't match its checksum in Artifactory, and therefore it's not recorded.")
+		return
+	}
+	log.Debug("Recorded the dependency", rb.repoPath)
+	rb.proxy.addDependency(buildinfo.Dependency{
+		Id:   rb.repoPath,
+		Type: strings.TrimPrefix(path.Ext(rb.repoPath), "."),
+		Checksum: buildinfo.Checksum{
+			Sha1:   actualSha1,
+			Md5:    hex.EncodeToString(rb.md5.Sum(nil)),
+			Sha256: hex.EncodeToString(rb.sha256.Sum(nil)),
+		},
+	})
+}
+
+func newErrorResponse(req *http.Request, err error) *http.Response {
+	message := err.Error()
+	return &http.Response{
+		StatusCode:    http.StatusBadGateway,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Request:       req,
+		Header:        http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(message)),
+		ContentLength: int64(len(message)),
+		Close:         true,
+	}
+}
+
+// Copies the data of the connection to the host and back, until either side closes its connection.
+func (p *proxy) tunnel(conn net.Conn, host string) {
+	target, err := p.dial(host)
+	if err != nil {
+		log.Debug("Failed to connect to", host+":", err.Error())
+		_ = conn.Close()
+		return
+	}
+	done := make(chan struct{}, 2)
+	copyData := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go copyData(target, conn)
+	go copyData(conn, target)
+	<-done
+	_ = conn.Close()
+	_ = target.Close()
+}
+
+// Connects to the host, through the upstream proxy which the environment of JFrog CLI sets for HTTPS, if any.
+func (p *proxy) dial(host string) (net.Conn, error) {
+	var proxyUrl *url.URL
+	if p.upstreamProxy != nil {
+		var err error
+		if proxyUrl, err = p.upstreamProxy(&http.Request{URL: &url.URL{Scheme: "https", Host: host}}); err != nil {
+			return nil, err
+		}
+	}
+	if proxyUrl == nil {
+		return net.Dial("tcp", host)
+	}
+	conn, err := net.Dial("tcp", hostWithPort(proxyUrl))
+	if err != nil {
+		return nil, err
+	}
+	if proxyUrl.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyUrl.Hostname(), MinVersion: tls.VersionTLS12})
+	}
+	connectRequest := &http.Request{Method: http.MethodConnect, URL: &url.URL{Opaque: host}, Host: host, Header: make(http.Header)}
+	if proxyUrl.User != nil {
+		password, _ := proxyUrl.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyUrl.User.Username() + ":" + password))
+		connectRequest.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := func() (*http.Response, error) {
+		if err := connectRequest.Write(conn); err != nil {
+			return nil, err
+		}
+		return http.ReadResponse(reader, connectRequest)
+	}()
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, errorutils.CheckErrorf("the proxy %s failed to connect to %s: %s", proxyUrl.Host, host, resp.Status)
+	}
+	// The reader may have buffered the first data of the host.
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// A connection whose data is read through a buffered reader.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (bc *bufferedConn) Read(b []byte) (int, error) {
+	return bc.reader.Read(b)
+}
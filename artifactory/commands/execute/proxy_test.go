@@ -0,0 +1,221 @@
+package execute
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContent = "test content"
+
+// A fake Artifactory, which serves every file under /artifactory/ with the test content and its sha1 header.
+// The files under /artifactory/corrupted/ are served with a wrong sha1, and the files under /artifactory/truncated/ are cut before their end.
+func newFakeArtifactory() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/artifactory/") {
+			_, _ = io.WriteString(w, testContent)
+			return
+		}
+		checksum := sha1.Sum([]byte(testContent))
+		if strings.HasPrefix(r.URL.Path, "/artifactory/corrupted/") {
+			checksum = sha1.Sum([]byte("other content"))
+		}
+		w.Header().Set(sha1Header, hex.EncodeToString(checksum[:]))
+		if strings.HasPrefix(r.URL.Path, "/artifactory/truncated/") {
+			w.Header().Set("Content-Length", strconv.Itoa(len(testContent)+10))
+		}
+		_, _ = io.WriteString(w, testContent)
+	})
+}
+
+func expectedDependency(id, fileType string) buildinfo.Dependency {
+	sha1Checksum := sha1.Sum([]byte(testContent))
+	md5Checksum := md5.Sum([]byte(testContent))
+	sha256Checksum := sha256.Sum256([]byte(testContent))
+	return buildinfo.Dependency{Id: id, Type: fileType, Checksum: buildinfo.Checksum{
+		Sha1:   hex.EncodeToString(sha1Checksum[:]),
+		Md5:    hex.EncodeToString(md5Checksum[:]),
+		Sha256: hex.EncodeToString(sha256Checksum[:]),
+	}}
+}
+
+// Starts a proxy of the fake Artifactory server, and returns it with a client which uses it, as the wrapped command would.
+func startTestProxy(t *testing.T, server *httptest.Server) (*proxy, *http.Client) {
+	p, err := newProxy(server.URL+"/artifactory", false)
+	require.NoError(t, err)
+	p.transport = server.Client().Transport
+	require.NoError(t, p.start())
+	t.Cleanup(func() {
+		assert.NoError(t, p.close())
+	})
+	proxyUrl, err := url.Parse(p.url())
+	require.NoError(t, err)
+	rootCAs := x509.NewCertPool()
+	require.True(t, rootCAs.AppendCertsFromPEM(p.ca.pem()))
+	transport := &http.Transport{Proxy: http.ProxyURL(proxyUrl), TLSClientConfig: &tls.Config{RootCAs: rootCAs}}
+	t.Cleanup(transport.CloseIdleConnections)
+	return p, &http.Client{Transport: transport}
+}
+
+// Downloads the URL through the proxy, and returns the error of reading the response.
+func download(t *testing.T, client *http.Client, downloadUrl string) error {
+	resp, err := client.Get(downloadUrl)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, resp.Body.Close())
+	}()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	assert.Equal(t, testContent, string(content))
+	return err
+}
+
+func TestProxyRecordsDownloads(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		newServer func(http.Handler) *httptest.Server
+	}{
+		{"https", httptest.NewTLSServer},
+		{"http", httptest.NewServer},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server := test.newServer(newFakeArtifactory())
+			defer server.Close()
+			p, client := startTestProxy(t, server)
+
+			assert.NoError(t, download(t, client, server.URL+"/artifactory/generic-local/libs/lib-1.0.jar"))
+			assert.NoError(t, download(t, client, server.URL+"/artifactory/api/npm/npm-remote/lib/-/lib-1.0.tgz"))
+			// Downloaded again, and recorded once.
+			assert.NoError(t, download(t, client, server.URL+"/artifactory/generic-local/libs/lib-1.0.jar"))
+			// Not recorded: A truncated download, a download whose checksum doesn't match, and a download from outside Artifactory.
+			assert.Error(t, download(t, client, server.URL+"/artifactory/truncated/file.zip"))
+			assert.NoError(t, download(t, client, server.URL+"/artifactory/corrupted/file.zip"))
+			assert.NoError(t, download(t, client, server.URL+"/other/file.zip"))
+
+			assert.Equal(t, []buildinfo.Dependency{
+				expectedDependency("generic-local/libs/lib-1.0.jar", "jar"),
+				expectedDependency("npm-remote/lib/-/lib-1.0.tgz", "tgz"),
+			}, p.getDependencies())
+		})
+	}
+}
+
+func TestProxyTunnelsOtherHosts(t *testing.T) {
+	artifactoryServer := httptest.NewTLSServer(newFakeArtifactory())
+	defer artifactoryServer.Close()
+	otherServer := httptest.NewTLSServer(newFakeArtifactory())
+	defer otherServer.Close()
+	p, client := startTestProxy(t, artifactoryServer)
+	// The connection isn't intercepted, so the client trusts the certificate of the other server and not the CA of the proxy.
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(otherServer.Certificate())
+	client.Transport.(*http.Transport).TLSClientConfig.RootCAs = rootCAs
+
+	assert.NoError(t, download(t, client, otherServer.URL+"/artifactory/generic-local/lib.jar"))
+	assert.Empty(t, p.getDependencies())
+
+	// The tunneled connections pass through the upstream proxy.
+	upstream, err := newProxy("https://acme.jfrog.io/artifactory/", false)
+	require.NoError(t, err)
+	var connected []string
+	upstreamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connected = append(connected, r.Method+" "+r.Host)
+		upstream.ServeHTTP(w, r)
+	}))
+	defer upstreamServer.Close()
+	upstreamUrl, err := url.Parse(upstreamServer.URL)
+	require.NoError(t, err)
+	p.upstreamProxy = http.ProxyURL(upstreamUrl)
+	client.Transport.(*http.Transport).CloseIdleConnections()
+
+	assert.NoError(t, download(t, client, otherServer.URL+"/artifactory/generic-local/lib.jar"))
+	assert.Equal(t, []string{http.MethodConnect + " " + strings.TrimPrefix(otherServer.URL, "https://")}, connected)
+}
+
+func TestMatchesNoProxyEntry(t *testing.T) {
+	for _, test := range []struct {
+		entry    string
+		expected bool
+	}{
+		{"*", true},
+		{"acme.jfrog.io", true},
+		{"ACME.jfrog.io:443", true},
+		{".jfrog.io", true},
+		{"jfrog.io", true},
+		{"other.jfrog.io", false},
+		{"me.jfrog.io", false},
+		{"10.0.0.0/8", false},
+	} {
+		assert.Equal(t, test.expected, matchesNoProxyEntry("acme.jfrog.io", test.entry), test.entry)
+	}
+	assert.True(t, matchesNoProxyEntry("10.1.2.3", "10.0.0.0/8"))
+}
+
+func TestGetRepoPath(t *testing.T) {
+	p, err := newProxy("https://acme.jfrog.io/artifactory/", false)
+	require.NoError(t, err)
+	for _, test := range []struct {
+		url      string
+		expected string
+	}{
+		{"https://acme.jfrog.io/artifactory/generic-local/a/b.zip", "generic-local/a/b.zip"},
+		{"https://acme.jfrog.io:443/artifactory/generic-local/a/b.zip", "generic-local/a/b.zip"},
+		{"https://acme.jfrog.io/artifactory/api/pypi/pypi-remote/packages/a.whl", "pypi-remote/packages/a.whl"},
+		{"https://acme.jfrog.io/xray/api/v1/system/version", ""},
+		{"https://other.jfrog.io/artifactory/generic-local/a/b.zip", ""},
+		{"http://acme.jfrog.io/artifactory/generic-local/a/b.zip", ""},
+	} {
+		parsedUrl, err := url.Parse(test.url)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, p.getRepoPath(parsedUrl), test.url)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	systemBundlePath := filepath.Join(t.TempDir(), "system.pem")
+	require.NoError(t, os.WriteFile(systemBundlePath, []byte("system certificates"), 0600))
+	t.Setenv("SSL_CERT_FILE", systemBundlePath)
+	p, err := newProxy("https://acme.jfrog.io/artifactory/", false)
+	require.NoError(t, err)
+	require.NoError(t, p.start())
+	defer func() {
+		assert.NoError(t, p.close())
+	}()
+	tempDir := t.TempDir()
+	env, err := p.environment(tempDir)
+	require.NoError(t, err)
+	caBundlePath := filepath.Join(tempDir, caBundleFileName)
+	assert.Contains(t, env, "HTTPS_PROXY="+p.url())
+	assert.Contains(t, env, "SSL_CERT_FILE="+caBundlePath)
+	assert.Contains(t, env, "NODE_EXTRA_CA_CERTS="+filepath.Join(tempDir, caFileName))
+	for _, name := range noProxyEnvVars {
+		assert.NotContains(t, strings.Join(env, "\n"), name+"=")
+	}
+	caBundle, err := os.ReadFile(caBundlePath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(caBundle), "system certificates\n"))
+	assert.True(t, strings.HasSuffix(string(caBundle), string(p.ca.pem())))
+
+	// The inherited NO_PROXY is kept, without the entries which would bypass the proxy for Artifactory.
+	t.Setenv("NO_PROXY", "localhost, .jfrog.io,10.0.0.0/8")
+	env, err = p.environment(tempDir)
+	require.NoError(t, err)
+	assert.Contains(t, env, "NO_PROXY=localhost,10.0.0.0/8")
+	assert.Contains(t, env, "no_proxy=localhost,10.0.0.0/8")
+}
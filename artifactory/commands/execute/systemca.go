@@ -0,0 +1,23 @@
+//go:build !windows
+
+package execute
+
+// The locations of the system CA bundle on Linux and macOS, which is extended with the CA of the proxy.
+var systemCaBundlePaths = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/tls/cacert.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// Returns the first system CA bundle which exists.
+func readSystemCaCertificates() ([]byte, error) {
+	for _, path := range systemCaBundlePaths {
+		caBundle, err := readCaBundleFile(path)
+		if err != nil || caBundle != nil {
+			return caBundle, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,38 @@
+package execute
+
+import (
+	"encoding/pem"
+	"errors"
+	"unsafe"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/sys/windows"
+)
+
+// Windows keeps the system CA certificates in its ROOT certificate store rather than in a bundle file.
+// Returns the certificates of the store as a PEM bundle.
+func readSystemCaCertificates() (caBundle []byte, err error) {
+	storeName, err := windows.UTF16PtrFromString("ROOT")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	store, err := windows.CertOpenSystemStore(0, storeName)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(windows.CertCloseStore(store, 0)))
+	}()
+	var cert *windows.CertContext
+	for {
+		// The enumeration ends with an error, once there are no more certificates.
+		if cert, err = windows.CertEnumCertificatesInStore(store, cert); err != nil {
+			if errors.Is(err, windows.Errno(windows.CRYPT_E_NOT_FOUND)) {
+				return caBundle, nil
+			}
+			return nil, errorutils.CheckError(err)
+		}
+		encodedCert := unsafe.Slice(cert.EncodedCert, cert.Length)
+		caBundle = append(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: encodedCert})...)
+	}
+}
@@ -0,0 +1,211 @@
+package gomodules
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	goSumFileName = "go.sum"
+	goModFileName = "go.mod"
+)
+
+// Resolves the complete module graph of go.sum through the Go resolver repository, and writes it to a directory in the GOPROXY layout,
+// so the modules can be carried to an environment without access to Artifactory. The content of each module is verified against its go.sum hash.
+// The module zips are collected into the build-info dependencies.
+type ModVendorExportCommand struct {
+	configFilePath     string
+	args               []string
+	outputDir          string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewModVendorExportCommand() *ModVendorExportCommand {
+	return &ModVendorExportCommand{}
+}
+
+func (mec *ModVendorExportCommand) SetConfigFilePath(configFilePath string) *ModVendorExportCommand {
+	mec.configFilePath = configFilePath
+	return mec
+}
+
+func (mec *ModVendorExportCommand) SetArgs(args []string) *ModVendorExportCommand {
+	mec.args = args
+	return mec
+}
+
+func (mec *ModVendorExportCommand) CommandName() string {
+	return "rt_go_mod_vendor_export"
+}
+
+func (mec *ModVendorExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return mec.serverDetails, nil
+}
+
+// Reads the resolver configuration, and extracts the build-info options and the output directory from the arguments.
+func (mec *ModVendorExportCommand) Init() (err error) {
+	if mec.args, mec.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(mec.args); err != nil {
+		return err
+	}
+	if len(mec.args) != 1 {
+		return errorutils.CheckErrorf("expected a single argument, the output directory, but got: %v", mec.args)
+	}
+	mec.outputDir = mec.args[0]
+	resolverConfig, err := projectconfig.GetRepoConfig(mec.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig == nil {
+		return errorutils.CheckErrorf("the resolver repository is missing from the config file (%s). Please run 'jf go-config' with the --repo-resolve option", mec.configFilePath)
+	}
+	if mec.serverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	mec.repo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (mec *ModVendorExportCommand) Run() error {
+	modules, err := readGoSum(goSumFileName)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(mec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	downloadDir := filepath.Join(mec.outputDir, cacheDownloadDir)
+	var dependencies []buildinfo.Dependency
+	for _, mv := range modules {
+		dependency, err := exportModule(servicesManager, mec.repo, downloadDir, mv)
+		if err != nil {
+			return err
+		}
+		if dependency != nil {
+			dependencies = append(dependencies, *dependency)
+		}
+	}
+	log.Info("Exported", len(modules), "module versions to", downloadDir)
+	collectBuildInfo, err := mec.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	moduleId, err := mec.getModuleId()
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveDependencies(mec.buildConfiguration, moduleId, buildinfo.Go, dependencies)
+}
+
+// Downloads the files of a module version, and verifies them against their go.sum hashes.
+// Returns the dependency of the module zip, or nil if only the go.mod file of the module is required.
+func exportModule(reader artifactoryutils.RemoteFileReader, repo, downloadDir string, mv *moduleVersion) (*buildinfo.Dependency, error) {
+	log.Debug("Exporting", mv.String())
+	if _, err := downloadVersionFile(reader, repo, downloadDir, mv, infoExtension); err != nil {
+		return nil, err
+	}
+	modPath, err := downloadVersionFile(reader, repo, downloadDir, mv, modExtension)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyHash(mv, modPath, mv.modHash, hashMod); err != nil {
+		return nil, err
+	}
+	if err = addToList(filepath.Join(filepath.Dir(modPath), listFileName), mv.version); err != nil {
+		return nil, err
+	}
+	if mv.zipHash == "" {
+		return nil, nil
+	}
+	zipPath, err := downloadVersionFile(reader, repo, downloadDir, mv, zipExtension)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyHash(mv, zipPath, mv.zipHash, hashZip); err != nil {
+		return nil, err
+	}
+	zipHashPath := zipPath[:len(zipPath)-len(zipExtension)] + zipHashExtension
+	if err = os.WriteFile(zipHashPath, []byte(mv.zipHash), 0644); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	details, err := fileutils.GetFileDetails(zipPath, true)
+	if err != nil {
+		return nil, err
+	}
+	return &buildinfo.Dependency{Id: mv.path + ":" + mv.version, Type: "zip", Checksum: details.Checksum}, nil
+}
+
+// Downloads a file of the module version from the Go API of the repository, and returns its local path.
+func downloadVersionFile(reader artifactoryutils.RemoteFileReader, repo, downloadDir string, mv *moduleVersion, extension string) (localPath string, err error) {
+	relativePath, err := getVersionFilePath(mv, extension)
+	if err != nil {
+		return "", err
+	}
+	localPath = filepath.Join(downloadDir, filepath.FromSlash(relativePath))
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	stream, err := reader.ReadRemoteFile("api/go/" + repo + "/" + relativePath)
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to download %s of %s: %s", extension, mv.String(), err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	file, err := os.Create(localPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = io.Copy(file, stream)
+	return localPath, errorutils.CheckError(err)
+}
+
+// Verifies the hash of a downloaded file against go.sum. Files without a hash in go.sum aren't verified.
+func verifyHash(mv *moduleVersion, localPath, expectedHash string, hashFile func(string) (string, error)) error {
+	if expectedHash == "" {
+		return nil
+	}
+	actualHash, err := hashFile(localPath)
+	if err != nil {
+		return err
+	}
+	if actualHash != expectedHash {
+		return errorutils.CheckErrorf("the hash of %s (%s) doesn't match its hash in go.sum (%s)", filepath.Base(localPath)+" of "+mv.String(), actualHash, expectedHash)
+	}
+	return nil
+}
+
+// The module is the build configuration module, or the module path in go.mod.
+func (mec *ModVendorExportCommand) getModuleId() (string, error) {
+	if module := mec.buildConfiguration.GetModule(); module != "" {
+		return module, nil
+	}
+	content, err := os.ReadFile(goModFileName)
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to read %s: %s", goModFileName, err.Error())
+	}
+	if modulePath := modfile.ModulePath(content); modulePath != "" {
+		return modulePath, nil
+	}
+	return "", errorutils.CheckErrorf("the module path is missing from %s", goModFileName)
+}
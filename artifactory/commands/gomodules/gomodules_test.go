@@ -0,0 +1,159 @@
+package gomodules
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoSum(t *testing.T) {
+	modules, err := parseGoSum([]byte(`github.com/BurntSushi/toml v1.4.0 h1:zip1=
+github.com/BurntSushi/toml v1.4.0/go.mod h1:mod1=
+golang.org/x/sys v0.1.0/go.mod h1:mod2=
+
+github.com/stretchr/testify v1.9.0/go.mod h1:mod3=
+github.com/stretchr/testify v1.9.0 h1:zip3=
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []*moduleVersion{
+		{path: "github.com/BurntSushi/toml", version: "v1.4.0", zipHash: "h1:zip1=", modHash: "h1:mod1="},
+		{path: "golang.org/x/sys", version: "v0.1.0", modHash: "h1:mod2="},
+		{path: "github.com/stretchr/testify", version: "v1.9.0", zipHash: "h1:zip3=", modHash: "h1:mod3="},
+	}, modules)
+
+	_, err = parseGoSum([]byte("github.com/BurntSushi/toml v1.4.0\n"))
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestVersionFilePath(t *testing.T) {
+	mv := &moduleVersion{path: "github.com/BurntSushi/toml", version: "v1.4.0-RC1"}
+	relativePath, err := getVersionFilePath(mv, zipExtension)
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/!burnt!sushi/toml/@v/v1.4.0-!r!c1.zip", relativePath)
+
+	parsed, extension, err := parseVersionFilePath(relativePath)
+	require.NoError(t, err)
+	assert.Equal(t, mv, parsed)
+	assert.Equal(t, zipExtension, extension)
+
+	for _, ignored := range []string{"github.com/!burnt!sushi/toml/@v/list", "github.com/!burnt!sushi/toml/@v/v1.4.0.ziphash", "sumdb/sum.golang.org/latest"} {
+		parsed, _, err = parseVersionFilePath(ignored)
+		assert.NoError(t, err)
+		assert.Nil(t, parsed, ignored)
+	}
+}
+
+// Serves the files of the Go API of a repository from memory.
+type testRemoteFiles map[string][]byte
+
+func (trf testRemoteFiles) ReadRemoteFile(readPath string) (io.ReadCloser, error) {
+	content, exists := trf[readPath]
+	if !exists {
+		return nil, errors.New("404 Not Found")
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// Returns the zip of a module version, with the layout of module zips.
+func createModuleZip(t *testing.T, mv *moduleVersion, goMod string) []byte {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for name, content := range map[string]string{"go.mod": goMod, "lib.go": "package lib\n"} {
+		file, err := writer.Create(mv.String() + "/" + name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+// Adds the files of a module version to the remote files, and sets its go.sum hashes.
+func addTestModule(t *testing.T, remoteFiles testRemoteFiles, mv *moduleVersion, withZip bool) {
+	goMod := "module " + mv.path + "\n"
+	files := map[string][]byte{infoExtension: []byte(`{"Version":"` + mv.version + `"}`), modExtension: []byte(goMod)}
+	if withZip {
+		files[zipExtension] = createModuleZip(t, mv, goMod)
+	}
+	tempDir := t.TempDir()
+	for extension, content := range files {
+		relativePath, err := getVersionFilePath(mv, extension)
+		require.NoError(t, err)
+		remoteFiles["api/go/go-remote/"+relativePath] = content
+		localPath := filepath.Join(tempDir, "file"+extension)
+		require.NoError(t, os.WriteFile(localPath, content, 0644))
+		switch extension {
+		case modExtension:
+			mv.modHash, err = hashMod(localPath)
+		case zipExtension:
+			mv.zipHash, err = hashZip(localPath)
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestExportAndCollect(t *testing.T) {
+	remoteFiles := testRemoteFiles{}
+	withZip := &moduleVersion{path: "github.com/BurntSushi/toml", version: "v1.4.0"}
+	modOnly := &moduleVersion{path: "golang.org/x/sys", version: "v0.1.0"}
+	addTestModule(t, remoteFiles, withZip, true)
+	addTestModule(t, remoteFiles, modOnly, false)
+
+	downloadDir := filepath.Join(t.TempDir(), cacheDownloadDir)
+	dependency, err := exportModule(remoteFiles, "go-remote", downloadDir, withZip)
+	require.NoError(t, err)
+	require.NotNil(t, dependency)
+	assert.Equal(t, "github.com/BurntSushi/toml:v1.4.0", dependency.Id)
+	assert.NotEmpty(t, dependency.Sha256)
+	dependency, err = exportModule(remoteFiles, "go-remote", downloadDir, modOnly)
+	require.NoError(t, err)
+	assert.Nil(t, dependency)
+
+	list, err := os.ReadFile(filepath.Join(downloadDir, "github.com", "!burnt!sushi", "toml", versionsDir, listFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0\n", string(list))
+	zipHash, err := os.ReadFile(filepath.Join(downloadDir, "github.com", "!burnt!sushi", "toml", versionsDir, "v1.4.0"+zipHashExtension))
+	require.NoError(t, err)
+	assert.Equal(t, withZip.zipHash, string(zipHash))
+
+	files, err := collectModuleFiles(downloadDir)
+	require.NoError(t, err)
+	var storagePaths []string
+	for _, file := range files {
+		storagePaths = append(storagePaths, file.storagePath)
+	}
+	// The files are uploaded to the module paths and versions as they are, rather than case-encoded.
+	assert.ElementsMatch(t, []string{
+		"github.com/BurntSushi/toml/@v/v1.4.0.info",
+		"github.com/BurntSushi/toml/@v/v1.4.0.mod",
+		"github.com/BurntSushi/toml/@v/v1.4.0.zip",
+		"golang.org/x/sys/@v/v0.1.0.info",
+		"golang.org/x/sys/@v/v0.1.0.mod",
+	}, storagePaths)
+
+	// A zip which was modified after the export doesn't match its .ziphash.
+	zipPath := filepath.Join(downloadDir, "github.com", "!burnt!sushi", "toml", versionsDir, "v1.4.0"+zipExtension)
+	require.NoError(t, os.WriteFile(zipPath, createModuleZip(t, withZip, "module other\n"), 0644))
+	_, err = collectModuleFiles(downloadDir)
+	assert.ErrorContains(t, err, "doesn't match")
+}
+
+func TestExportHashMismatch(t *testing.T) {
+	remoteFiles := testRemoteFiles{}
+	mv := &moduleVersion{path: "github.com/stretchr/testify", version: "v1.9.0"}
+	addTestModule(t, remoteFiles, mv, true)
+	mv.zipHash = "h1:" + strings.Repeat("A", 43) + "="
+	_, err := exportModule(remoteFiles, "go-remote", t.TempDir(), mv)
+	assert.ErrorContains(t, err, "doesn't match its hash in go.sum")
+
+	_, err = exportModule(remoteFiles, "go-remote", t.TempDir(), &moduleVersion{path: "github.com/missing/module", version: "v1.0.0"})
+	assert.ErrorContains(t, err, "failed to download")
+}
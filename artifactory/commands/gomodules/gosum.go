@@ -0,0 +1,67 @@
+package gomodules
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const modSuffix = "/go.mod"
+
+// A version of a module in the module graph, and its hashes in go.sum.
+// Modules whose content isn't needed for the build have only the hash of their go.mod file.
+type moduleVersion struct {
+	path    string
+	version string
+	zipHash string
+	modHash string
+}
+
+func (mv *moduleVersion) String() string {
+	return mv.path + "@" + mv.version
+}
+
+// Reads the module versions of go.sum, in their order in the file.
+func readGoSum(goSumPath string) ([]*moduleVersion, error) {
+	content, err := os.ReadFile(goSumPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read %s: %s", goSumPath, err.Error())
+	}
+	modules, err := parseGoSum(content)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("unexpected format of %s: %s", goSumPath, err.Error())
+	}
+	return modules, nil
+}
+
+func parseGoSum(content []byte) ([]*moduleVersion, error) {
+	var modules []*moduleVersion
+	indexes := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 || !strings.HasPrefix(fields[2], "h1:") {
+			return nil, errorutils.CheckErrorf("line %d: expected '<module> <version>[/go.mod] h1:<hash>'", lineNumber)
+		}
+		version, isMod := strings.CutSuffix(fields[1], modSuffix)
+		key := fields[0] + "@" + version
+		index, exists := indexes[key]
+		if !exists {
+			index = len(modules)
+			indexes[key] = index
+			modules = append(modules, &moduleVersion{path: fields[0], version: version})
+		}
+		if isMod {
+			modules[index].modHash = fields[2]
+		} else {
+			modules[index].zipHash = fields[2]
+		}
+	}
+	return modules, errorutils.CheckError(scanner.Err())
+}
@@ -0,0 +1,192 @@
+package gomodules
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const goVersionProperty = "go.version"
+
+// Uploads a directory in the GOPROXY layout, such as the output of 'jf go mod-vendor-export', to the Go deployer repository.
+// The files are uploaded to the paths in which the repository stores the module versions, so it serves them as a Go proxy, and the module zips are verified against
+// their .ziphash files before they are uploaded. The uploaded files are collected into the build-info artifacts.
+type ModVendorImportCommand struct {
+	configFilePath     string
+	args               []string
+	inputDir           string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewModVendorImportCommand() *ModVendorImportCommand {
+	return &ModVendorImportCommand{}
+}
+
+func (mic *ModVendorImportCommand) SetConfigFilePath(configFilePath string) *ModVendorImportCommand {
+	mic.configFilePath = configFilePath
+	return mic
+}
+
+func (mic *ModVendorImportCommand) SetArgs(args []string) *ModVendorImportCommand {
+	mic.args = args
+	return mic
+}
+
+func (mic *ModVendorImportCommand) CommandName() string {
+	return "rt_go_mod_vendor_import"
+}
+
+func (mic *ModVendorImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return mic.serverDetails, nil
+}
+
+// Reads the deployer configuration, and extracts the build-info options and the input directory from the arguments.
+func (mic *ModVendorImportCommand) Init() (err error) {
+	if mic.args, mic.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(mic.args); err != nil {
+		return err
+	}
+	if len(mic.args) != 1 {
+		return errorutils.CheckErrorf("expected a single argument, the exported directory, but got: %v", mic.args)
+	}
+	mic.inputDir = mic.args[0]
+	deployerConfig, err := projectconfig.GetRepoConfig(mic.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig == nil {
+		return errorutils.CheckErrorf("the deployer repository is missing from the config file (%s). Please run 'jf go-config' with the --repo-deploy option", mic.configFilePath)
+	}
+	if mic.serverDetails, err = deployerConfig.ServerDetails(); err != nil {
+		return err
+	}
+	mic.repo = deployerConfig.TargetRepo()
+	return nil
+}
+
+func (mic *ModVendorImportCommand) Run() (err error) {
+	downloadDir := filepath.Join(mic.inputDir, cacheDownloadDir)
+	exists, err := fileutils.IsDirExists(downloadDir, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("the directory %s doesn't include a module download cache (%s)", mic.inputDir, cacheDownloadDir)
+	}
+	files, err := collectModuleFiles(downloadDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		log.Info("No modules were found in", downloadDir)
+		return nil
+	}
+	collectBuildInfo, err := mic.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	var uploadParams []services.UploadParams
+	for _, file := range files {
+		up := services.NewUploadParams()
+		up.CommonParams = &specutils.CommonParams{Pattern: file.localPath, Target: mic.repo + "/" + file.storagePath, TargetProps: specutils.NewProperties()}
+		up.TargetProps.AddProperty(goVersionProperty, file.version)
+		up.Flat = true
+		if collectBuildInfo {
+			if up.BuildProps, err = build.CreateBuildPropsFromConfiguration(mic.buildConfiguration); err != nil {
+				return err
+			}
+		}
+		uploadParams = append(uploadParams, up)
+	}
+	servicesManager, err := utils.CreateServiceManager(mic.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close(), summary.TransferDetailsReader.Close())
+	}()
+	log.Info("Imported", summary.TotalSucceeded, "module files to", mic.repo)
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to import %d module files to Artifactory. See Artifactory logs for more details", summary.TotalFailed)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	moduleId := mic.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = filepath.Base(filepath.Clean(mic.inputDir))
+	}
+	return buildinfoutils.SaveArtifacts(mic.buildConfiguration, moduleId, buildinfo.Go, artifacts)
+}
+
+// A file of a module version in the download cache.
+type moduleFile struct {
+	localPath string
+	// The path of the file in the Go repository.
+	storagePath string
+	version     string
+}
+
+// Returns the info, mod and zip files of the download cache. Each zip with a .ziphash file is verified against it.
+func collectModuleFiles(downloadDir string) ([]moduleFile, error) {
+	var files []moduleFile
+	err := filepath.WalkDir(downloadDir, func(localPath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(downloadDir, localPath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		mv, extension, err := parseVersionFilePath(relativePath)
+		if err != nil || mv == nil {
+			return err
+		}
+		if extension == zipExtension {
+			if err = verifyZipHash(mv, localPath); err != nil {
+				return err
+			}
+		}
+		files = append(files, moduleFile{localPath: localPath, storagePath: getStoragePath(mv, extension), version: mv.version})
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+func verifyZipHash(mv *moduleVersion, zipPath string) error {
+	zipHash, err := os.ReadFile(zipPath[:len(zipPath)-len(zipExtension)] + zipHashExtension)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Debug("The zip of", mv.String(), "has no .ziphash file, and therefore isn't verified")
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	return verifyHash(mv, zipPath, strings.TrimSpace(string(zipHash)), hashZip)
+}
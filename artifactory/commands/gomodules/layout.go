@@ -0,0 +1,97 @@
+package gomodules
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// The files of a module version in the GOPROXY layout, which is also the layout of the download cache of the module cache.
+const (
+	versionsDir   = "@v"
+	listFileName  = "list"
+	infoExtension = ".info"
+	modExtension  = ".mod"
+	zipExtension  = ".zip"
+	// The go command keeps the hash of each downloaded zip next to it, and verifies it before the zip is extracted.
+	zipHashExtension = ".ziphash"
+)
+
+// The download cache, under the exported directory. The directory can be used as GOMODCACHE, and its download cache as GOPROXY=file://<dir>/cache/download.
+var cacheDownloadDir = filepath.Join("cache", "download")
+
+// Returns the path of a file of the module version in the GOPROXY layout, in which the module path and the version are case-encoded.
+func getVersionFilePath(mv *moduleVersion, extension string) (string, error) {
+	escapedPath, err := module.EscapePath(mv.path)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	escapedVersion, err := module.EscapeVersion(mv.version)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return path.Join(escapedPath, versionsDir, escapedVersion+extension), nil
+}
+
+// Returns the path of a file of the module version in a Go repository in Artifactory. Artifactory stores the module path and
+// the version as they are, and case-encodes them only in the paths of its GOPROXY API.
+func getStoragePath(mv *moduleVersion, extension string) string {
+	return path.Join(mv.path, versionsDir, mv.version+extension)
+}
+
+// Parses the path of a file in the GOPROXY layout, relative to the download cache.
+// Returns nil if the file isn't an info, mod or zip file of a module version.
+func parseVersionFilePath(relativePath string) (*moduleVersion, string, error) {
+	escapedPath, fileName, found := strings.Cut(relativePath, "/"+versionsDir+"/")
+	if !found || strings.Contains(fileName, "/") {
+		return nil, "", nil
+	}
+	extension := path.Ext(fileName)
+	if !slices.Contains([]string{infoExtension, modExtension, zipExtension}, extension) {
+		return nil, "", nil
+	}
+	modulePath, err := module.UnescapePath(escapedPath)
+	if err != nil {
+		return nil, "", errorutils.CheckErrorf("invalid module path in %s: %s", relativePath, err.Error())
+	}
+	version, err := module.UnescapeVersion(strings.TrimSuffix(fileName, extension))
+	if err != nil {
+		return nil, "", errorutils.CheckErrorf("invalid module version in %s: %s", relativePath, err.Error())
+	}
+	return &moduleVersion{path: modulePath, version: version}, extension, nil
+}
+
+// Adds the version to the list file of the module, which lists the versions the proxy serves.
+func addToList(listPath, version string) error {
+	content, err := os.ReadFile(listPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	versions := strings.Fields(string(content))
+	if slices.Contains(versions, version) {
+		return nil
+	}
+	versions = append(versions, version)
+	return errorutils.CheckError(os.WriteFile(listPath, []byte(strings.Join(versions, "\n")+"\n"), 0644))
+}
+
+// Returns the go.sum hash of a go.mod file.
+func hashMod(modPath string) (string, error) {
+	hash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return os.Open(modPath)
+	})
+	return hash, errorutils.CheckError(err)
+}
+
+// Returns the go.sum hash of a module zip.
+func hashZip(zipPath string) (string, error) {
+	hash, err := dirhash.HashZip(zipPath, dirhash.Hash1)
+	return hash, errorutils.CheckError(err)
+}
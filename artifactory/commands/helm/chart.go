@@ -0,0 +1,160 @@
+package helm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	chartFileName = "Chart.yaml"
+	lockFileName  = "Chart.lock"
+	// The lock file of charts with apiVersion v1.
+	legacyLockFileName = "requirements.lock"
+	chartsDirName      = "charts"
+	chartArchiveType   = "tgz"
+)
+
+type chartMetadata struct {
+	Name         string `yaml:"name"`
+	Version      string `yaml:"version"`
+	Dependencies []struct {
+		Name       string `yaml:"name"`
+		Repository string `yaml:"repository"`
+	} `yaml:"dependencies"`
+}
+
+type chartLock struct {
+	Dependencies []struct {
+		Name       string `yaml:"name"`
+		Version    string `yaml:"version"`
+		Repository string `yaml:"repository"`
+	} `yaml:"dependencies"`
+}
+
+// A Helm chart and its locked dependencies.
+type chart struct {
+	name         string
+	version      string
+	dependencies []buildinfo.Dependency
+}
+
+func (c *chart) id() string {
+	return c.name + ":" + c.version
+}
+
+// The files of a chart, from which the chart and its dependencies are read.
+type chartFiles struct {
+	metadata []byte
+	lock     []byte
+	// The checksums of the dependency archives in the charts directory, by file name.
+	archives map[string]buildinfo.Checksum
+}
+
+// Reads the chart in a chart directory.
+func readChartDir(dir string) (*chart, error) {
+	files := chartFiles{archives: make(map[string]buildinfo.Checksum)}
+	var err error
+	if files.metadata, err = os.ReadFile(filepath.Join(dir, chartFileName)); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, lockFile := range []string{lockFileName, legacyLockFileName} {
+		if files.lock, err = os.ReadFile(filepath.Join(dir, lockFile)); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	archives, err := filepath.Glob(filepath.Join(dir, chartsDirName, "*."+chartArchiveType))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, archive := range archives {
+		details, err := fileutils.GetFileDetails(archive, true)
+		if err != nil {
+			return nil, err
+		}
+		files.archives[filepath.Base(archive)] = details.Checksum
+	}
+	return files.toChart()
+}
+
+// Reads the chart in a packaged chart archive.
+// The archive includes a single top directory, which contains the chart files.
+func readChartArchive(archivePath string) (c *chart, err error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	files := chartFiles{archives: make(map[string]buildinfo.Checksum)}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		parts := strings.Split(path.Clean(header.Name), "/")
+		switch {
+		case len(parts) == 2 && parts[1] == chartFileName:
+			files.metadata, err = io.ReadAll(tarReader)
+		case len(parts) == 2 && (parts[1] == lockFileName || parts[1] == legacyLockFileName):
+			files.lock, err = io.ReadAll(tarReader)
+		case len(parts) == 3 && parts[1] == chartsDirName && strings.HasSuffix(parts[2], "."+chartArchiveType):
+			var details *fileutils.FileDetails
+			if details, err = fileutils.GetFileDetailsFromReader(tarReader, true); err == nil {
+				files.archives[parts[2]] = details.Checksum
+			}
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	if files.metadata == nil {
+		return nil, errorutils.CheckErrorf("could not find '%s' in the chart archive: %s", chartFileName, archivePath)
+	}
+	return files.toChart()
+}
+
+func (cf *chartFiles) toChart() (*chart, error) {
+	metadata := new(chartMetadata)
+	if err := yaml.Unmarshal(cf.metadata, metadata); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", chartFileName, err.Error())
+	}
+	c := &chart{name: metadata.Name, version: metadata.Version}
+	if cf.lock == nil {
+		return c, nil
+	}
+	lock := new(chartLock)
+	if err := yaml.Unmarshal(cf.lock, lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", lockFileName, err.Error())
+	}
+	for _, dependency := range lock.Dependencies {
+		c.dependencies = append(c.dependencies, buildinfo.Dependency{
+			Id:          dependency.Name + ":" + dependency.Version,
+			Type:        chartArchiveType,
+			Checksum:    cf.archives[dependency.Name+"-"+dependency.Version+"."+chartArchiveType],
+			RequestedBy: [][]string{{c.id()}},
+		})
+	}
+	return c, nil
+}
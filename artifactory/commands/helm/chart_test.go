@@ -0,0 +1,107 @@
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testChart = `apiVersion: v2
+name: app
+version: 1.2.0
+dependencies:
+  - name: redis
+    version: ~18.0.0
+    repository: "@jfrog"
+  - name: common
+    version: 2.x.x
+    repository: https://acme.jfrog.io/artifactory/api/helm/helm-virtual
+`
+	testChartLock = `dependencies:
+- name: redis
+  repository: https://acme.jfrog.io/artifactory/api/helm/helm-virtual
+  version: 18.0.4
+- name: common
+  repository: https://acme.jfrog.io/artifactory/api/helm/helm-virtual
+  version: 2.13.3
+digest: sha256:0a1b2c
+generated: "2024-05-01T10:00:00Z"
+`
+	// The checksums of "redis".
+	redisSha1   = "b840fc02d524045429941cc15f59e41cb7be6c52"
+	redisSha256 = "34fb46c847bb9df96e5205a39d382f648a6e8dce1e014cd85b4ca6a88d88ed03"
+	redisMd5    = "86a1b907d54bf7010394bf316e183e67"
+)
+
+func TestReadChartDir(t *testing.T) {
+	chartDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, chartFileName), []byte(testChart), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, lockFileName), []byte(testChartLock), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(chartDir, chartsDirName), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, chartsDirName, "redis-18.0.4.tgz"), []byte("redis"), 0644))
+
+	c, err := readChartDir(chartDir)
+	require.NoError(t, err)
+	assertChart(t, c)
+}
+
+func TestReadChartDirWithoutLock(t *testing.T) {
+	chartDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, chartFileName), []byte(testChart), 0644))
+
+	c, err := readChartDir(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, "app:1.2.0", c.id())
+	assert.Empty(t, c.dependencies)
+}
+
+func TestReadChartArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "app-1.2.0.tgz")
+	writeArchive(t, archivePath, map[string]string{
+		"app/" + chartFileName:                     testChart,
+		"app/" + lockFileName:                      testChartLock,
+		"app/charts/redis-18.0.4.tgz":              "redis",
+		"app/charts/common/" + chartFileName:       "name: common\nversion: 2.13.3\n",
+		"app/templates/deployment.yaml":            "kind: Deployment",
+		"app/charts/redis/charts/nested-1.0.0.tgz": "nested",
+	})
+
+	c, err := readChartArchive(archivePath)
+	require.NoError(t, err)
+	assertChart(t, c)
+}
+
+func assertChart(t *testing.T, c *chart) {
+	assert.Equal(t, "app:1.2.0", c.id())
+	assert.Equal(t, []buildinfo.Dependency{
+		{
+			Id:          "redis:18.0.4",
+			Type:        chartArchiveType,
+			Checksum:    buildinfo.Checksum{Sha1: redisSha1, Sha256: redisSha256, Md5: redisMd5},
+			RequestedBy: [][]string{{"app:1.2.0"}},
+		},
+		{Id: "common:2.13.3", Type: chartArchiveType, RequestedBy: [][]string{{"app:1.2.0"}}},
+	}, c.dependencies)
+}
+
+func writeArchive(t *testing.T, archivePath string, files map[string]string) {
+	buffer := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, os.WriteFile(archivePath, buffer.Bytes(), 0644))
+}
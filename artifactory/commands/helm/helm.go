@@ -0,0 +1,254 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ToolName       = "helm"
+	executableName = "helm"
+
+	ModuleType buildinfo.ModuleType = "helm"
+)
+
+// The flags of 'helm package' and 'helm dependency', which are followed by a value.
+var flagsWithValue = []string{"-d", "--destination", "--version", "--app-version", "--key", "--keyring", "--passphrase-file", "--kube-context", "--kubeconfig", "-n", "--namespace", "--registry-config", "--repository-cache", "--repository-config"}
+
+// Runs 'helm package' or 'helm dependency', resolving the chart dependencies from an Artifactory Helm repository.
+// The resolver repository is added to a temporary copy of the Helm repositories file, under the 'jfrog' name.
+// Only the dependencies which reference the resolver repository in Chart.yaml are resolved from Artifactory.
+// The locked dependencies of the chart are collected into the build-info.
+type HelmCommand struct {
+	cmdName            string
+	configFilePath     string
+	helmArgs           []string
+	resolverDetails    *config.ServerDetails
+	resolverRepo       string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewHelmCommand() *HelmCommand {
+	return &HelmCommand{}
+}
+
+func (hc *HelmCommand) SetCmdName(cmdName string) *HelmCommand {
+	hc.cmdName = cmdName
+	return hc
+}
+
+func (hc *HelmCommand) SetConfigFilePath(configFilePath string) *HelmCommand {
+	hc.configFilePath = configFilePath
+	return hc
+}
+
+func (hc *HelmCommand) SetArgs(args []string) *HelmCommand {
+	hc.helmArgs = args
+	return hc
+}
+
+func (hc *HelmCommand) CommandName() string {
+	return "rt_helm_" + hc.cmdName
+}
+
+func (hc *HelmCommand) ServerDetails() (*config.ServerDetails, error) {
+	return hc.resolverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the build-info options from the helm arguments.
+func (hc *HelmCommand) Init() (err error) {
+	if hc.helmArgs, hc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(hc.helmArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(hc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil || resolverConfig == nil {
+		return err
+	}
+	if hc.resolverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	hc.resolverRepo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (hc *HelmCommand) Run() (err error) {
+	var env []string
+	if hc.resolverDetails != nil {
+		var tempDir string
+		if tempDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+		}()
+		if env, err = hc.createResolverEnv(tempDir); err != nil {
+			return err
+		}
+		if hc.resolvesDependencies() {
+			for _, chartPath := range hc.getChartPaths() {
+				warnUnresolvedDependencies(chartPath, repositoryUrl(hc.resolverDetails, hc.resolverRepo))
+			}
+		}
+	}
+	if err = runHelm(env, append([]string{hc.cmdName}, hc.helmArgs...)...); err != nil {
+		return err
+	}
+	collectBuildInfo, err := hc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	for _, chartPath := range hc.getChartPaths() {
+		if err = hc.collectDependencies(chartPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the environment variables which configure Helm to use a temporary repositories file, which includes the resolver repository.
+func (hc *HelmCommand) createResolverEnv(tempDir string) ([]string, error) {
+	userFilePath, err := getUserRepositoriesFile()
+	if err != nil {
+		return nil, err
+	}
+	repositoriesFilePath, err := writeRepositoriesFile(userFilePath, tempDir, hc.resolverDetails, hc.resolverRepo)
+	if err != nil {
+		return nil, err
+	}
+	return []string{repositoryConfigEnv + "=" + repositoriesFilePath}, nil
+}
+
+// Returns the paths of the charts the command ran on, or nil if the command doesn't resolve dependencies.
+func (hc *HelmCommand) getChartPaths() []string {
+	positionalArgs := getPositionalArgs(hc.helmArgs)
+	switch hc.cmdName {
+	case "package":
+		return positionalArgs
+	case "dependency", "dep", "dependencies":
+		if len(positionalArgs) == 0 {
+			return nil
+		}
+		switch positionalArgs[0] {
+		case "update", "up", "build":
+			if len(positionalArgs) > 1 {
+				return positionalArgs[1:2]
+			}
+			return []string{"."}
+		}
+	}
+	return nil
+}
+
+// Returns true if the command resolves the chart dependencies: 'helm dependency update' or 'build', or 'helm package' with --dependency-update.
+func (hc *HelmCommand) resolvesDependencies() bool {
+	if hc.cmdName == "package" {
+		return slices.ContainsFunc(hc.helmArgs, func(arg string) bool {
+			return arg == "-u" || arg == "--dependency-update"
+		})
+	}
+	return hc.getChartPaths() != nil
+}
+
+// Helm resolves each dependency from the repository in the Chart.yaml file, and the file isn't modified by the command,
+// since the Chart.lock file which Helm generates must match it. Therefore, only the dependencies which reference the resolver repository,
+// by its URL or by the @jfrog alias, are resolved from Artifactory. A warning is logged for the dependencies of other remote repositories.
+func warnUnresolvedDependencies(chartPath, resolverUrl string) {
+	content, err := os.ReadFile(filepath.Join(chartPath, chartFileName))
+	if err != nil {
+		log.Debug("Couldn't read", chartFileName, "of", chartPath+":", err.Error())
+		return
+	}
+	metadata := new(chartMetadata)
+	if err = yaml.Unmarshal(content, metadata); err != nil {
+		log.Debug("Couldn't parse", chartFileName, "of", chartPath+":", err.Error())
+		return
+	}
+	for _, dependency := range metadata.Dependencies {
+		if !isResolvedFromArtifactory(dependency.Repository, resolverUrl) {
+			log.Warn(fmt.Sprintf("The dependency '%s' of the chart %s is resolved from %s and not from Artifactory. "+
+				"To resolve it from Artifactory, set its repository to '@%s' or to %s in %s.", dependency.Name, chartPath, dependency.Repository, resolverRepositoryName, resolverUrl, chartFileName))
+		}
+	}
+}
+
+// Returns false if the repository of a dependency is a remote repository other than the resolver repository.
+// Local dependencies have no repository, or a file:// repository.
+func isResolvedFromArtifactory(repository, resolverUrl string) bool {
+	switch {
+	case repository == "" || strings.HasPrefix(repository, "file://"):
+		return true
+	case repository == "@"+resolverRepositoryName || repository == "alias:"+resolverRepositoryName:
+		return true
+	default:
+		return strings.TrimSuffix(repository, "/") == resolverUrl
+	}
+}
+
+// Collects the locked dependencies of a chart directory.
+func (hc *HelmCommand) collectDependencies(chartPath string) error {
+	c, err := readChartDir(chartPath)
+	if err != nil {
+		return err
+	}
+	if len(c.dependencies) == 0 {
+		log.Debug("No locked dependencies were found in the chart", c.id())
+	}
+	return buildinfoutils.SaveDependencies(hc.buildConfiguration, c.id(), ModuleType, c.dependencies)
+}
+
+// Returns the arguments which aren't flags or flag values.
+func getPositionalArgs(args []string) (positionalArgs []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			positionalArgs = append(positionalArgs, arg)
+			continue
+		}
+		for _, flag := range flagsWithValue {
+			if arg == flag {
+				// Skip the flag value.
+				i++
+				break
+			}
+		}
+	}
+	return
+}
+
+func runHelm(env []string, args ...string) error {
+	cmd, err := newHelmCmd(env, args...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	return errorutils.CheckError(cmd.Run())
+}
+
+func newHelmCmd(env []string, args ...string) (*exec.Cmd, error) {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("could not find the helm executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", filepath.Base(executablePath), args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
@@ -0,0 +1,45 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChartPaths(t *testing.T) {
+	helmCmd := NewHelmCommand().SetCmdName("package").SetArgs([]string{"-d", "dist", "charts/app", "--version", "1.0.0", "charts/lib"})
+	assert.Equal(t, []string{"charts/app", "charts/lib"}, helmCmd.getChartPaths())
+	helmCmd.SetCmdName("dependency").SetArgs([]string{"update", "--skip-refresh"})
+	assert.Equal(t, []string{"."}, helmCmd.getChartPaths())
+	helmCmd.SetArgs([]string{"build", "charts/app"})
+	assert.Equal(t, []string{"charts/app"}, helmCmd.getChartPaths())
+	helmCmd.SetArgs([]string{"list", "charts/app"})
+	assert.Empty(t, helmCmd.getChartPaths())
+}
+
+func TestResolvesDependencies(t *testing.T) {
+	helmCmd := NewHelmCommand().SetCmdName("package").SetArgs([]string{"charts/app"})
+	assert.False(t, helmCmd.resolvesDependencies())
+	helmCmd.SetArgs([]string{"charts/app", "--dependency-update"})
+	assert.True(t, helmCmd.resolvesDependencies())
+	helmCmd.SetCmdName("dependency").SetArgs([]string{"build"})
+	assert.True(t, helmCmd.resolvesDependencies())
+	helmCmd.SetArgs([]string{"list"})
+	assert.False(t, helmCmd.resolvesDependencies())
+}
+
+func TestIsResolvedFromArtifactory(t *testing.T) {
+	resolverUrl := "https://acme.jfrog.io/artifactory/api/helm/helm-virtual"
+	for repository, expected := range map[string]bool{
+		"":                                   true,
+		"file://../lib":                      true,
+		"@jfrog":                             true,
+		"alias:jfrog":                        true,
+		resolverUrl + "/":                    true,
+		"@bitnami":                           false,
+		"https://charts.bitnami.com/bitnami": false,
+		"oci://registry-1.docker.io/bitnamicharts": false,
+	} {
+		assert.Equal(t, expected, isResolvedFromArtifactory(repository, resolverUrl), repository)
+	}
+}
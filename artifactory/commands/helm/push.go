@@ -0,0 +1,213 @@
+package helm
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The package types of Artifactory repositories, which store the charts as OCI artifacts.
+var ociPackageTypes = []string{"helmoci", "oci", "docker"}
+
+// Pushes a packaged chart to the deployer repository.
+// Charts are uploaded to Helm repositories, and pushed by the helm client to OCI repositories.
+// The chart is recorded as an artifact in the build-info, and its dependencies are read from the Chart.lock file in the archive.
+// If a remote is provided, the chart is pushed to it by the helm client, without collecting build-info.
+type HelmPushCommand struct {
+	configFilePath     string
+	helmArgs           []string
+	chartPath          string
+	remote             string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+	result             *commandsutils.Result
+}
+
+func NewHelmPushCommand() *HelmPushCommand {
+	return &HelmPushCommand{result: new(commandsutils.Result)}
+}
+
+func (hpc *HelmPushCommand) SetConfigFilePath(configFilePath string) *HelmPushCommand {
+	hpc.configFilePath = configFilePath
+	return hpc
+}
+
+func (hpc *HelmPushCommand) SetArgs(args []string) *HelmPushCommand {
+	hpc.helmArgs = args
+	return hpc
+}
+
+func (hpc *HelmPushCommand) Result() *commandsutils.Result {
+	return hpc.result
+}
+
+func (hpc *HelmPushCommand) CommandName() string {
+	return "rt_helm_push"
+}
+
+func (hpc *HelmPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return hpc.serverDetails, nil
+}
+
+// Reads the deployer configuration and the chart path from the helm arguments.
+func (hpc *HelmPushCommand) Init() (err error) {
+	if hpc.helmArgs, hpc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(hpc.helmArgs); err != nil {
+		return err
+	}
+	positionalArgs := getPositionalArgs(hpc.helmArgs)
+	if len(positionalArgs) == 0 {
+		return errorutils.CheckErrorf("the path of the packaged chart is missing. Usage: jf helm push <chart.tgz> [remote]")
+	}
+	hpc.chartPath = positionalArgs[0]
+	if len(positionalArgs) > 1 {
+		hpc.remote = positionalArgs[1]
+		return nil
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(hpc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig == nil {
+		return errorutils.CheckErrorf("the deployer repository is missing from the config file (%s). Please run 'jf helm-config' with the --repo-deploy option, or provide the remote to push to", hpc.configFilePath)
+	}
+	if hpc.serverDetails, err = deployerConfig.ServerDetails(); err != nil {
+		return err
+	}
+	hpc.repo = deployerConfig.TargetRepo()
+	return nil
+}
+
+func (hpc *HelmPushCommand) Run() (err error) {
+	if hpc.remote != "" {
+		return runHelm(nil, append([]string{"push"}, hpc.helmArgs...)...)
+	}
+	c, err := readChartArchive(hpc.chartPath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(hpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	repoDetails := new(services.RepositoryDetails)
+	if err = servicesManager.GetRepository(hpc.repo, repoDetails); err != nil {
+		return err
+	}
+	var artifacts []buildinfo.Artifact
+	if isOciPackageType(repoDetails.PackageType) {
+		artifacts, err = hpc.pushToOciRepo(c)
+	} else {
+		artifacts, err = hpc.uploadToHelmRepo(servicesManager)
+	}
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := hpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	if err = buildinfoutils.SaveDependencies(hpc.buildConfiguration, c.id(), ModuleType, c.dependencies); err != nil {
+		return err
+	}
+	return buildinfoutils.SaveArtifacts(hpc.buildConfiguration, c.id(), ModuleType, artifacts)
+}
+
+func isOciPackageType(packageType string) bool {
+	for _, ociPackageType := range ociPackageTypes {
+		if strings.EqualFold(packageType, ociPackageType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Uploads the chart archive to the root of the Helm repository, which indexes it.
+func (hpc *HelmPushCommand) uploadToHelmRepo(servicesManager artifactory.ArtifactoryServicesManager) (artifacts []buildinfo.Artifact, err error) {
+	up := services.NewUploadParams()
+	up.CommonParams = &specutils.CommonParams{Pattern: hpc.chartPath, Target: hpc.repo + "/"}
+	up.Flat = true
+	collectBuildInfo, err := hpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return nil, err
+	}
+	if collectBuildInfo {
+		if up.BuildProps, err = build.CreateBuildPropsFromConfiguration(hpc.buildConfiguration); err != nil {
+			return nil, err
+		}
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, up)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close(), summary.TransferDetailsReader.Close())
+	}()
+	hpc.result.SetSuccessCount(summary.TotalSucceeded)
+	hpc.result.SetFailCount(summary.TotalFailed)
+	if summary.TotalFailed > 0 {
+		return nil, errorutils.CheckErrorf("failed to upload the chart to Artifactory. See Artifactory logs for more details")
+	}
+	if !collectBuildInfo {
+		return nil, nil
+	}
+	return specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+}
+
+// Pushes the chart to the OCI repository with the helm client.
+// The client logs in to the registry with a temporary registry config file, to keep the credentials out of the user's config.
+func (hpc *HelmPushCommand) pushToOciRepo(c *chart) (artifacts []buildinfo.Artifact, err error) {
+	platformUrl, err := url.Parse(hpc.serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	registry := platformUrl.Host
+	username, password, err := projectconfig.GetCredentials(hpc.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	env := []string{registryConfigEnv + "=" + tempDir + "/registry.json"}
+	loginCmd, err := newHelmCmd(env, "registry", "login", registry, "--username", username, "--password-stdin")
+	if err != nil {
+		return nil, err
+	}
+	loginCmd.Stdin = strings.NewReader(password)
+	if err = loginCmd.Run(); err != nil {
+		return nil, errorutils.CheckErrorf("failed to log in to the %s registry: %s", registry, err.Error())
+	}
+	args := append([]string{"push"}, hpc.helmArgs...)
+	if err = runHelm(env, append(args, "oci://"+registry+"/"+hpc.repo)...); err != nil {
+		hpc.result.SetFailCount(1)
+		return nil, err
+	}
+	hpc.result.SetSuccessCount(1)
+	collectBuildInfo, err := hpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return nil, err
+	}
+	log.Debug("Searching for the layers of the pushed chart", c.id())
+	// The chart is stored in the repository under <name>/<version>, as the manifest and layers of an OCI image.
+	return buildinfoutils.GetDeployedArtifacts(hpc.serverDetails, hpc.buildConfiguration, hpc.repo, c.name+"/"+c.version+"/*")
+}
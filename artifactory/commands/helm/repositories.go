@@ -0,0 +1,94 @@
+package helm
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// The name of the resolver repository in the Helm repositories file.
+	// Dependencies may reference the repository either by its URL or by the @jfrog alias.
+	resolverRepositoryName = "jfrog"
+
+	repositoryConfigEnv = "HELM_REPOSITORY_CONFIG"
+	registryConfigEnv   = "HELM_REGISTRY_CONFIG"
+)
+
+// The Helm repositories file. The repository entries are kept as is, to preserve fields which aren't used by the command.
+type repositoriesFile struct {
+	APIVersion   string          `yaml:"apiVersion"`
+	Generated    string          `yaml:"generated,omitempty"`
+	Repositories []yaml.MapSlice `yaml:"repositories"`
+}
+
+// Returns the Helm repositories URL of an Artifactory Helm repository.
+func repositoryUrl(serverDetails *config.ServerDetails, repo string) string {
+	return strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/helm/" + repo
+}
+
+// Returns the path of the Helm repositories file of the user.
+func getUserRepositoriesFile() (string, error) {
+	if path := os.Getenv(repositoryConfigEnv); path != "" {
+		return path, nil
+	}
+	output, err := exec.Command(executableName, "env", repositoryConfigEnv).Output()
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to get the Helm repositories file: %s", err.Error())
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Writes a copy of the user's repositories file into the target directory, with the resolver repository added to it.
+// An existing repository with the same name is replaced. Returns the path of the written file.
+func writeRepositoriesFile(userFilePath, targetDir string, serverDetails *config.ServerDetails, repo string) (string, error) {
+	file := &repositoriesFile{APIVersion: "v1"}
+	content, err := os.ReadFile(userFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", errorutils.CheckError(err)
+	}
+	if err == nil {
+		if err = yaml.Unmarshal(content, file); err != nil {
+			return "", errorutils.CheckErrorf("failed to parse the Helm repositories file %s: %s", userFilePath, err.Error())
+		}
+	}
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil {
+		return "", err
+	}
+	entry := yaml.MapSlice{
+		{Key: "name", Value: resolverRepositoryName},
+		{Key: "url", Value: repositoryUrl(serverDetails, repo)},
+	}
+	if password != "" {
+		entry = append(entry, yaml.MapItem{Key: "username", Value: username}, yaml.MapItem{Key: "password", Value: password})
+	}
+	repositories := []yaml.MapSlice{entry}
+	for _, repository := range file.Repositories {
+		if getValue(repository, "name") != resolverRepositoryName {
+			repositories = append(repositories, repository)
+		}
+	}
+	file.Repositories = repositories
+	if content, err = yaml.Marshal(file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	targetPath := filepath.Join(targetDir, "repositories.yaml")
+	// The file includes credentials, and is therefore readable by the user only.
+	return targetPath, errorutils.CheckError(os.WriteFile(targetPath, content, 0600))
+}
+
+func getValue(slice yaml.MapSlice, key string) interface{} {
+	for _, item := range slice {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
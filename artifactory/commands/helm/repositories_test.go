@@ -0,0 +1,57 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRepositoriesFile(t *testing.T) {
+	tempDir := t.TempDir()
+	userFilePath := filepath.Join(tempDir, "user.yaml")
+	require.NoError(t, os.WriteFile(userFilePath, []byte(`apiVersion: ""
+generated: "0001-01-01T00:00:00Z"
+repositories:
+- name: bitnami
+  url: https://charts.bitnami.com/bitnami
+  pass_credentials_all: false
+- name: jfrog
+  url: https://old.jfrog.io/artifactory/api/helm/old
+`), 0644))
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "pass"}
+
+	filePath, err := writeRepositoriesFile(userFilePath, tempDir, serverDetails, "helm-virtual")
+	require.NoError(t, err)
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: ""
+generated: "0001-01-01T00:00:00Z"
+repositories:
+- name: jfrog
+  url: https://acme.jfrog.io/artifactory/api/helm/helm-virtual
+  username: user
+  password: pass
+- name: bitnami
+  url: https://charts.bitnami.com/bitnami
+  pass_credentials_all: false
+`, string(content))
+}
+
+func TestWriteRepositoriesFileWithoutUserFile(t *testing.T) {
+	tempDir := t.TempDir()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}
+
+	filePath, err := writeRepositoriesFile(filepath.Join(tempDir, "missing.yaml"), tempDir, serverDetails, "helm-virtual")
+	require.NoError(t, err)
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+repositories:
+- name: jfrog
+  url: https://acme.jfrog.io/artifactory/api/helm/helm-virtual
+`, string(content))
+}
@@ -0,0 +1,274 @@
+package licenses
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	TableFormat = "table"
+	CsvFormat   = "csv"
+	JsonFormat  = "json"
+)
+
+// A dependency of the build, with its licenses and the manifest they were read from.
+type DependencyLicense struct {
+	Id           string       `json:"id"`
+	Type         string       `json:"type,omitempty"`
+	Licenses     []string     `json:"licenses,omitempty"`
+	PolicyStatus PolicyStatus `json:"policyStatus,omitempty"`
+	Source       string       `json:"source,omitempty"`
+	Modules      []string     `json:"modules"`
+	Sha1         string       `json:"sha1,omitempty"`
+	Sha256       string       `json:"sha256,omitempty"`
+}
+
+type dependencyLicenseRow struct {
+	Id           string `col-name:"Dependency" csv:"dependency"`
+	Type         string `col-name:"Type" csv:"type"`
+	Licenses     string `col-name:"Licenses" csv:"licenses"`
+	PolicyStatus string `col-name:"Policy" omitempty:"true" csv:"policy"`
+	Modules      string `col-name:"Modules" csv:"modules"`
+	Source       string `col-name:"Source" csv:"source"`
+	Sha256       string `csv:"sha256"`
+}
+
+// Creates a license inventory of the build dependencies, and optionally evaluates it against a license policy.
+// The licenses are read from the package manifests in the local caches, without resolving the dependencies again.
+type BuildLicensesCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	fromRt             bool
+	policyPath         string
+	format             string
+	failOnViolation    bool
+	reader             *licenseReader
+}
+
+func NewBuildLicensesCommand() *BuildLicensesCommand {
+	return &BuildLicensesCommand{format: TableFormat, failOnViolation: true}
+}
+
+func (blc *BuildLicensesCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildLicensesCommand {
+	blc.serverDetails = serverDetails
+	return blc
+}
+
+func (blc *BuildLicensesCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildLicensesCommand {
+	blc.buildConfiguration = buildConfiguration
+	return blc
+}
+
+// If true, the build-info published to Artifactory is read, rather than the local build-info collected by the build commands.
+func (blc *BuildLicensesCommand) SetFromRt(fromRt bool) *BuildLicensesCommand {
+	blc.fromRt = fromRt
+	return blc
+}
+
+func (blc *BuildLicensesCommand) SetPolicyPath(policyPath string) *BuildLicensesCommand {
+	blc.policyPath = policyPath
+	return blc
+}
+
+func (blc *BuildLicensesCommand) SetFormat(format string) *BuildLicensesCommand {
+	blc.format = format
+	return blc
+}
+
+func (blc *BuildLicensesCommand) SetFailOnViolation(failOnViolation bool) *BuildLicensesCommand {
+	blc.failOnViolation = failOnViolation
+	return blc
+}
+
+func (blc *BuildLicensesCommand) CommandName() string {
+	return "rt_build_licenses"
+}
+
+func (blc *BuildLicensesCommand) ServerDetails() (*config.ServerDetails, error) {
+	return blc.serverDetails, nil
+}
+
+func (blc *BuildLicensesCommand) Run() error {
+	if blc.format != TableFormat && blc.format != CsvFormat && blc.format != JsonFormat {
+		return errorutils.CheckErrorf("unsupported format '%s'. Acceptable values are: %s, %s, %s", blc.format, TableFormat, CsvFormat, JsonFormat)
+	}
+	var policy *LicensePolicy
+	if blc.policyPath != "" {
+		var err error
+		if policy, err = LoadLicensePolicy(blc.policyPath); err != nil {
+			return err
+		}
+	}
+	modules, err := blc.getModules()
+	if err != nil {
+		return err
+	}
+	if blc.reader == nil {
+		blc.reader = newLicenseReader()
+	}
+	inventory, err := createInventory(modules, blc.reader, policy)
+	if err != nil {
+		return err
+	}
+	if err = printInventory(inventory, blc.format); err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+	var violations []string
+	for _, dependency := range inventory {
+		if dependency.PolicyStatus.isViolation() {
+			violations = append(violations, dependency.Id)
+		}
+	}
+	if len(violations) > 0 {
+		message := "the licenses of the following dependencies violate the license policy: " + strings.Join(violations, ", ")
+		if blc.failOnViolation {
+			return errorutils.CheckError(errors.New(message))
+		}
+		log.Warn(message)
+	}
+	return nil
+}
+
+// Returns the modules of the local build-info, or of the build-info published to Artifactory.
+func (blc *BuildLicensesCommand) getModules() ([]buildinfo.Module, error) {
+	buildName, err := blc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := blc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	project := blc.buildConfiguration.GetProject()
+	if blc.fromRt {
+		sm, err := utils.CreateServiceManager(blc.serverDetails, -1, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		publishedBuildInfo, found, err := sm.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: project})
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errorutils.CheckErrorf("build %s/%s wasn't found in Artifactory", buildName, buildNumber)
+		}
+		return publishedBuildInfo.BuildInfo.Modules, nil
+	}
+	partials, err := build.ReadPartialBuildInfoFiles(buildName, buildNumber, project)
+	if err != nil {
+		return nil, err
+	}
+	if len(partials) == 0 {
+		return nil, errorutils.CheckErrorf("no local build-info was found for build %s/%s. To read the build-info published to Artifactory, use the --from-rt option", buildName, buildNumber)
+	}
+	return partialsToModules(partials), nil
+}
+
+// Groups the dependencies of the partial build-info files by module.
+func partialsToModules(partials buildinfo.Partials) []buildinfo.Module {
+	var modules []buildinfo.Module
+	indexes := make(map[string]int)
+	for _, partial := range partials {
+		index, exists := indexes[partial.ModuleId]
+		if !exists {
+			index = len(modules)
+			indexes[partial.ModuleId] = index
+			modules = append(modules, buildinfo.Module{Id: partial.ModuleId, Type: partial.ModuleType})
+		}
+		modules[index].Dependencies = append(modules[index].Dependencies, partial.Dependencies...)
+	}
+	return modules
+}
+
+// Creates the license inventory of the dependencies of all modules. A dependency used by several modules is listed once.
+func createInventory(modules []buildinfo.Module, reader *licenseReader, policy *LicensePolicy) ([]*DependencyLicense, error) {
+	var inventory []*DependencyLicense
+	dependencies := make(map[string]*DependencyLicense)
+	for _, module := range modules {
+		for _, dependency := range module.Dependencies {
+			key := string(module.Type) + "/" + dependency.Id
+			if existing, found := dependencies[key]; found {
+				if existing.Modules[len(existing.Modules)-1] != module.Id {
+					existing.Modules = append(existing.Modules, module.Id)
+				}
+				continue
+			}
+			dependencyLicense := &DependencyLicense{
+				Id:      dependency.Id,
+				Type:    string(module.Type),
+				Modules: []string{module.Id},
+				Sha1:    dependency.Sha1,
+				Sha256:  dependency.Sha256,
+			}
+			license, err := reader.read(module.Type, dependency.Id)
+			if err != nil {
+				return nil, err
+			}
+			if license != nil {
+				dependencyLicense.Licenses = license.licenses
+				dependencyLicense.Source = license.source
+			} else {
+				log.Debug("No package manifest was found in the local caches for", dependency.Id)
+			}
+			if policy != nil {
+				dependencyLicense.PolicyStatus = policy.evaluate(dependencyLicense.Licenses)
+			}
+			dependencies[key] = dependencyLicense
+			inventory = append(inventory, dependencyLicense)
+		}
+	}
+	sort.SliceStable(inventory, func(i, j int) bool {
+		return inventory[i].Id < inventory[j].Id
+	})
+	return inventory, nil
+}
+
+func printInventory(inventory []*DependencyLicense, format string) error {
+	if format == JsonFormat {
+		if inventory == nil {
+			inventory = []*DependencyLicense{}
+		}
+		content, err := json.MarshalIndent(inventory, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	var rows []dependencyLicenseRow
+	for _, dependency := range inventory {
+		rows = append(rows, dependencyLicenseRow{
+			Id:           dependency.Id,
+			Type:         dependency.Type,
+			Licenses:     strings.Join(dependency.Licenses, ", "),
+			PolicyStatus: string(dependency.PolicyStatus),
+			Modules:      strings.Join(dependency.Modules, ", "),
+			Source:       dependency.Source,
+			Sha256:       dependency.Sha256,
+		})
+	}
+	if format == CsvFormat {
+		content, err := gocsv.MarshalString(rows)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(strings.TrimSuffix(content, "\n"))
+		return nil
+	}
+	return coreutils.PrintTable(rows, "Dependency Licenses", "No dependencies were found", false)
+}
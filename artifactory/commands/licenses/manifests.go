@@ -0,0 +1,564 @@
+package licenses
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Maximum number of parent POMs followed when looking for the licenses of a Maven dependency.
+const maxPomParents = 10
+
+// The license of a dependency, as read from its package manifest.
+type manifestLicense struct {
+	licenses []string
+	// Path to the manifest or LICENSE file the licenses were read from.
+	source string
+}
+
+// Reads the licenses of build dependencies from the package manifests present in the local caches.
+// No dependency is downloaded or resolved again.
+type licenseReader struct {
+	// Local Maven repository.
+	mavenRepository string
+	// Gradle modules cache.
+	gradleCache string
+	// Project directory, containing the node_modules directory.
+	projectDir string
+	// Python site-packages directories. Detected on first use if empty.
+	sitePackages []string
+	// NuGet global packages directory.
+	nugetPackages string
+	// Go modules cache.
+	goModCache string
+
+	npmPackages          map[string]string
+	sitePackagesDetected bool
+}
+
+func newLicenseReader() *licenseReader {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		log.Debug("Couldn't detect the home directory:", err.Error())
+	}
+	reader := &licenseReader{
+		mavenRepository: filepath.Join(homeDir, ".m2", "repository"),
+		gradleCache:     filepath.Join(homeDir, ".gradle", "caches", "modules-2", "files-2.1"),
+		nugetPackages:   os.Getenv("NUGET_PACKAGES"),
+		goModCache:      os.Getenv("GOMODCACHE"),
+	}
+	if gradleHome := os.Getenv("GRADLE_USER_HOME"); gradleHome != "" {
+		reader.gradleCache = filepath.Join(gradleHome, "caches", "modules-2", "files-2.1")
+	}
+	if reader.nugetPackages == "" {
+		reader.nugetPackages = filepath.Join(homeDir, ".nuget", "packages")
+	}
+	if reader.goModCache == "" {
+		reader.goModCache = detectGoModCache(homeDir)
+	}
+	if reader.projectDir, err = os.Getwd(); err != nil {
+		log.Debug("Couldn't detect the working directory:", err.Error())
+	}
+	return reader
+}
+
+// Returns the licenses of the dependency, or nil if its manifest wasn't found in the local caches.
+func (lr *licenseReader) read(moduleType buildinfo.ModuleType, dependencyId string) (*manifestLicense, error) {
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		return lr.readMaven(dependencyId)
+	case buildinfo.Npm:
+		return lr.readNpm(dependencyId)
+	case buildinfo.Python:
+		return lr.readPython(dependencyId)
+	case buildinfo.Nuget, "dotnet":
+		return lr.readNuget(dependencyId)
+	case buildinfo.Go:
+		return lr.readGo(dependencyId)
+	default:
+		return nil, nil
+	}
+}
+
+// Splits a dependency ID in the form of <name>:<version>.
+func splitDependencyId(dependencyId string) (name, version string, ok bool) {
+	separator := strings.LastIndex(dependencyId, ":")
+	if separator <= 0 || separator == len(dependencyId)-1 {
+		return "", "", false
+	}
+	return dependencyId[:separator], dependencyId[separator+1:], true
+}
+
+type pomProject struct {
+	Parent struct {
+		GroupId    string `xml:"groupId"`
+		ArtifactId string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+	Licenses []struct {
+		Name string `xml:"name"`
+		Url  string `xml:"url"`
+	} `xml:"licenses>license"`
+}
+
+// Maven dependency IDs are in the form of <group>:<artifact>:<version>.
+// Licenses are commonly declared in a parent POM, so the parents are followed until licenses are found.
+func (lr *licenseReader) readMaven(dependencyId string) (*manifestLicense, error) {
+	parts := strings.Split(dependencyId, ":")
+	if len(parts) < 3 {
+		return nil, nil
+	}
+	groupId, artifactId, version := parts[0], parts[1], parts[2]
+	var result *manifestLicense
+	for i := 0; i < maxPomParents && groupId != ""; i++ {
+		pomPath, err := lr.findPom(groupId, artifactId, version)
+		if err != nil || pomPath == "" {
+			return result, err
+		}
+		if result == nil {
+			result = &manifestLicense{source: pomPath}
+		}
+		pom := new(pomProject)
+		if err = readXml(pomPath, pom); err != nil {
+			// A malformed POM doesn't fail the report. The licenses found so far, if any, are kept.
+			log.Warn("Skipping the POM", pomPath+":", err.Error())
+			return result, nil
+		}
+		if len(pom.Licenses) > 0 {
+			result.source = pomPath
+			for _, license := range pom.Licenses {
+				name := license.Name
+				if name == "" {
+					name = license.Url
+				}
+				result.licenses = append(result.licenses, normalizeLicenseName(name))
+			}
+			return result, nil
+		}
+		groupId, artifactId, version = pom.Parent.GroupId, pom.Parent.ArtifactId, pom.Parent.Version
+	}
+	return result, nil
+}
+
+// Looks for the POM in the local Maven repository, and then in the Gradle cache.
+func (lr *licenseReader) findPom(groupId, artifactId, version string) (string, error) {
+	pomName := artifactId + "-" + version + ".pom"
+	pomPath := filepath.Join(lr.mavenRepository, filepath.FromSlash(strings.ReplaceAll(groupId, ".", "/")), artifactId, version, pomName)
+	exists, err := fileutils.IsFileExists(pomPath, false)
+	if err != nil || exists {
+		return pomPath, err
+	}
+	// Gradle stores each file under a directory named after its SHA-1 checksum.
+	matches, err := filepath.Glob(filepath.Join(lr.gradleCache, groupId, artifactId, version, "*", pomName))
+	if err != nil || len(matches) == 0 {
+		return "", errorutils.CheckError(err)
+	}
+	return matches[0], nil
+}
+
+type packageJson struct {
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	License  json.RawMessage `json:"license"`
+	Licenses []struct {
+		Type string `json:"type"`
+	} `json:"licenses"`
+}
+
+// npm dependency IDs are in the form of <name>:<version>. The manifests are read from the project's node_modules directory.
+func (lr *licenseReader) readNpm(dependencyId string) (*manifestLicense, error) {
+	if lr.npmPackages == nil {
+		lr.npmPackages = make(map[string]string)
+		if err := indexNodeModules(filepath.Join(lr.projectDir, "node_modules"), lr.npmPackages); err != nil {
+			return nil, err
+		}
+	}
+	manifestPath, found := lr.npmPackages[dependencyId]
+	if !found {
+		return nil, nil
+	}
+	manifest, err := readPackageJson(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	result := &manifestLicense{source: manifestPath}
+	var license string
+	if err = json.Unmarshal(manifest.License, &license); err != nil {
+		// Legacy packages declare the license as an object.
+		var licenseObject struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(manifest.License, &licenseObject) == nil {
+			license = licenseObject.Type
+		}
+	}
+	if license != "" {
+		result.licenses = []string{strings.TrimSpace(license)}
+	}
+	for _, legacyLicense := range manifest.Licenses {
+		result.licenses = append(result.licenses, normalizeLicenseName(legacyLicense.Type))
+	}
+	return result, nil
+}
+
+// Maps the <name>:<version> of each installed npm package to its package.json, including nested node_modules directories.
+func indexNodeModules(nodeModulesDir string, index map[string]string) error {
+	entries, err := os.ReadDir(nodeModulesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		packageDirs := []string{filepath.Join(nodeModulesDir, entry.Name())}
+		if strings.HasPrefix(entry.Name(), "@") {
+			scopedEntries, err := os.ReadDir(packageDirs[0])
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			packageDirs = nil
+			for _, scopedEntry := range scopedEntries {
+				packageDirs = append(packageDirs, filepath.Join(nodeModulesDir, entry.Name(), scopedEntry.Name()))
+			}
+		}
+		for _, packageDir := range packageDirs {
+			manifestPath := filepath.Join(packageDir, "package.json")
+			manifest, err := readPackageJson(manifestPath)
+			if err != nil {
+				log.Debug("Skipping", packageDir+":", err.Error())
+				continue
+			}
+			if _, exists := index[manifest.Name+":"+manifest.Version]; !exists {
+				index[manifest.Name+":"+manifest.Version] = manifestPath
+			}
+			if err = indexNodeModules(filepath.Join(packageDir, "node_modules"), index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readPackageJson(manifestPath string) (*packageJson, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	manifest := new(packageJson)
+	return manifest, errorutils.CheckError(json.Unmarshal(content, manifest))
+}
+
+var (
+	pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+	// Wheel files are named <name>-<version>-<tags>.whl and source distributions <name>-<version>.<extension>.
+	pythonWheelPattern = regexp.MustCompile(`^([^-]+)-([^-]+)-.+\.whl$`)
+	pythonSdistPattern = regexp.MustCompile(`^(.+)-([^-]+?)\.(tar\.gz|tar\.bz2|zip|tgz|egg)$`)
+)
+
+// Python dependency IDs are either the name of the downloaded package file or <name>:<version>.
+func parsePythonDependencyId(dependencyId string) (name, version string, ok bool) {
+	if groups := pythonWheelPattern.FindStringSubmatch(dependencyId); groups != nil {
+		return groups[1], groups[2], true
+	}
+	if groups := pythonSdistPattern.FindStringSubmatch(dependencyId); groups != nil {
+		return groups[1], groups[2], true
+	}
+	return splitDependencyId(dependencyId)
+}
+
+// Package names are compared after being normalized, as pip replaces dashes and dots in the dist-info directory names.
+func normalizePythonName(name string) string {
+	return strings.ToLower(pythonNameSeparators.ReplaceAllString(name, "_"))
+}
+
+func (lr *licenseReader) readPython(dependencyId string) (*manifestLicense, error) {
+	name, version, ok := parsePythonDependencyId(dependencyId)
+	if !ok {
+		return nil, nil
+	}
+	if !lr.sitePackagesDetected && len(lr.sitePackages) == 0 {
+		lr.sitePackages = detectSitePackages()
+		lr.sitePackagesDetected = true
+	}
+	expectedName := normalizePythonName(name) + "-" + strings.ToLower(version) + ".dist-info"
+	for _, sitePackagesDir := range lr.sitePackages {
+		entries, err := os.ReadDir(sitePackagesDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			distInfoName, isDistInfo := strings.CutSuffix(entry.Name(), ".dist-info")
+			if !isDistInfo || !entry.IsDir() {
+				continue
+			}
+			separator := strings.LastIndex(distInfoName, "-")
+			if separator <= 0 || normalizePythonName(distInfoName[:separator])+"-"+strings.ToLower(distInfoName[separator+1:])+".dist-info" != expectedName {
+				continue
+			}
+			metadataPath := filepath.Join(sitePackagesDir, entry.Name(), "METADATA")
+			licenses, err := readPythonMetadata(metadataPath)
+			if err != nil {
+				return nil, err
+			}
+			return &manifestLicense{licenses: licenses, source: metadataPath}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Reads the licenses from the headers of a Python package METADATA file.
+// The License-Expression header is preferred over the license classifiers, which are preferred over the free text License header.
+func readPythonMetadata(metadataPath string) ([]string, error) {
+	file, err := os.Open(metadataPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	var expression, license string
+	var classifiers []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// The headers end at the first empty line, followed by the package description.
+		if line == "" {
+			break
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "License-Expression":
+			expression = value
+		case "License":
+			license = value
+		case "Classifier":
+			if classifier, isLicense := strings.CutPrefix(value, "License :: "); isLicense {
+				segments := strings.Split(classifier, " :: ")
+				classifiers = append(classifiers, normalizeLicenseName(segments[len(segments)-1]))
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	switch {
+	case expression != "":
+		return []string{expression}, nil
+	case len(classifiers) > 0:
+		return classifiers, nil
+	case license != "" && license != "UNKNOWN":
+		if detected := detectLicenseFromText(license); detected != "" {
+			return []string{detected}, nil
+		}
+		return []string{normalizeLicenseName(license)}, nil
+	}
+	return nil, nil
+}
+
+// Returns the site-packages directories of the active virtual environment and the Python interpreter in the PATH.
+func detectSitePackages() []string {
+	var sitePackages []string
+	if virtualEnv := os.Getenv("VIRTUAL_ENV"); virtualEnv != "" {
+		matches, _ := filepath.Glob(filepath.Join(virtualEnv, "lib", "python*", "site-packages"))
+		sitePackages = append(sitePackages, matches...)
+		sitePackages = append(sitePackages, filepath.Join(virtualEnv, "Lib", "site-packages"))
+	}
+	for _, python := range []string{"python3", "python"} {
+		output, err := exec.Command(python, "-c", "import sys; print('\\n'.join(sys.path))").Output()
+		if err != nil {
+			continue
+		}
+		for _, path := range strings.Split(string(output), "\n") {
+			path = strings.TrimSpace(path)
+			if strings.HasSuffix(path, "site-packages") || strings.HasSuffix(path, "dist-packages") {
+				sitePackages = append(sitePackages, path)
+			}
+		}
+		break
+	}
+	return sitePackages
+}
+
+type nuspecPackage struct {
+	Metadata struct {
+		License struct {
+			Type  string `xml:"type,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"license"`
+		LicenseUrl string `xml:"licenseUrl"`
+	} `xml:"metadata"`
+}
+
+var nugetLicenseUrlPattern = regexp.MustCompile(`^https?://licenses\.nuget\.org/(.+)$`)
+
+// NuGet dependency IDs are in the form of <name>:<version>. The .nuspec files are read from the global packages directory.
+func (lr *licenseReader) readNuget(dependencyId string) (*manifestLicense, error) {
+	name, version, ok := splitDependencyId(dependencyId)
+	if !ok {
+		return nil, nil
+	}
+	packageDir := filepath.Join(lr.nugetPackages, strings.ToLower(name), strings.ToLower(version))
+	nuspecPath := filepath.Join(packageDir, strings.ToLower(name)+".nuspec")
+	exists, err := fileutils.IsFileExists(nuspecPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	nuspec := new(nuspecPackage)
+	if err = readXml(nuspecPath, nuspec); err != nil {
+		log.Warn("Skipping the nuspec", nuspecPath+":", err.Error())
+		return nil, nil
+	}
+	result := &manifestLicense{source: nuspecPath}
+	license := nuspec.Metadata.License
+	switch {
+	case license.Type == "expression":
+		result.licenses = []string{strings.TrimSpace(license.Value)}
+	case license.Type == "file":
+		licensePath := filepath.Join(packageDir, filepath.FromSlash(strings.TrimSpace(license.Value)))
+		content, err := os.ReadFile(licensePath)
+		if err != nil {
+			log.Warn("Couldn't read the license file of", dependencyId+":", err.Error())
+			break
+		}
+		if detected := detectLicenseFromText(string(content)); detected != "" {
+			result.licenses = []string{detected}
+			result.source = licensePath
+		}
+	case nuspec.Metadata.LicenseUrl != "":
+		// Packages created before the license element was introduced only reference the license URL.
+		if groups := nugetLicenseUrlPattern.FindStringSubmatch(nuspec.Metadata.LicenseUrl); groups != nil {
+			expression, err := url.PathUnescape(groups[1])
+			if err != nil {
+				expression = groups[1]
+			}
+			result.licenses = []string{expression}
+		} else {
+			result.licenses = []string{nuspec.Metadata.LicenseUrl}
+		}
+	}
+	return result, nil
+}
+
+// Go dependency IDs are in the form of <escaped module path>:<version>.
+// The license is detected from the LICENSE file of the extracted module, or of the module zip in the download cache.
+func (lr *licenseReader) readGo(dependencyId string) (*manifestLicense, error) {
+	modulePath, version, ok := splitDependencyId(dependencyId)
+	if !ok || lr.goModCache == "" {
+		return nil, nil
+	}
+	modulePath = escapeGoModulePath(modulePath)
+	moduleDir := filepath.Join(lr.goModCache, filepath.FromSlash(modulePath)+"@"+version)
+	if entries, err := os.ReadDir(moduleDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || !isLicenseFileName(entry.Name()) {
+				continue
+			}
+			licensePath := filepath.Join(moduleDir, entry.Name())
+			content, err := os.ReadFile(licensePath)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			return &manifestLicense{licenses: detectedLicenses(string(content)), source: licensePath}, nil
+		}
+		return nil, nil
+	}
+	zipPath := filepath.Join(lr.goModCache, "cache", "download", filepath.FromSlash(modulePath), "@v", version+".zip")
+	exists, err := fileutils.IsFileExists(zipPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return readGoModuleZip(zipPath, modulePath+"@"+version+"/")
+}
+
+func readGoModuleZip(zipPath, rootPrefix string) (*manifestLicense, error) {
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	for _, file := range archive.File {
+		fileName, inRoot := strings.CutPrefix(file.Name, rootPrefix)
+		if !inRoot || strings.Contains(fileName, "/") || !isLicenseFileName(fileName) {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		content, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return &manifestLicense{licenses: detectedLicenses(string(content)), source: zipPath + "!/" + file.Name}, nil
+	}
+	return nil, nil
+}
+
+func detectedLicenses(text string) []string {
+	if detected := detectLicenseFromText(text); detected != "" {
+		return []string{detected}
+	}
+	return nil
+}
+
+func isLicenseFileName(fileName string) bool {
+	lowerName := strings.ToLower(fileName)
+	return strings.HasPrefix(lowerName, "license") || strings.HasPrefix(lowerName, "licence") || strings.HasPrefix(lowerName, "copying")
+}
+
+// Module paths are stored in the Go modules cache with each capital letter replaced by '!' followed by the lowercase letter.
+func escapeGoModulePath(modulePath string) string {
+	var escaped strings.Builder
+	for _, char := range modulePath {
+		if char >= 'A' && char <= 'Z' {
+			escaped.WriteRune('!')
+			char += 'a' - 'A'
+		}
+		escaped.WriteRune(char)
+	}
+	return escaped.String()
+}
+
+func detectGoModCache(homeDir string) string {
+	if output, err := exec.Command("go", "env", "GOMODCACHE").Output(); err == nil {
+		if goModCache := strings.TrimSpace(string(output)); goModCache != "" {
+			return goModCache
+		}
+	}
+	if goPath := os.Getenv("GOPATH"); goPath != "" {
+		return filepath.Join(filepath.SplitList(goPath)[0], "pkg", "mod")
+	}
+	return filepath.Join(homeDir, "go", "pkg", "mod")
+}
+
+func readXml(path string, target interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(xml.Unmarshal(content, target))
+}
@@ -0,0 +1,179 @@
+package licenses
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mitLicenseText = `MIT License
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction.`
+
+func TestReadMavenLicenseFromParent(t *testing.T) {
+	reader := &licenseReader{mavenRepository: t.TempDir(), gradleCache: t.TempDir()}
+	writeFile(t, filepath.Join(reader.mavenRepository, "org", "example", "lib", "1.0", "lib-1.0.pom"),
+		`<project><parent><groupId>org.example</groupId><artifactId>parent</artifactId><version>2</version></parent></project>`)
+	parentPom := filepath.Join(reader.mavenRepository, "org", "example", "parent", "2", "parent-2.pom")
+	writeFile(t, parentPom,
+		`<project xmlns="http://maven.apache.org/POM/4.0.0"><licenses><license><name>The Apache Software License, Version 2.0</name></license></licenses></project>`)
+
+	license, err := reader.read(buildinfo.Maven, "org.example:lib:1.0")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Equal(t, []string{"Apache-2.0"}, license.licenses)
+	assert.Equal(t, parentPom, license.source)
+
+	// Gradle cache.
+	gradlePom := filepath.Join(reader.gradleCache, "org.example", "other", "1.0", "0123abcd", "other-1.0.pom")
+	writeFile(t, gradlePom, `<project><licenses><license><name>MIT License</name></license></licenses></project>`)
+	license, err = reader.read(buildinfo.Gradle, "org.example:other:1.0")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Equal(t, []string{"MIT"}, license.licenses)
+
+	license, err = reader.read(buildinfo.Maven, "org.example:missing:1.0")
+	assert.NoError(t, err)
+	assert.Nil(t, license)
+
+	// A malformed POM is skipped.
+	malformedPom := filepath.Join(reader.mavenRepository, "org", "example", "malformed", "1.0", "malformed-1.0.pom")
+	writeFile(t, malformedPom, `<project><licenses>`)
+	license, err = reader.read(buildinfo.Maven, "org.example:malformed:1.0")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Empty(t, license.licenses)
+	assert.Equal(t, malformedPom, license.source)
+}
+
+func TestReadNpmLicense(t *testing.T) {
+	reader := &licenseReader{projectDir: t.TempDir()}
+	nodeModules := filepath.Join(reader.projectDir, "node_modules")
+	writeFile(t, filepath.Join(nodeModules, "lodash", "package.json"), `{"name":"lodash","version":"4.17.21","license":"MIT"}`)
+	writeFile(t, filepath.Join(nodeModules, "@scope", "pkg", "package.json"), `{"name":"@scope/pkg","version":"1.0.0","license":"(MIT OR Apache-2.0)"}`)
+	nestedManifest := filepath.Join(nodeModules, "lodash", "node_modules", "legacy", "package.json")
+	writeFile(t, nestedManifest, `{"name":"legacy","version":"0.1.0","license":{"type":"BSD-3-Clause"}}`)
+
+	tests := []struct {
+		id       string
+		expected []string
+	}{
+		{"lodash:4.17.21", []string{"MIT"}},
+		{"@scope/pkg:1.0.0", []string{"(MIT OR Apache-2.0)"}},
+		{"legacy:0.1.0", []string{"BSD-3-Clause"}},
+	}
+	for _, test := range tests {
+		license, err := reader.read(buildinfo.Npm, test.id)
+		require.NoError(t, err)
+		require.NotNil(t, license, test.id)
+		assert.Equal(t, test.expected, license.licenses, test.id)
+	}
+	license, err := reader.read(buildinfo.Npm, "lodash:1.0.0")
+	assert.NoError(t, err)
+	assert.Nil(t, license)
+}
+
+func TestReadPythonLicense(t *testing.T) {
+	reader := &licenseReader{sitePackages: []string{t.TempDir()}}
+	writeFile(t, filepath.Join(reader.sitePackages[0], "PyYAML-6.0.1.dist-info", "METADATA"),
+		"Metadata-Version: 2.1\nName: PyYAML\nVersion: 6.0.1\nLicense: MIT\nClassifier: License :: OSI Approved :: MIT License\n\nThe License: GPL text in the description is ignored.\n")
+	writeFile(t, filepath.Join(reader.sitePackages[0], "zope.interface-6.0.dist-info", "METADATA"),
+		"Metadata-Version: 2.4\nName: zope.interface\nVersion: 6.0\nLicense-Expression: ZPL-2.1\n")
+
+	for _, id := range []string{"PyYAML-6.0.1.tar.gz", "PyYAML-6.0.1-cp311-cp311-manylinux_2_17_x86_64.whl", "pyyaml:6.0.1"} {
+		license, err := reader.read(buildinfo.Python, id)
+		require.NoError(t, err)
+		require.NotNil(t, license, id)
+		assert.Equal(t, []string{"MIT"}, license.licenses, id)
+	}
+	license, err := reader.read(buildinfo.Python, "zope-interface:6.0")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Equal(t, []string{"ZPL-2.1"}, license.licenses)
+}
+
+func TestReadNugetLicense(t *testing.T) {
+	reader := &licenseReader{nugetPackages: t.TempDir()}
+	writeFile(t, filepath.Join(reader.nugetPackages, "newtonsoft.json", "13.0.1", "newtonsoft.json.nuspec"),
+		`<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd"><metadata><license type="expression">MIT</license></metadata></package>`)
+	writeFile(t, filepath.Join(reader.nugetPackages, "legacy", "1.0.0", "legacy.nuspec"),
+		`<package><metadata><licenseUrl>https://licenses.nuget.org/Apache-2.0%20OR%20MIT</licenseUrl></metadata></package>`)
+	writeFile(t, filepath.Join(reader.nugetPackages, "embedded", "2.0.0", "embedded.nuspec"),
+		`<package><metadata><license type="file">docs/LICENSE.txt</license></metadata></package>`)
+	writeFile(t, filepath.Join(reader.nugetPackages, "embedded", "2.0.0", "docs", "LICENSE.txt"), mitLicenseText)
+
+	for id, expected := range map[string]string{"Newtonsoft.Json:13.0.1": "MIT", "Legacy:1.0.0": "Apache-2.0 OR MIT", "Embedded:2.0.0": "MIT"} {
+		license, err := reader.read(buildinfo.Nuget, id)
+		require.NoError(t, err)
+		require.NotNil(t, license, id)
+		assert.Equal(t, []string{expected}, license.licenses, id)
+	}
+
+	// A malformed nuspec, or a missing license file, is skipped.
+	writeFile(t, filepath.Join(reader.nugetPackages, "malformed", "1.0.0", "malformed.nuspec"), `<package><metadata>`)
+	license, err := reader.read(buildinfo.Nuget, "Malformed:1.0.0")
+	require.NoError(t, err)
+	assert.Nil(t, license)
+	writeFile(t, filepath.Join(reader.nugetPackages, "unlicensed", "1.0.0", "unlicensed.nuspec"),
+		`<package><metadata><license type="file">LICENSE.txt</license></metadata></package>`)
+	license, err = reader.read(buildinfo.Nuget, "Unlicensed:1.0.0")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Empty(t, license.licenses)
+}
+
+func TestReadGoLicense(t *testing.T) {
+	reader := &licenseReader{goModCache: t.TempDir()}
+	licensePath := filepath.Join(reader.goModCache, "github.com", "!burnt!sushi", "toml@v1.3.2", "COPYING")
+	writeFile(t, licensePath, mitLicenseText)
+	license, err := reader.read(buildinfo.Go, "github.com/BurntSushi/toml:v1.3.2")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Equal(t, []string{"MIT"}, license.licenses)
+	assert.Equal(t, licensePath, license.source)
+
+	// Modules which were downloaded but not extracted are read from the module zip.
+	zipPath := filepath.Join(reader.goModCache, "cache", "download", "golang.org", "x", "mod", "@v", "v0.21.0.zip")
+	require.NoError(t, os.MkdirAll(filepath.Dir(zipPath), 0755))
+	zipFile, err := os.Create(zipPath)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(zipFile)
+	fileWriter, err := zipWriter.Create("golang.org/x/mod@v0.21.0/LICENSE")
+	require.NoError(t, err)
+	_, err = fileWriter.Write([]byte("Redistribution and use in source and binary forms, with or without modification... Neither the name of Google Inc."))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, zipFile.Close())
+	license, err = reader.read(buildinfo.Go, "golang.org/x/mod:v0.21.0")
+	require.NoError(t, err)
+	require.NotNil(t, license)
+	assert.Equal(t, []string{"BSD-3-Clause"}, license.licenses)
+}
+
+func TestNormalizeLicenseName(t *testing.T) {
+	tests := map[string]string{
+		"Apache License, Version 2.0":    "Apache-2.0",
+		"Apache-2.0":                     "Apache-2.0",
+		"The MIT License":                "MIT",
+		"Eclipse Public License - v 1.0": "EPL-1.0",
+		"BSD 3-Clause":                   "BSD-3-Clause",
+		"GNU General Public License v3":  "GPL-3.0",
+		"BSD License":                    "BSD License",
+		"Proprietary":                    "Proprietary",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, normalizeLicenseName(name), name)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
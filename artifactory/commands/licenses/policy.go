@@ -0,0 +1,120 @@
+package licenses
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+type PolicyStatus string
+
+const (
+	Allowed PolicyStatus = "allowed"
+	Denied  PolicyStatus = "denied"
+	// The license isn't included in the allow list.
+	NotAllowed PolicyStatus = "not-allowed"
+	// No license was found for the dependency.
+	Unknown PolicyStatus = "unknown"
+)
+
+// The allow and deny lists of licenses, read from a YAML or JSON file:
+//
+//	allow: [MIT, Apache-2.0, BSD-*]
+//	deny: [GPL-*, AGPL-*]
+//
+// The licenses are matched case-insensitively, and may include the * wildcard.
+type LicensePolicy struct {
+	Allow []string `yaml:"allow" json:"allow"`
+	Deny  []string `yaml:"deny" json:"deny"`
+}
+
+func LoadLicensePolicy(policyPath string) (*LicensePolicy, error) {
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	policy := new(LicensePolicy)
+	if err = yaml.UnmarshalStrict(content, policy); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the license policy file %s: %s", policyPath, err.Error())
+	}
+	if len(policy.Allow) == 0 && len(policy.Deny) == 0 {
+		return nil, errorutils.CheckErrorf("the license policy file %s should include an allow list, a deny list or both", policyPath)
+	}
+	return policy, nil
+}
+
+// Evaluates the licenses of a single dependency. Each license may be an SPDX expression.
+// A dependency which declares several licenses is allowed only if all of them are allowed.
+func (lp *LicensePolicy) evaluate(licenses []string) PolicyStatus {
+	if len(licenses) == 0 {
+		return Unknown
+	}
+	severity := allowedSeverity
+	for _, license := range licenses {
+		severity = max(severity, lp.evaluateExpression(parseLicenseExpression(license)))
+	}
+	return severity.policyStatus()
+}
+
+// The severity of the evaluation of a license expression.
+type licenseSeverity int
+
+const (
+	allowedSeverity licenseSeverity = iota
+	notAllowedSeverity
+	deniedSeverity
+)
+
+// Any alternative of an OR expression may be chosen, so it's as severe as its most permissive alternative.
+// All the parts of an AND expression apply, so it's as severe as its strictest part.
+func (lp *LicensePolicy) evaluateExpression(expression *licenseExpression) licenseSeverity {
+	if expression.operator == "" {
+		return lp.evaluateLicense(expression.license)
+	}
+	severity := lp.evaluateExpression(expression.operands[0])
+	for _, operand := range expression.operands[1:] {
+		if expression.operator == "OR" {
+			severity = min(severity, lp.evaluateExpression(operand))
+		} else {
+			severity = max(severity, lp.evaluateExpression(operand))
+		}
+	}
+	return severity
+}
+
+func (lp *LicensePolicy) evaluateLicense(license string) licenseSeverity {
+	if matchesAny(license, lp.Deny) {
+		return deniedSeverity
+	}
+	if len(lp.Allow) > 0 && !matchesAny(license, lp.Allow) {
+		return notAllowedSeverity
+	}
+	return allowedSeverity
+}
+
+func (severity licenseSeverity) policyStatus() PolicyStatus {
+	switch severity {
+	case deniedSeverity:
+		return Denied
+	case notAllowedSeverity:
+		return NotAllowed
+	}
+	return Allowed
+}
+
+func (status PolicyStatus) isViolation() bool {
+	return status == Denied || status == NotAllowed
+}
+
+func matchesAny(license string, patterns []string) bool {
+	for _, pattern := range patterns {
+		patternRegexp := "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(strings.TrimSpace(pattern)), `\*`, ".*") + "$"
+		if matched, err := regexp.MatchString(patternRegexp, license); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
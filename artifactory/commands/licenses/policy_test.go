@@ -0,0 +1,109 @@
+package licenses
+
+import (
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicensePolicyEvaluate(t *testing.T) {
+	policy := &LicensePolicy{Allow: []string{"MIT", "Apache-2.0", "BSD-*"}, Deny: []string{"GPL-*", "AGPL-*"}}
+	tests := []struct {
+		licenses []string
+		expected PolicyStatus
+	}{
+		{[]string{"MIT"}, Allowed},
+		{[]string{"mit", "bsd-3-clause"}, Allowed},
+		{[]string{"GPL-3.0"}, Denied},
+		{[]string{"MIT", "GPL-2.0"}, Denied},
+		{[]string{"LGPL-2.1"}, NotAllowed},
+		{nil, Unknown},
+		// Any alternative of an OR may be chosen.
+		{[]string{"(MIT OR LGPL-2.1)"}, Allowed},
+		{[]string{"MIT OR GPL-2.0"}, Allowed},
+		{[]string{"GPL-2.0 OR LGPL-2.1"}, NotAllowed},
+		{[]string{"GPL-2.0 or AGPL-3.0"}, Denied},
+		// All the parts of an AND apply.
+		{[]string{"MIT AND BSD-2-Clause"}, Allowed},
+		{[]string{"MIT AND LGPL-2.1"}, NotAllowed},
+		{[]string{"MIT AND GPL-2.0"}, Denied},
+		// AND takes precedence over OR, unless parenthesized.
+		{[]string{"GPL-2.0 OR MIT AND Apache-2.0"}, Allowed},
+		{[]string{"(GPL-2.0 OR MIT) AND LGPL-2.1"}, NotAllowed},
+		{[]string{"MIT AND (GPL-3.0 OR (BSD-3-Clause AND Apache-2.0))"}, Allowed},
+		{[]string{"GPL-2.0 WITH Classpath-exception-2.0 OR MIT"}, Allowed},
+		{[]string{"MIT", "GPL-2.0 OR LGPL-2.1"}, NotAllowed},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, policy.evaluate(test.licenses), test.licenses)
+	}
+
+	// Without an allow list, any license which isn't denied is allowed.
+	denyOnly := &LicensePolicy{Deny: []string{"AGPL-3.0"}}
+	assert.Equal(t, Allowed, denyOnly.evaluate([]string{"LGPL-2.1"}))
+	assert.Equal(t, Denied, denyOnly.evaluate([]string{"AGPL-3.0"}))
+}
+
+func TestParseLicenseExpression(t *testing.T) {
+	assert.Equal(t, &licenseExpression{license: "MIT"}, parseLicenseExpression(" MIT "))
+	assert.Equal(t, &licenseExpression{operator: "OR", operands: []*licenseExpression{
+		{license: "MIT"},
+		{operator: "AND", operands: []*licenseExpression{{license: "Apache-2.0"}, {license: "BSD-3-Clause"}}},
+	}}, parseLicenseExpression("(MIT OR (Apache-2.0 AND BSD-3-Clause))"))
+	assert.Equal(t, &licenseExpression{license: "GPL-2.0"}, parseLicenseExpression("GPL-2.0 WITH Classpath-exception-2.0"))
+
+	// Expressions which can't be parsed are kept as a single license.
+	for _, expression := range []string{"Apache License 2.0", "(MIT OR", "MIT OR", "MIT WITH", "()"} {
+		assert.Equal(t, &licenseExpression{license: expression}, parseLicenseExpression(expression), expression)
+	}
+}
+
+func TestLoadLicensePolicy(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "policy.yaml")
+	writeFile(t, yamlPath, "allow:\n  - MIT\ndeny: [GPL-*]\n")
+	policy, err := LoadLicensePolicy(yamlPath)
+	require.NoError(t, err)
+	assert.Equal(t, &LicensePolicy{Allow: []string{"MIT"}, Deny: []string{"GPL-*"}}, policy)
+
+	jsonPath := filepath.Join(dir, "policy.json")
+	writeFile(t, jsonPath, `{"deny": ["AGPL-*"]}`)
+	policy, err = LoadLicensePolicy(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AGPL-*"}, policy.Deny)
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	writeFile(t, invalidPath, "allowed: [MIT]\n")
+	_, err = LoadLicensePolicy(invalidPath)
+	assert.Error(t, err)
+}
+
+func TestCreateInventory(t *testing.T) {
+	reader := &licenseReader{projectDir: t.TempDir()}
+	writeFile(t, filepath.Join(reader.projectDir, "node_modules", "left-pad", "package.json"), `{"name":"left-pad","version":"1.3.0","license":"WTFPL"}`)
+	writeFile(t, filepath.Join(reader.projectDir, "node_modules", "react", "package.json"), `{"name":"react","version":"18.2.0","license":"MIT"}`)
+	modules := []buildinfo.Module{
+		{Id: "web", Type: buildinfo.Npm, Dependencies: []buildinfo.Dependency{{Id: "react:18.2.0"}, {Id: "left-pad:1.3.0"}}},
+		{Id: "admin", Type: buildinfo.Npm, Dependencies: []buildinfo.Dependency{{Id: "react:18.2.0"}, {Id: "missing:1.0.0"}}},
+		{Id: "image", Type: buildinfo.Docker, Dependencies: []buildinfo.Dependency{{Id: "sha256:abc"}}},
+	}
+	policy := &LicensePolicy{Allow: []string{"MIT"}}
+	inventory, err := createInventory(modules, reader, policy)
+	require.NoError(t, err)
+	require.Len(t, inventory, 4)
+
+	assert.Equal(t, "left-pad:1.3.0", inventory[0].Id)
+	assert.Equal(t, NotAllowed, inventory[0].PolicyStatus)
+	assert.Equal(t, "missing:1.0.0", inventory[1].Id)
+	assert.Equal(t, Unknown, inventory[1].PolicyStatus)
+	assert.Empty(t, inventory[1].Source)
+	assert.Equal(t, "react:18.2.0", inventory[2].Id)
+	assert.Equal(t, Allowed, inventory[2].PolicyStatus)
+	assert.Equal(t, []string{"web", "admin"}, inventory[2].Modules)
+	assert.Equal(t, filepath.Join(reader.projectDir, "node_modules", "react", "package.json"), inventory[2].Source)
+	assert.Equal(t, "sha256:abc", inventory[3].Id)
+	assert.Equal(t, Unknown, inventory[3].PolicyStatus)
+}
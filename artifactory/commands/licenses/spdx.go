@@ -0,0 +1,185 @@
+package licenses
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Well known license names, as they appear in package manifests, mapped to their SPDX identifiers.
+// The names are matched after being lowercased, with dashes and underscores replaced by spaces.
+var licenseNameAliases = []struct {
+	pattern *regexp.Regexp
+	spdxId  string
+}{
+	{regexp.MustCompile(`^(the )?apache( software)?( license)?,? (version |v ?)?2(\.0)?( license)?$`), "Apache-2.0"},
+	{regexp.MustCompile(`^(the )?apache( software)?( license)?,? (version |v ?)?1\.1$`), "Apache-1.1"},
+	{regexp.MustCompile(`^(the )?mit( license)?$`), "MIT"},
+	{regexp.MustCompile(`^(the )?((new|modified|revised) bsd|bsd (3|three) clause)( license)?$`), "BSD-3-Clause"},
+	{regexp.MustCompile(`^(the )?((simplified|freebsd) bsd|bsd (2|two) clause)( license)?$`), "BSD-2-Clause"},
+	{regexp.MustCompile(`^(the )?isc( license)?$`), "ISC"},
+	{regexp.MustCompile(`^(the )?unlicense$`), "Unlicense"},
+	{regexp.MustCompile(`^(the )?mozilla public license,? (version |v ?)?2(\.0)?$`), "MPL-2.0"},
+	{regexp.MustCompile(`^(the )?eclipse public license,? (version |v ?)?1(\.0)?$`), "EPL-1.0"},
+	{regexp.MustCompile(`^(the )?eclipse public license,? (version |v ?)?2(\.0)?$`), "EPL-2.0"},
+	{regexp.MustCompile(`^(the )?gnu lesser general public license,? (version |v ?)?2\.1$`), "LGPL-2.1"},
+	{regexp.MustCompile(`^(the )?gnu lesser general public license,? (version |v ?)?3(\.0)?$`), "LGPL-3.0"},
+	{regexp.MustCompile(`^(the )?gnu general public license,? (version |v ?)?2(\.0)?$`), "GPL-2.0"},
+	{regexp.MustCompile(`^(the )?gnu general public license,? (version |v ?)?3(\.0)?$`), "GPL-3.0"},
+	{regexp.MustCompile(`^(the )?gnu affero general public license,? (version |v ?)?3(\.0)?$`), "AGPL-3.0"},
+	{regexp.MustCompile(`^(the )?common development and distribution license( \(?cddl\)?)?,? (version |v ?)?1(\.0)?$`), "CDDL-1.0"},
+}
+
+var licenseNameSeparators = regexp.MustCompile(`[\s\-_]+`)
+
+// Returns the SPDX identifier of a license name found in a package manifest.
+// Names which aren't recognized are returned as is.
+func normalizeLicenseName(name string) string {
+	name = strings.TrimSpace(name)
+	normalized := strings.ToLower(licenseNameSeparators.ReplaceAllString(name, " "))
+	for _, alias := range licenseNameAliases {
+		if alias.pattern.MatchString(normalized) {
+			return alias.spdxId
+		}
+	}
+	return name
+}
+
+// Phrases identifying the license of a LICENSE file. The phrases are checked in order, so more specific ones come first.
+var licenseTextPhrases = []struct {
+	phrases []string
+	spdxId  string
+}{
+	{[]string{"apache license", "version 2.0"}, "Apache-2.0"},
+	{[]string{"mozilla public license version 2.0"}, "MPL-2.0"},
+	{[]string{"mozilla public license, version 2.0"}, "MPL-2.0"},
+	{[]string{"gnu affero general public license", "version 3"}, "AGPL-3.0"},
+	{[]string{"gnu lesser general public license", "version 3"}, "LGPL-3.0"},
+	{[]string{"gnu lesser general public license", "version 2.1"}, "LGPL-2.1"},
+	{[]string{"gnu general public license", "version 3"}, "GPL-3.0"},
+	{[]string{"gnu general public license", "version 2"}, "GPL-2.0"},
+	{[]string{"eclipse public license - v 2.0"}, "EPL-2.0"},
+	{[]string{"eclipse public license - v 1.0"}, "EPL-1.0"},
+	{[]string{"this is free and unencumbered software released into the public domain"}, "Unlicense"},
+	{[]string{"permission to use, copy, modify, and/or distribute this software for any purpose"}, "ISC"},
+	{[]string{"permission is hereby granted, free of charge"}, "MIT"},
+	{[]string{"redistribution and use in source and binary forms", "neither the name"}, "BSD-3-Clause"},
+	{[]string{"redistribution and use in source and binary forms", "names of its contributors"}, "BSD-3-Clause"},
+	{[]string{"redistribution and use in source and binary forms"}, "BSD-2-Clause"},
+}
+
+var whitespaces = regexp.MustCompile(`\s+`)
+
+// Detects the license of a LICENSE file by its content. Returns an empty string if the license isn't recognized.
+func detectLicenseFromText(text string) string {
+	text = strings.ToLower(whitespaces.ReplaceAllString(text, " "))
+	for _, candidate := range licenseTextPhrases {
+		matched := true
+		for _, phrase := range candidate.phrases {
+			if !strings.Contains(text, phrase) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return candidate.spdxId
+		}
+	}
+	return ""
+}
+
+// A parsed SPDX license expression, such as "MIT OR (Apache-2.0 AND BSD-3-Clause)".
+// A leaf holds a single license identifier, and any other node combines its operands with its operator.
+type licenseExpression struct {
+	license string
+	// "OR" or "AND". Empty for a leaf.
+	operator string
+	operands []*licenseExpression
+}
+
+// Parses an SPDX license expression. AND takes precedence over OR, and parentheses group sub-expressions.
+// Expressions which can't be parsed, such as license names including spaces, are returned as a single license.
+func parseLicenseExpression(expression string) *licenseExpression {
+	parser := &licenseExpressionParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))}
+	parsed, ok := parser.parseOr()
+	if !ok || parser.position != len(parser.tokens) {
+		return &licenseExpression{license: strings.TrimSpace(expression)}
+	}
+	return parsed
+}
+
+type licenseExpressionParser struct {
+	tokens   []string
+	position int
+}
+
+func (lep *licenseExpressionParser) parseOr() (*licenseExpression, bool) {
+	return lep.parseOperation("OR", lep.parseAnd)
+}
+
+func (lep *licenseExpressionParser) parseAnd() (*licenseExpression, bool) {
+	return lep.parseOperation("AND", lep.parseTerm)
+}
+
+// Parses the operands joined by the operator. A single operand is returned as is.
+func (lep *licenseExpressionParser) parseOperation(operator string, parseOperand func() (*licenseExpression, bool)) (*licenseExpression, bool) {
+	operand, ok := parseOperand()
+	if !ok {
+		return nil, false
+	}
+	operands := []*licenseExpression{operand}
+	for lep.nextIs(operator) {
+		lep.position++
+		if operand, ok = parseOperand(); !ok {
+			return nil, false
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operand, true
+	}
+	return &licenseExpression{operator: operator, operands: operands}, true
+}
+
+// Parses a parenthesized expression, or a license identifier followed by an optional "WITH <exception>".
+func (lep *licenseExpressionParser) parseTerm() (*licenseExpression, bool) {
+	if lep.nextIs("(") {
+		lep.position++
+		expression, ok := lep.parseOr()
+		if !ok || !lep.nextIs(")") {
+			return nil, false
+		}
+		lep.position++
+		return expression, true
+	}
+	if !lep.nextIsIdentifier() {
+		return nil, false
+	}
+	token := lep.tokens[lep.position]
+	lep.position++
+	if lep.nextIs("WITH") {
+		// The exception only extends the license, so the license identifier alone is matched against the policy.
+		lep.position++
+		if !lep.nextIsIdentifier() {
+			return nil, false
+		}
+		lep.position++
+	}
+	return &licenseExpression{license: token}, true
+}
+
+func (lep *licenseExpressionParser) nextIs(token string) bool {
+	return lep.position < len(lep.tokens) && strings.EqualFold(lep.tokens[lep.position], token)
+}
+
+// Returns true if the next token is a license or exception identifier, rather than an operator or a parenthesis.
+func (lep *licenseExpressionParser) nextIsIdentifier() bool {
+	if lep.position == len(lep.tokens) {
+		return false
+	}
+	for _, reserved := range []string{"(", ")", "OR", "AND", "WITH"} {
+		if lep.nextIs(reserved) {
+			return false
+		}
+	}
+	return true
+}
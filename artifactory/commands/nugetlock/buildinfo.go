@@ -0,0 +1,137 @@
+package nugetlock
+
+import (
+	"crypto/md5"  // #nosec G501 -- md5 is one of the checksums of the build-info dependencies.
+	"crypto/sha1" // #nosec G505 -- sha1 is one of the checksums of the build-info dependencies.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	globalPackagesEnv   = "NUGET_PACKAGES"
+	globalPackagesLabel = "global-packages:"
+	nupkgType           = "nupkg"
+)
+
+// Returns the build-info dependencies of a project, over all its target frameworks. The dependencies which the project gets
+// through the projects it references are left to the modules of those projects, as in the build-info collected from the assets file.
+// The lock file only holds the SHA-512 content hash of each package, while the build-info holds its SHA-1, MD5 and SHA-256,
+// which can't be derived from it. The checksums are therefore read from the package files in the global packages folder,
+// after they're verified against the SHA-512 of the lock file.
+func getDependencies(project *ProjectGraph, packagesDir string) ([]buildinfo.Dependency, error) {
+	dependencies := make(map[string]*buildinfo.Dependency)
+	for _, framework := range project.Frameworks {
+		children, nodes := framework.getChildren(), framework.getNodes()
+		// The paths of the build-info are of the dependency IDs, which are <name>:<version> rather than the node IDs.
+		var populate func(parentNodeId, parentId string, parentRequestedBy [][]string) error
+		populate = func(parentNodeId, parentId string, parentRequestedBy [][]string) error {
+			for _, edge := range children[parentNodeId] {
+				node := nodes[edge.To]
+				if node.Type == projectType {
+					continue
+				}
+				id := node.Name + ":" + node.Resolved
+				dependency, exists := dependencies[id]
+				if !exists {
+					checksum, err := getChecksum(packagesDir, node)
+					if err != nil {
+						return err
+					}
+					dependency = &buildinfo.Dependency{Id: id, Type: nupkgType, Checksum: checksum}
+					dependencies[id] = dependency
+				}
+				var added [][]string
+				for _, requestedBy := range parentRequestedBy {
+					path := append([]string{parentId}, requestedBy...)
+					if len(dependency.RequestedBy) >= buildinfo.RequestedByMaxLength || slices.Contains(path, id) || containsPath(dependency.RequestedBy, path) {
+						continue
+					}
+					dependency.RequestedBy = append(dependency.RequestedBy, path)
+					added = append(added, path)
+				}
+				if len(added) > 0 {
+					if err := populate(edge.To, id, added); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		if err := populate(project.Name, project.Name, [][]string{{}}); err != nil {
+			return nil, err
+		}
+	}
+	result := make([]buildinfo.Dependency, 0, len(dependencies))
+	for _, id := range slices.Sorted(maps.Keys(dependencies)) {
+		result = append(result, *dependencies[id])
+	}
+	return result, nil
+}
+
+func containsPath(paths [][]string, path []string) bool {
+	return slices.ContainsFunc(paths, func(p []string) bool {
+		return slices.Equal(p, path)
+	})
+}
+
+// Returns the checksums of the package file of a node, after verifying its SHA-512 against the lock file.
+// The package is expected in the global packages folder, since it's restored by the command.
+func getChecksum(packagesDir string, node *Node) (checksum buildinfo.Checksum, err error) {
+	name, version := strings.ToLower(node.Name), strings.ToLower(node.Resolved)
+	packagePath := filepath.Join(packagesDir, name, version, name+"."+version+".nupkg")
+	file, err := os.Open(packagePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return checksum, errorutils.CheckErrorf("the package %s of the dependency %s wasn't found, so its checksums can't be added to the build-info. "+
+				"If the packages are restored to a folder other than the NuGet global packages folder, please set the %s environment variable to it", packagePath, node.Name+":"+node.Resolved, globalPackagesEnv)
+		}
+		return checksum, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	md5Hash, sha1Hash, sha256Hash, sha512Hash := md5.New(), sha1.New(), sha256.New(), sha512.New() // #nosec G401
+	if _, err = io.Copy(io.MultiWriter(md5Hash, sha1Hash, sha256Hash, sha512Hash), file); err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	if node.Sha512 != "" && base64.StdEncoding.EncodeToString(sha512Hash.Sum(nil)) != node.Sha512 {
+		return checksum, errorutils.CheckErrorf("the SHA-512 of %s doesn't match its content hash in the lock file (%s)", packagePath, node.Sha512)
+	}
+	checksum.Md5, checksum.Sha1, checksum.Sha256 = hex.EncodeToString(md5Hash.Sum(nil)), hex.EncodeToString(sha1Hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil))
+	return checksum, nil
+}
+
+// Returns the global packages folder of NuGet, into which the packages are extracted by restore.
+func getGlobalPackagesDir() (string, error) {
+	if packagesDir := os.Getenv(globalPackagesEnv); packagesDir != "" {
+		return packagesDir, nil
+	}
+	output, err := exec.Command("dotnet", "nuget", "locals", "global-packages", "--list").Output()
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to find the NuGet global packages folder: %s", err.Error())
+	}
+	return parseGlobalPackagesDir(string(output))
+}
+
+func parseGlobalPackagesDir(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		if _, packagesDir, found := strings.Cut(line, globalPackagesLabel); found {
+			return strings.TrimSpace(packagesDir), nil
+		}
+	}
+	return "", errorutils.CheckErrorf("failed to find the NuGet global packages folder in the output: %s", output)
+}
@@ -0,0 +1,56 @@
+package nugetlock
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Shows the dependency graph of the projects under the working directory, as locked by their packages.lock.json files.
+type DepsTreeCommand struct {
+	format string
+}
+
+func NewDepsTreeCommand() *DepsTreeCommand {
+	return &DepsTreeCommand{format: JsonFormat}
+}
+
+func (dtc *DepsTreeCommand) SetFormat(format string) *DepsTreeCommand {
+	dtc.format = format
+	return dtc
+}
+
+func (dtc *DepsTreeCommand) CommandName() string {
+	return "rt_nuget_deps_tree"
+}
+
+func (dtc *DepsTreeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (dtc *DepsTreeCommand) Run() error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockFiles, err := FindLockFiles(workingDir)
+	if err != nil {
+		return err
+	}
+	if len(lockFiles) == 0 {
+		return errorutils.CheckErrorf("no %s files were found under %s", lockFileName, workingDir)
+	}
+	graph, err := loadGraph(workingDir, lockFiles)
+	if err != nil {
+		return err
+	}
+	var output strings.Builder
+	if err = writeGraph(&output, graph, dtc.format); err != nil {
+		return err
+	}
+	log.Output(strings.TrimSuffix(output.String(), "\n"))
+	return nil
+}
@@ -0,0 +1,102 @@
+package nugetlock
+
+import (
+	"os"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/dotnet"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs the .NET CLI, and collects the build-info dependencies of the projects with lock files from their packages.lock.json files,
+// so the dependencies are those which the lock files pin, rather than those found in the global packages folder.
+// Without lock files, the build-info is collected by the .NET CLI command itself. Collecting the build-info of a solution in which only some
+// of the projects have lock files fails, since the dependencies of all its projects are collected the same way.
+type DotnetCommand struct {
+	dotnetCommand      *dotnet.DotnetCoreCliCommand
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewDotnetCommand() *DotnetCommand {
+	return &DotnetCommand{}
+}
+
+// Sets the .NET CLI command to run, which is configured with the build configuration.
+func (dc *DotnetCommand) SetDotnetCommand(dotnetCommand *dotnet.DotnetCoreCliCommand) *DotnetCommand {
+	dc.dotnetCommand = dotnetCommand
+	return dc
+}
+
+func (dc *DotnetCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *DotnetCommand {
+	dc.buildConfiguration = buildConfiguration
+	return dc
+}
+
+func (dc *DotnetCommand) CommandName() string {
+	return dc.dotnetCommand.CommandName()
+}
+
+func (dc *DotnetCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.dotnetCommand.ServerDetails()
+}
+
+func (dc *DotnetCommand) Run() error {
+	collectBuildInfo, err := dc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if !collectBuildInfo {
+		return dc.dotnetCommand.Run()
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockFiles, err := FindLockFiles(workingDir)
+	if err != nil {
+		return err
+	}
+	if len(lockFiles) == 0 {
+		return dc.dotnetCommand.Run()
+	}
+	// The .NET CLI command collects the dependencies of all the projects from their assets files,
+	// so the dependencies can't be collected from lock files for some of the projects only.
+	withoutLockFiles, err := findProjectsWithoutLockFiles(workingDir)
+	if err != nil {
+		return err
+	}
+	if len(withoutLockFiles) > 0 {
+		return errorutils.CheckErrorf("the build-info can't be collected, because some of the projects under %s have %s files, while these projects don't:\n%s\n"+
+			"Please set RestorePackagesWithLockFile in all the projects, or in none of them", workingDir, lockFileName, strings.Join(withoutLockFiles, "\n"))
+	}
+	// The build-info is collected from the lock files rather than by the command.
+	dc.dotnetCommand.SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	if err = dc.dotnetCommand.Run(); err != nil {
+		return err
+	}
+	log.Info("Collecting the build-info dependencies from", len(lockFiles), "lock files...")
+	// The lock files are read after the command, which may update them.
+	graph, err := loadGraph(workingDir, lockFiles)
+	if err != nil {
+		return err
+	}
+	packagesDir, err := getGlobalPackagesDir()
+	if err != nil {
+		return err
+	}
+	for _, project := range graph.Projects {
+		dependencies, err := getDependencies(project, packagesDir)
+		if err != nil {
+			return err
+		}
+		if err = buildinfoutils.SaveDependencies(dc.buildConfiguration, project.Name, buildinfo.Nuget, dependencies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package nugetlock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The output formats of the dependency graph.
+const (
+	JsonFormat = "json"
+	DotFormat  = "dot"
+	TextFormat = "text"
+)
+
+var Formats = []string{JsonFormat, DotFormat, TextFormat}
+
+func writeGraph(writer io.Writer, graph *Graph, format string) error {
+	switch format {
+	case JsonFormat:
+		content, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		_, err = fmt.Fprintln(writer, string(content))
+		return errorutils.CheckError(err)
+	case DotFormat:
+		return errorutils.CheckError(writeDot(writer, graph))
+	case TextFormat:
+		return errorutils.CheckError(writeText(writer, graph))
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s'. The supported formats are: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Writes a digraph with a cluster for each project and target framework.
+func writeDot(writer io.Writer, graph *Graph) (err error) {
+	var builder strings.Builder
+	builder.WriteString("digraph dependencies {\n")
+	for projectIndex, project := range graph.Projects {
+		for frameworkIndex, framework := range project.Frameworks {
+			// The IDs of the nodes are scoped by the cluster, since a package may resolve differently in each.
+			prefix := fmt.Sprintf("%d.%d:", projectIndex, frameworkIndex)
+			fmt.Fprintf(&builder, "  subgraph %s {\n", strconv.Quote("cluster_"+prefix))
+			fmt.Fprintf(&builder, "    label=%s;\n", strconv.Quote(project.Name+" ("+framework.Framework+")"))
+			for _, node := range framework.Nodes {
+				label := node.Name
+				if node.Resolved != "" {
+					label += "\n" + node.Resolved
+				}
+				fmt.Fprintf(&builder, "    %s [label=%s];\n", strconv.Quote(prefix+node.Id), strconv.Quote(label))
+			}
+			for _, edge := range framework.Edges {
+				fmt.Fprintf(&builder, "    %s -> %s", strconv.Quote(prefix+edge.From), strconv.Quote(prefix+edge.To))
+				if edge.Requested != "" {
+					fmt.Fprintf(&builder, " [label=%s]", strconv.Quote(edge.Requested))
+				}
+				builder.WriteString(";\n")
+			}
+			builder.WriteString("  }\n")
+		}
+	}
+	builder.WriteString("}\n")
+	_, err = io.WriteString(writer, builder.String())
+	return
+}
+
+// Writes a tree for each project and target framework. The dependencies of a node which was already written aren't repeated, and it's marked with (*).
+func writeText(writer io.Writer, graph *Graph) (err error) {
+	var builder strings.Builder
+	for _, project := range graph.Projects {
+		for _, framework := range project.Frameworks {
+			fmt.Fprintf(&builder, "%s (%s)\n", project.Name, framework.Framework)
+			children, nodes := framework.getChildren(), framework.getNodes()
+			written := make(map[string]bool)
+			var writeChildren func(id, indent string)
+			writeChildren = func(id, indent string) {
+				for i, edge := range children[id] {
+					branch, childIndent := "├── ", indent+"│   "
+					if i == len(children[id])-1 {
+						branch, childIndent = "└── ", indent+"    "
+					}
+					builder.WriteString(indent + branch + formatNode(nodes[edge.To], edge))
+					if written[edge.To] && len(children[edge.To]) > 0 {
+						builder.WriteString(" (*)\n")
+						continue
+					}
+					builder.WriteString("\n")
+					written[edge.To] = true
+					writeChildren(edge.To, childIndent)
+				}
+			}
+			writeChildren(project.Name, "")
+		}
+	}
+	_, err = io.WriteString(writer, builder.String())
+	return
+}
+
+func formatNode(node *Node, edge *Edge) string {
+	text := node.Name
+	if node.Type == projectType {
+		text += " (project)"
+	} else {
+		text += " " + node.Resolved
+	}
+	if edge.Requested != "" && edge.Requested != node.Resolved {
+		text += " (requested " + edge.Requested + ")"
+	}
+	return text
+}
@@ -0,0 +1,162 @@
+package nugetlock
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// The type of the node of the project whose lock file the graph describes.
+const rootType = "Root"
+
+// The project file extensions, by which the name of a project is found.
+var projectFileExtensions = []string{".csproj", ".fsproj", ".vbproj"}
+
+// The dependency graphs of the projects of a solution, as locked by their packages.lock.json files.
+type Graph struct {
+	Projects []*ProjectGraph `json:"projects"`
+}
+
+type ProjectGraph struct {
+	Name string `json:"name"`
+	// The path of the lock file, relative to the working directory.
+	LockFile   string            `json:"lockFile"`
+	Frameworks []*FrameworkGraph `json:"frameworks"`
+}
+
+// The dependency graph of a project for one of its target frameworks. The first node is the project.
+type FrameworkGraph struct {
+	Framework string  `json:"framework"`
+	Nodes     []*Node `json:"nodes"`
+	Edges     []*Edge `json:"edges"`
+}
+
+type Node struct {
+	// <name>/<resolved version> for packages, and the name for projects.
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// Root, Project, Direct, Transitive or CentralTransitive.
+	Type string `json:"type"`
+	// The version range which the project requests. Set for the direct and centrally managed dependencies.
+	Requested string `json:"requested,omitempty"`
+	Resolved  string `json:"resolved,omitempty"`
+	// The base64 SHA-512 of the package file.
+	Sha512 string `json:"sha512,omitempty"`
+}
+
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// The version range which the source node requests.
+	Requested string `json:"requested,omitempty"`
+}
+
+// Reads the lock files, and the Directory.Packages.props files which apply to them, into a graph.
+func loadGraph(rootDir string, lockFiles []string) (*Graph, error) {
+	graph := new(Graph)
+	for _, lockFilePath := range lockFiles {
+		lock, err := readLockFile(lockFilePath)
+		if err != nil {
+			return nil, err
+		}
+		projectDir := filepath.Dir(lockFilePath)
+		centralVersions, err := readCentralVersions(projectDir, rootDir)
+		if err != nil {
+			return nil, err
+		}
+		relativePath, err := filepath.Rel(rootDir, lockFilePath)
+		if err != nil {
+			relativePath = lockFilePath
+		}
+		graph.Projects = append(graph.Projects, newProjectGraph(getProjectName(projectDir), filepath.ToSlash(relativePath), lock, centralVersions))
+	}
+	return graph, nil
+}
+
+// Returns the name of the project file in the directory, or the name of the directory if there's no single project file.
+func getProjectName(projectDir string) string {
+	entries, err := os.ReadDir(projectDir)
+	if err == nil {
+		var names []string
+		for _, entry := range entries {
+			if extension := filepath.Ext(entry.Name()); !entry.IsDir() && slices.Contains(projectFileExtensions, extension) {
+				names = append(names, strings.TrimSuffix(entry.Name(), extension))
+			}
+		}
+		if len(names) == 1 {
+			return names[0]
+		}
+	}
+	return filepath.Base(projectDir)
+}
+
+func newProjectGraph(name, lockFilePath string, lock *lockFile, centralVersions map[string]string) *ProjectGraph {
+	projectGraph := &ProjectGraph{Name: name, LockFile: lockFilePath}
+	for _, framework := range slices.Sorted(maps.Keys(lock.Dependencies)) {
+		projectGraph.Frameworks = append(projectGraph.Frameworks, newFrameworkGraph(name, framework, lock.Dependencies[framework], centralVersions))
+	}
+	return projectGraph
+}
+
+func newFrameworkGraph(projectName, framework string, dependencies map[string]*lockDependency, centralVersions map[string]string) *FrameworkGraph {
+	root := &Node{Id: projectName, Name: projectName, Type: rootType}
+	frameworkGraph := &FrameworkGraph{Framework: framework}
+	// NuGet package names are case-insensitive, so the dependencies are referred to by their lowercase names.
+	ids := make(map[string]string)
+	// The projects which are referenced by other projects, rather than directly by the root project.
+	referencedProjects := make(map[string]bool)
+	for _, name := range slices.Sorted(maps.Keys(dependencies)) {
+		dependency := dependencies[name]
+		node := &Node{Id: name, Name: name, Type: dependency.Type, Requested: dependency.Requested}
+		if dependency.Type != projectType {
+			node.Id, node.Resolved, node.Sha512 = name+"/"+dependency.Resolved, dependency.Resolved, dependency.ContentHash
+			if node.Requested == "" && dependency.Type == directType {
+				node.Requested = centralVersions[strings.ToLower(name)]
+			}
+		} else {
+			for child := range dependency.Dependencies {
+				referencedProjects[strings.ToLower(child)] = true
+			}
+		}
+		frameworkGraph.Nodes = append(frameworkGraph.Nodes, node)
+		ids[strings.ToLower(name)] = node.Id
+	}
+	for _, node := range frameworkGraph.Nodes {
+		if node.Type == directType || (node.Type == projectType && !referencedProjects[strings.ToLower(node.Name)]) {
+			frameworkGraph.Edges = append(frameworkGraph.Edges, &Edge{From: root.Id, To: node.Id, Requested: node.Requested})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(dependencies)) {
+		dependency := dependencies[name]
+		for _, child := range slices.Sorted(maps.Keys(dependency.Dependencies)) {
+			// The dependencies of a package which don't apply to the target framework aren't locked.
+			if to, exists := ids[strings.ToLower(child)]; exists {
+				frameworkGraph.Edges = append(frameworkGraph.Edges, &Edge{From: ids[strings.ToLower(name)], To: to, Requested: dependency.Dependencies[child]})
+			}
+		}
+	}
+	slices.SortFunc(frameworkGraph.Nodes, func(a, b *Node) int {
+		return strings.Compare(strings.ToLower(a.Id), strings.ToLower(b.Id))
+	})
+	frameworkGraph.Nodes = append([]*Node{root}, frameworkGraph.Nodes...)
+	return frameworkGraph
+}
+
+// Returns the edges from each node, by the ID of the node.
+func (fg *FrameworkGraph) getChildren() map[string][]*Edge {
+	children := make(map[string][]*Edge)
+	for _, edge := range fg.Edges {
+		children[edge.From] = append(children[edge.From], edge)
+	}
+	return children
+}
+
+func (fg *FrameworkGraph) getNodes() map[string]*Node {
+	nodes := make(map[string]*Node)
+	for _, node := range fg.Nodes {
+		nodes[node.Id] = node
+	}
+	return nodes
+}
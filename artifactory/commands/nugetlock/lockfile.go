@@ -0,0 +1,156 @@
+package nugetlock
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	lockFileName            = "packages.lock.json"
+	centralPackagesFileName = "Directory.Packages.props"
+)
+
+// The types of the dependencies in a lock file, besides Transitive and CentralTransitive.
+const (
+	directType  = "Direct"
+	projectType = "Project"
+)
+
+// The directories which don't include projects or their lock files.
+var skippedDirs = []string{"bin", "obj", "node_modules"}
+
+// The packages.lock.json file, which NuGet writes next to a project when RestorePackagesWithLockFile is set.
+type lockFile struct {
+	Version int `json:"version"`
+	// The dependencies of each target framework, by their names.
+	Dependencies map[string]map[string]*lockDependency `json:"dependencies"`
+}
+
+type lockDependency struct {
+	Type      string `json:"type"`
+	Requested string `json:"requested,omitempty"`
+	Resolved  string `json:"resolved,omitempty"`
+	// The base64 SHA-512 of the package file.
+	ContentHash string `json:"contentHash,omitempty"`
+	// The version ranges which the dependency requests from its own dependencies, by their names.
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+func readLockFile(lockFilePath string) (*lockFile, error) {
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lock := new(lockFile)
+	if err = json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", lockFilePath, err.Error())
+	}
+	if lock.Version == 0 {
+		return nil, errorutils.CheckErrorf("%s is not a NuGet lock file", lockFilePath)
+	}
+	return lock, nil
+}
+
+// Returns the paths of the packages.lock.json files under the root directory, sorted.
+func FindLockFiles(rootDir string) ([]string, error) {
+	return findFiles(rootDir, func(fileName string) bool {
+		return fileName == lockFileName
+	})
+}
+
+// Returns the paths of the project files under the root directory, which have no packages.lock.json file next to them, sorted.
+func findProjectsWithoutLockFiles(rootDir string) ([]string, error) {
+	projectFiles, err := findFiles(rootDir, func(fileName string) bool {
+		return slices.Contains(projectFileExtensions, filepath.Ext(fileName))
+	})
+	if err != nil {
+		return nil, err
+	}
+	var withoutLockFiles []string
+	for _, projectFile := range projectFiles {
+		if _, err = os.Stat(filepath.Join(filepath.Dir(projectFile), lockFileName)); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, errorutils.CheckError(err)
+			}
+			withoutLockFiles = append(withoutLockFiles, projectFile)
+		}
+	}
+	return withoutLockFiles, nil
+}
+
+// Returns the paths of the files under the root directory which match by their names, sorted.
+// The hidden directories and the directories which don't include projects are skipped.
+func findFiles(rootDir string, match func(fileName string) bool) (files []string, err error) {
+	err = filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != rootDir && (strings.HasPrefix(entry.Name(), ".") || slices.Contains(skippedDirs, entry.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if match(entry.Name()) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// The Directory.Packages.props file, which sets the versions of the packages centrally when ManagePackageVersionsCentrally is set.
+type centralPackagesFile struct {
+	ItemGroups []struct {
+		PackageVersions []struct {
+			Include string `xml:"Include,attr"`
+			Version string `xml:"Version,attr"`
+		} `xml:"PackageVersion"`
+	} `xml:"ItemGroup"`
+}
+
+// Returns the central package versions which apply to a project, by their lowercase package names.
+// Like MSBuild, the nearest Directory.Packages.props file in the directory of the project or its parents up to the root directory is used.
+// Returns nil if the project doesn't use central package management.
+func readCentralVersions(projectDir, rootDir string) (map[string]string, error) {
+	for dir := projectDir; ; dir = filepath.Dir(dir) {
+		content, err := os.ReadFile(filepath.Join(dir, centralPackagesFileName))
+		if err == nil {
+			return parseCentralVersions(content)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, errorutils.CheckError(err)
+		}
+		if dir == rootDir || filepath.Dir(dir) == dir {
+			return nil, nil
+		}
+	}
+}
+
+func parseCentralVersions(content []byte) (map[string]string, error) {
+	props := new(centralPackagesFile)
+	if err := xml.Unmarshal(content, props); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", centralPackagesFileName, err.Error())
+	}
+	versions := make(map[string]string)
+	for _, itemGroup := range props.ItemGroups {
+		for _, packageVersion := range itemGroup.PackageVersions {
+			if packageVersion.Include != "" && packageVersion.Version != "" {
+				versions[strings.ToLower(packageVersion.Include)] = packageVersion.Version
+			}
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,209 @@
+package nugetlock
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appLockFile = `{
+  "version": 1,
+  "dependencies": {
+    "net8.0": {
+      "Newtonsoft.Json": {
+        "type": "Direct",
+        "resolved": "13.0.3",
+        "contentHash": "%s"
+      },
+      "Serilog": {
+        "type": "Direct",
+        "requested": "[3.1.0, )",
+        "resolved": "3.1.1",
+        "contentHash": "serilog-hash",
+        "dependencies": {
+          "System.Runtime": "4.3.0"
+        }
+      },
+      "System.Runtime": {
+        "type": "CentralTransitive",
+        "requested": "[4.3.1, )",
+        "resolved": "4.3.1",
+        "contentHash": "runtime-hash"
+      },
+      "Lib": {
+        "type": "Project",
+        "dependencies": {
+          "Newtonsoft.Json": "[13.0.3, )"
+        }
+      }
+    }
+  }
+}`
+
+const centralPackages = `<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="newtonsoft.json" Version="13.0.3" />
+    <PackageVersion Include="System.Runtime" Version="4.3.1" />
+  </ItemGroup>
+</Project>`
+
+var nupkgContent = []byte("newtonsoft.json nupkg")
+
+func sha512Of(content []byte) string {
+	checksum := sha512.Sum512(content)
+	return base64.StdEncoding.EncodeToString(checksum[:])
+}
+
+// Creates a solution with the App project, which has a lock file and references the Lib project, under central package management.
+func createSolution(t *testing.T, newtonsoftHash string) string {
+	rootDir := t.TempDir()
+	appDir := filepath.Join(rootDir, "src", "App")
+	require.NoError(t, os.MkdirAll(filepath.Join(appDir, "obj"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, centralPackagesFileName), []byte(centralPackages), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, "App.csproj"), []byte("<Project />"), 0644))
+	lockContent := []byte(fmt.Sprintf(appLockFile, newtonsoftHash))
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, lockFileName), lockContent, 0644))
+	// Lock files under obj aren't of projects.
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, "obj", lockFileName), lockContent, 0644))
+	return rootDir
+}
+
+func loadTestGraph(t *testing.T, rootDir string) *Graph {
+	lockFiles, err := FindLockFiles(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(rootDir, "src", "App", lockFileName)}, lockFiles)
+	graph, err := loadGraph(rootDir, lockFiles)
+	require.NoError(t, err)
+	return graph
+}
+
+func TestLoadGraph(t *testing.T) {
+	graph := loadTestGraph(t, createSolution(t, "newtonsoft-hash"))
+	require.Len(t, graph.Projects, 1)
+	assert.Equal(t, "App", graph.Projects[0].Name)
+	assert.Equal(t, "src/App/"+lockFileName, graph.Projects[0].LockFile)
+	require.Len(t, graph.Projects[0].Frameworks, 1)
+	framework := graph.Projects[0].Frameworks[0]
+	assert.Equal(t, "net8.0", framework.Framework)
+	assert.Equal(t, []*Node{
+		{Id: "App", Name: "App", Type: rootType},
+		{Id: "Lib", Name: "Lib", Type: projectType},
+		// The requested version of a direct dependency which the lock file doesn't include is taken from Directory.Packages.props.
+		{Id: "Newtonsoft.Json/13.0.3", Name: "Newtonsoft.Json", Type: directType, Requested: "13.0.3", Resolved: "13.0.3", Sha512: "newtonsoft-hash"},
+		{Id: "Serilog/3.1.1", Name: "Serilog", Type: directType, Requested: "[3.1.0, )", Resolved: "3.1.1", Sha512: "serilog-hash"},
+		{Id: "System.Runtime/4.3.1", Name: "System.Runtime", Type: "CentralTransitive", Requested: "[4.3.1, )", Resolved: "4.3.1", Sha512: "runtime-hash"},
+	}, framework.Nodes)
+	assert.Equal(t, []*Edge{
+		{From: "App", To: "Lib"},
+		{From: "App", To: "Newtonsoft.Json/13.0.3", Requested: "13.0.3"},
+		{From: "App", To: "Serilog/3.1.1", Requested: "[3.1.0, )"},
+		{From: "Lib", To: "Newtonsoft.Json/13.0.3", Requested: "[13.0.3, )"},
+		{From: "Serilog/3.1.1", To: "System.Runtime/4.3.1", Requested: "4.3.0"},
+	}, framework.Edges)
+}
+
+func TestFindProjectsWithoutLockFiles(t *testing.T) {
+	rootDir := createSolution(t, "newtonsoft-hash")
+	withoutLockFiles, err := findProjectsWithoutLockFiles(rootDir)
+	require.NoError(t, err)
+	assert.Empty(t, withoutLockFiles)
+
+	libDir := filepath.Join(rootDir, "src", "Lib")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "Lib.csproj"), []byte("<Project />"), 0644))
+	withoutLockFiles, err = findProjectsWithoutLockFiles(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(libDir, "Lib.csproj")}, withoutLockFiles)
+}
+
+func TestWriteGraph(t *testing.T) {
+	graph := loadTestGraph(t, createSolution(t, "newtonsoft-hash"))
+
+	output := new(bytes.Buffer)
+	require.NoError(t, writeGraph(output, graph, TextFormat))
+	assert.Equal(t, `App (net8.0)
+├── Lib (project)
+│   └── Newtonsoft.Json 13.0.3 (requested [13.0.3, ))
+├── Newtonsoft.Json 13.0.3
+└── Serilog 3.1.1 (requested [3.1.0, ))
+    └── System.Runtime 4.3.1 (requested 4.3.0)
+`, output.String())
+
+	output.Reset()
+	require.NoError(t, writeGraph(output, graph, DotFormat))
+	assert.Contains(t, output.String(), `    "0.0:App" -> "0.0:Serilog/3.1.1" [label="[3.1.0, )"];`)
+	assert.Contains(t, output.String(), `    "0.0:System.Runtime/4.3.1" [label="System.Runtime\n4.3.1"];`)
+
+	output.Reset()
+	require.NoError(t, writeGraph(output, graph, JsonFormat))
+	parsed := new(Graph)
+	require.NoError(t, json.Unmarshal(output.Bytes(), parsed))
+	assert.Equal(t, graph, parsed)
+
+	assert.ErrorContains(t, writeGraph(output, graph, "yaml"), "unsupported format")
+}
+
+func TestGetDependencies(t *testing.T) {
+	packagesDir := t.TempDir()
+	writePackage := func(name, version string) {
+		packageDir := filepath.Join(packagesDir, name, version)
+		require.NoError(t, os.MkdirAll(packageDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, name+"."+version+".nupkg"), nupkgContent, 0644))
+	}
+	writePackage("newtonsoft.json", "13.0.3")
+	writePackage("serilog", "3.1.1")
+
+	graph := loadTestGraph(t, createSolution(t, sha512Of(nupkgContent)))
+	// All the packages share the same content.
+	for _, node := range graph.Projects[0].Frameworks[0].Nodes {
+		if node.Sha512 != "" {
+			node.Sha512 = sha512Of(nupkgContent)
+		}
+	}
+	// The packages which aren't in the global packages folder fail the collection.
+	_, err := getDependencies(graph.Projects[0], packagesDir)
+	assert.ErrorContains(t, err, "system.runtime.4.3.1.nupkg of the dependency System.Runtime:4.3.1 wasn't found")
+
+	writePackage("system.runtime", "4.3.1")
+	dependencies, err := getDependencies(graph.Projects[0], packagesDir)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 3)
+	assert.Equal(t, "Newtonsoft.Json:13.0.3", dependencies[0].Id)
+	assert.Equal(t, [][]string{{"App"}}, dependencies[0].RequestedBy)
+	sha1Checksum := sha1.Sum(nupkgContent)
+	assert.Equal(t, hex.EncodeToString(sha1Checksum[:]), dependencies[0].Sha1)
+	assert.NotEmpty(t, dependencies[0].Md5)
+	assert.NotEmpty(t, dependencies[0].Sha256)
+	assert.Equal(t, "Serilog:3.1.1", dependencies[1].Id)
+	assert.Equal(t, [][]string{{"App"}}, dependencies[1].RequestedBy)
+	assert.Equal(t, "System.Runtime:4.3.1", dependencies[2].Id)
+	assert.Equal(t, [][]string{{"Serilog:3.1.1", "App"}}, dependencies[2].RequestedBy)
+
+	graph = loadTestGraph(t, createSolution(t, sha512Of([]byte("other"))))
+	_, err = getDependencies(graph.Projects[0], packagesDir)
+	assert.ErrorContains(t, err, "doesn't match its content hash in the lock file")
+}
+
+func TestParseGlobalPackagesDir(t *testing.T) {
+	packagesDir, err := parseGlobalPackagesDir("global-packages: /home/user/.nuget/packages/\n")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.nuget/packages/", packagesDir)
+	packagesDir, err = parseGlobalPackagesDir("info : global-packages: C:\\Users\\user\\.nuget\\packages\\\r\n")
+	require.NoError(t, err)
+	assert.Equal(t, "C:\\Users\\user\\.nuget\\packages\\", packagesDir)
+	_, err = parseGlobalPackagesDir("error: unknown command")
+	assert.Error(t, err)
+}
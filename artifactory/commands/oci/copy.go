@@ -0,0 +1,131 @@
+package oci
+
+import (
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Copies an image, or a whole multi-platform image index, from a Docker repository to another, which may be on another Artifactory server.
+// The blobs are streamed from the source registry to the target registry, so the image isn't pulled locally and no Docker daemon is needed.
+// Blobs which already exist in the target repository are skipped.
+type CopyCommand struct {
+	sourceImageReference string
+	targetImageReference string
+	sourceServerDetails  *config.ServerDetails
+	targetServerDetails  *config.ServerDetails
+	detailedSummary      bool
+	buildConfiguration   *build.BuildConfiguration
+	result               *commandsutils.Result
+}
+
+func NewCopyCommand() *CopyCommand {
+	return &CopyCommand{result: new(commandsutils.Result)}
+}
+
+func (cc *CopyCommand) SetSourceImageReference(sourceImageReference string) *CopyCommand {
+	cc.sourceImageReference = sourceImageReference
+	return cc
+}
+
+func (cc *CopyCommand) SetTargetImageReference(targetImageReference string) *CopyCommand {
+	cc.targetImageReference = targetImageReference
+	return cc
+}
+
+func (cc *CopyCommand) SetSourceServerDetails(sourceServerDetails *config.ServerDetails) *CopyCommand {
+	cc.sourceServerDetails = sourceServerDetails
+	return cc
+}
+
+func (cc *CopyCommand) SetTargetServerDetails(targetServerDetails *config.ServerDetails) *CopyCommand {
+	cc.targetServerDetails = targetServerDetails
+	return cc
+}
+
+// Sets the build configuration, in which the copied image is recorded as artifacts of the target.
+func (cc *CopyCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *CopyCommand {
+	cc.buildConfiguration = buildConfiguration
+	return cc
+}
+
+func (cc *CopyCommand) SetDetailedSummary(detailedSummary bool) *CopyCommand {
+	cc.detailedSummary = detailedSummary
+	return cc
+}
+
+func (cc *CopyCommand) IsDetailedSummary() bool {
+	return cc.detailedSummary
+}
+
+func (cc *CopyCommand) Result() *commandsutils.Result {
+	return cc.result
+}
+
+func (cc *CopyCommand) CommandName() string {
+	return "rt_docker_copy"
+}
+
+// The target server, to which the image is copied and in which the build-info is recorded.
+func (cc *CopyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.targetServerDetails, nil
+}
+
+func (cc *CopyCommand) Run() error {
+	sourceRef, err := parseImageReference(cc.sourceImageReference)
+	if err != nil {
+		return err
+	}
+	targetRef, err := parseImageReference(cc.targetImageReference)
+	if err != nil {
+		return err
+	}
+	if targetRef.isDigest() {
+		return errorutils.CheckErrorf("an image can only be copied to a tag: %s", cc.targetImageReference)
+	}
+	source, err := newRegistryClient(cc.sourceServerDetails, sourceRef.repo, sourceRef.image)
+	if err != nil {
+		return err
+	}
+	if err = source.authenticate("pull"); err != nil {
+		return err
+	}
+	target, err := newRegistryClient(cc.targetServerDetails, targetRef.repo, targetRef.image)
+	if err != nil {
+		return err
+	}
+	if err = target.authenticate("pull,push"); err != nil {
+		return err
+	}
+	log.Info("Copying the image", sourceRef.String(), "to", targetRef.String())
+	childDigests, err := copyImage(source, target, sourceRef, targetRef.reference)
+	if err != nil {
+		return err
+	}
+	log.Info("Copied the image", sourceRef.String(), "to", targetRef.String())
+	return collectPushedFiles(cc.targetServerDetails, cc.buildConfiguration, targetRef, childDigests, cc.detailedSummary, cc.result)
+}
+
+// Copies the manifest of the source image reference, with all the content it references, and tags it in the target.
+// Returns the digests of the manifests referenced by the tagged index, which Artifactory stores in their own folders.
+func copyImage(source, target *registryClient, sourceRef *imageReference, tag string) ([]string, error) {
+	content, mediaType, err := source.getManifest(sourceRef.reference)
+	if err != nil {
+		return nil, err
+	}
+	if sourceRef.isDigest() && digestOf(content) != sourceRef.reference {
+		return nil, errorutils.CheckErrorf("the digest of the copied manifest %s doesn't match: %s", sourceRef.reference, digestOf(content))
+	}
+	m, err := parseManifest(content)
+	if err != nil {
+		return nil, err
+	}
+	childDigests, err := pushReferences(target, source, m, "")
+	if err != nil {
+		return nil, err
+	}
+	_, err = target.putManifest(tag, mediaType, content)
+	return childDigests, err
+}
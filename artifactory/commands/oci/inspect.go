@@ -0,0 +1,440 @@
+package oci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-cli/utils/imageindex"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	TableFormat = "table"
+	JsonFormat  = "json"
+
+	// The annotations of the attestation manifests, which buildx adds to the index.
+	referenceTypeAnnotation  = "vnd.docker.reference.type"
+	attestationReferenceType = "attestation-manifest"
+	// The longest instruction shown in the table.
+	maxInstructionLength = 60
+)
+
+// Lists the layers of an image in an Artifactory Docker repository, or in a local OCI image layout (a directory or a tarball),
+// with their sizes and the instructions which created them. The layers of an image in Artifactory are searched by their checksums
+// in its repository, to find the other tags which share them, and to suggest a common base image.
+type InspectLayersCommand struct {
+	source        string
+	format        string
+	serverDetails *config.ServerDetails
+}
+
+func NewInspectLayersCommand() *InspectLayersCommand {
+	return &InspectLayersCommand{format: TableFormat}
+}
+
+// Sets the image reference (<repository>/<image>:<tag>), or the path of a local OCI image layout.
+func (ilc *InspectLayersCommand) SetSource(source string) *InspectLayersCommand {
+	ilc.source = source
+	return ilc
+}
+
+func (ilc *InspectLayersCommand) SetFormat(format string) *InspectLayersCommand {
+	ilc.format = format
+	return ilc
+}
+
+func (ilc *InspectLayersCommand) SetServerDetails(serverDetails *config.ServerDetails) *InspectLayersCommand {
+	ilc.serverDetails = serverDetails
+	return ilc
+}
+
+func (ilc *InspectLayersCommand) CommandName() string {
+	return "rt_docker_inspect_layers"
+}
+
+func (ilc *InspectLayersCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ilc.serverDetails, nil
+}
+
+// A layer of an image.
+type Layer struct {
+	Platform  string `json:"platform,omitempty"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	CreatedBy string `json:"createdBy,omitempty"`
+	// The other tags in the repository, which include this layer.
+	SharedWith []string `json:"sharedWith,omitempty"`
+}
+
+type layerRow struct {
+	Platform   string `col-name:"Platform"`
+	Index      string `col-name:"#"`
+	Digest     string `col-name:"Digest"`
+	Size       string `col-name:"Size"`
+	CreatedBy  string `col-name:"Created By"`
+	SharedWith string `col-name:"Shared With"`
+}
+
+// The content of an image, in a registry or in an OCI image layout.
+type imageContent interface {
+	readManifest(digest string) ([]byte, error)
+	readBlob(digest string) ([]byte, error)
+	// Returns the content of a blob, without verifying it. The caller is responsible for closing it.
+	getBlob(digest string) (io.ReadCloser, error)
+}
+
+// Returns a manifest by its digest, after verifying it.
+func (rc *registryClient) readManifest(digest string) ([]byte, error) {
+	content, _, err := rc.getManifest(digest)
+	if err != nil {
+		return nil, err
+	}
+	if digestOf(content) != digest {
+		return nil, errorutils.CheckErrorf("the digest of the manifest %s doesn't match its content", digest)
+	}
+	return content, nil
+}
+
+// In an OCI image layout, manifests are stored as blobs.
+func (ol *ociLayout) readManifest(digest string) ([]byte, error) {
+	return ol.readBlob(digest)
+}
+
+func (ol *ociLayout) getBlob(digest string) (io.ReadCloser, error) {
+	file, _, err := ol.openBlob(digest)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// The fields of an image config, which describe the platform and the history of the layers.
+type imageConfig struct {
+	Architecture string `json:"architecture"`
+	Os           string `json:"os"`
+	Variant      string `json:"variant"`
+	History      []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+func (ilc *InspectLayersCommand) Run() error {
+	if ilc.format != TableFormat && ilc.format != JsonFormat {
+		return errorutils.CheckErrorf("unsupported format '%s'. Acceptable values are: %s, %s", ilc.format, TableFormat, JsonFormat)
+	}
+	// The layers of a local layout aren't searched in Artifactory, so there is nothing to suggest.
+	if _, statErr := os.Stat(ilc.source); statErr == nil {
+		layers, err := ilc.inspectLayout()
+		if err != nil {
+			return err
+		}
+		return printLayers(layers, nil, ilc.format)
+	}
+	layers, err := ilc.inspectImage()
+	if err != nil {
+		return err
+	}
+	return printLayers(layers, suggestBaseImages(layers), ilc.format)
+}
+
+func (ilc *InspectLayersCommand) inspectLayout() (layers []Layer, err error) {
+	layoutDir := ilc.source
+	isDir, err := fileutils.IsDirExists(ilc.source, false)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		if layoutDir, err = fileutils.CreateTempDir(); err != nil {
+			return nil, err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(layoutDir))
+		}()
+		if err = extractLayout(ilc.source, layoutDir); err != nil {
+			return nil, err
+		}
+	}
+	layout := &ociLayout{dir: layoutDir}
+	index, indexContent, err := layout.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	if len(index.Manifests) == 1 {
+		if indexContent, err = layout.readBlob(index.Manifests[0].Digest); err != nil {
+			return nil, err
+		}
+	}
+	return inspectManifest(layout, indexContent)
+}
+
+func (ilc *InspectLayersCommand) inspectImage() ([]Layer, error) {
+	ref, err := parseImageReference(ilc.source)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newRegistryClient(ilc.serverDetails, ref.repo, ref.image)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.authenticate("pull"); err != nil {
+		return nil, err
+	}
+	manifestContent, _, err := client.getManifest(ref.reference)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := inspectManifest(client, manifestContent)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Searching the layers of", ref.String(), "in the other tags of the", ref.repo, "repository")
+	servicesManager, err := utils.CreateServiceManager(ilc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return layers, findSharedLayers(servicesManager, ref, manifestContent, layers)
+}
+
+// Returns the layers of an image manifest, or of all the image manifests referenced by an image index.
+func inspectManifest(content imageContent, manifestContent []byte) ([]Layer, error) {
+	m, err := parseManifest(manifestContent)
+	if err != nil {
+		return nil, err
+	}
+	var layers []Layer
+	for _, child := range m.Manifests {
+		if child.Annotations[referenceTypeAnnotation] == attestationReferenceType {
+			continue
+		}
+		childContent, err := content.readManifest(child.Digest)
+		if err != nil {
+			return nil, err
+		}
+		childLayers, err := inspectManifest(content, childContent)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, childLayers...)
+	}
+	if m.Config == nil {
+		return layers, nil
+	}
+	configContent, err := content.readBlob(m.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	imgConfig := new(imageConfig)
+	if err = json.Unmarshal(configContent, imgConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the image config %s: %s", m.Config.Digest, err.Error())
+	}
+	platform := path.Join(imgConfig.Os, imgConfig.Architecture, imgConfig.Variant)
+	// The history includes an entry for each instruction, and only the instructions which aren't marked as empty layers created a layer.
+	var instructions []string
+	for _, history := range imgConfig.History {
+		if !history.EmptyLayer {
+			instructions = append(instructions, history.CreatedBy)
+		}
+	}
+	for i, layer := range m.Layers {
+		imageLayer := Layer{Platform: platform, Digest: layer.Digest, Size: layer.Size}
+		if len(instructions) == len(m.Layers) {
+			imageLayer.CreatedBy = instructions[i]
+		}
+		layers = append(layers, imageLayer)
+	}
+	return layers, nil
+}
+
+// Searches the layers by their checksums in the repository of the image, and sets the other tags which share them.
+// The folders of the image itself, the tag folder and the folders of the manifests of its index, are excluded.
+func findSharedLayers(servicesManager artifactory.ArtifactoryServicesManager, ref *imageReference, manifestContent []byte, layers []Layer) error {
+	if len(layers) == 0 {
+		return nil
+	}
+	excludedFolders := map[string]bool{path.Join(ref.image, ref.reference): true, imageindex.ManifestFolder(ref.image, digestOf(manifestContent)): true}
+	if m, err := parseManifest(manifestContent); err == nil {
+		for _, child := range m.Manifests {
+			excludedFolders[imageindex.ManifestFolder(ref.image, child.Digest)] = true
+		}
+	}
+	var checksums []map[string]string
+	for _, layer := range layers {
+		checksums = append(checksums, map[string]string{"sha256": strings.TrimPrefix(layer.Digest, "sha256:")})
+	}
+	criteria, err := json.Marshal(map[string]interface{}{"repo": ref.repo, "type": "file", "$or": checksums})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = artifactoryutils.RunAql(servicesManager, fmt.Sprintf(`items.find(%s).include("path","name","sha256")`, criteria), result); err != nil {
+		return err
+	}
+	sharedWith := make(map[string]map[string]bool)
+	for _, item := range result.Results {
+		if excludedFolders[item.Path] || strings.HasPrefix(path.Base(item.Path), "_") {
+			continue
+		}
+		if sharedWith[item.Sha256] == nil {
+			sharedWith[item.Sha256] = make(map[string]bool)
+		}
+		sharedWith[item.Sha256][folderToImage(ref.repo, item.Path)] = true
+	}
+	for i := range layers {
+		for image := range sharedWith[strings.TrimPrefix(layers[i].Digest, "sha256:")] {
+			layers[i].SharedWith = append(layers[i].SharedWith, image)
+		}
+		sort.Strings(layers[i].SharedWith)
+	}
+	return nil
+}
+
+// Returns the image reference of a folder in the repository: <repository>/<image>:<tag>, or <repository>/<image>@<digest> for the folder of a manifest.
+func folderToImage(repo, folder string) string {
+	image, reference := path.Dir(folder), path.Base(folder)
+	if digest, isDigest := strings.CutPrefix(reference, "sha256__"); isDigest {
+		return repo + "/" + image + "@sha256:" + digest
+	}
+	return repo + "/" + image + ":" + reference
+}
+
+// Suggests a common base image for each platform: the tag which shares the longest sequence of the first layers of the image.
+// The layers above the shared ones are unique to the image, and are candidates for deduplication.
+func suggestBaseImages(layers []Layer) []string {
+	var platforms []string
+	layersByPlatform := make(map[string][]Layer)
+	for _, layer := range layers {
+		if layersByPlatform[layer.Platform] == nil {
+			platforms = append(platforms, layer.Platform)
+		}
+		layersByPlatform[layer.Platform] = append(layersByPlatform[layer.Platform], layer)
+	}
+	var suggestions []string
+	for _, platform := range platforms {
+		platformLayers := layersByPlatform[platform]
+		baseImage, sharedCount := longestSharedPrefix(platformLayers)
+		var sharedSize, uniqueSize int64
+		for i, layer := range platformLayers {
+			if i < sharedCount {
+				sharedSize += layer.Size
+			} else if len(layer.SharedWith) == 0 {
+				uniqueSize += layer.Size
+			}
+		}
+		prefix := ""
+		if platform != "" {
+			prefix = platform + ": "
+		}
+		switch {
+		case sharedCount == len(platformLayers):
+			suggestions = append(suggestions, fmt.Sprintf("%sAll the layers are shared with %s. The tags are duplicates of the same image.", prefix, baseImage))
+		case sharedCount > 0:
+			suggestions = append(suggestions, fmt.Sprintf("%sThe first %d layers (%s) are shared with %s. Consider building the images which duplicate the other %d layers (%s unique to this image) on top of it as a common base image.",
+				prefix, sharedCount, servicesutils.ConvertIntToStorageSizeString(sharedSize), baseImage, len(platformLayers)-sharedCount, servicesutils.ConvertIntToStorageSizeString(uniqueSize)))
+		case uniqueSize > 0:
+			suggestions = append(suggestions, fmt.Sprintf("%sThe base layers aren't shared with any other tag in the repository. If other images are built from the same base image, pin it to the same digest so its layers are stored once.", prefix))
+		}
+	}
+	return suggestions
+}
+
+// Returns the tag which shares the longest sequence of the first layers, and the length of the sequence.
+func longestSharedPrefix(layers []Layer) (string, int) {
+	candidates := make(map[string]bool)
+	if len(layers) > 0 {
+		for _, image := range layers[0].SharedWith {
+			candidates[image] = true
+		}
+	}
+	baseImage, count := "", 0
+	for i := 0; i < len(layers) && len(candidates) > 0; i++ {
+		next := make(map[string]bool)
+		for _, image := range layers[i].SharedWith {
+			if candidates[image] {
+				next[image] = true
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		candidates, count = next, i+1
+		baseImage = ""
+		for image := range candidates {
+			if baseImage == "" || image < baseImage {
+				baseImage = image
+			}
+		}
+	}
+	return baseImage, count
+}
+
+func printLayers(layers []Layer, suggestions []string, format string) error {
+	if format == JsonFormat {
+		if layers == nil {
+			layers = []Layer{}
+		}
+		content, err := json.MarshalIndent(struct {
+			Layers      []Layer  `json:"layers"`
+			Suggestions []string `json:"suggestions,omitempty"`
+		}{layers, suggestions}, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	var rows []layerRow
+	var totalSize int64
+	index := 0
+	for i, layer := range layers {
+		if i == 0 || layer.Platform != layers[i-1].Platform {
+			index = 0
+		}
+		index++
+		totalSize += layer.Size
+		createdBy := strings.Join(strings.Fields(layer.CreatedBy), " ")
+		if len(createdBy) > maxInstructionLength {
+			createdBy = createdBy[:maxInstructionLength-3] + "..."
+		}
+		rows = append(rows, layerRow{
+			Platform:   layer.Platform,
+			Index:      strconv.Itoa(index),
+			Digest:     shortDigest(layer.Digest),
+			Size:       servicesutils.ConvertIntToStorageSizeString(layer.Size),
+			CreatedBy:  createdBy,
+			SharedWith: strings.Join(layer.SharedWith, "\n"),
+		})
+	}
+	if err := coreutils.PrintTable(rows, "Layers", "The image has no layers", false); err != nil {
+		return err
+	}
+	log.Output("Total size of the layers:", servicesutils.ConvertIntToStorageSizeString(totalSize))
+	for _, suggestion := range suggestions {
+		log.Output(suggestion)
+	}
+	return nil
+}
+
+// Returns the digest with the first 12 characters of its hex.
+func shortDigest(digest string) string {
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	if len(encoded) > 12 {
+		encoded = encoded[:12]
+	}
+	return algorithm + ":" + encoded
+}
@@ -0,0 +1,63 @@
+package oci
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectManifest(t *testing.T) {
+	sourceDir := t.TempDir()
+	writeTestLayout(t, sourceDir)
+	layout := &ociLayout{dir: sourceDir}
+	index, _, err := layout.readIndex()
+	require.NoError(t, err)
+	manifestContent, err := layout.readBlob(index.Manifests[0].Digest)
+	require.NoError(t, err)
+	layers, err := inspectManifest(layout, manifestContent)
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+	assert.Equal(t, Layer{Platform: "linux/amd64", Digest: digestOf([]byte("layer content")), Size: int64(len("layer content")), CreatedBy: "COPY app /app"}, layers[0])
+
+	// The layers of an image in the registry are the same.
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	client := newTestClient(t, server, "app")
+	_, err = pushLayout(client, layout, "1.0", "")
+	require.NoError(t, err)
+	manifestContent, _, err = client.getManifest("1.0")
+	require.NoError(t, err)
+	registryLayers, err := inspectManifest(client, manifestContent)
+	require.NoError(t, err)
+	assert.Equal(t, layers, registryLayers)
+}
+
+func TestSuggestBaseImages(t *testing.T) {
+	layers := []Layer{
+		{Platform: "linux/amd64", Digest: "sha256:1", Size: 100, SharedWith: []string{"docker-local/base:1", "docker-local/other:2"}},
+		{Platform: "linux/amd64", Digest: "sha256:2", Size: 50, SharedWith: []string{"docker-local/other:2"}},
+		{Platform: "linux/amd64", Digest: "sha256:3", Size: 10},
+		{Platform: "linux/arm64", Digest: "sha256:4", Size: 100, SharedWith: []string{"docker-local/base:1"}},
+		{Platform: "linux/arm64", Digest: "sha256:5", Size: 10, SharedWith: []string{"docker-local/base:1"}},
+		{Platform: "linux/arm/v7", Digest: "sha256:6", Size: 100},
+	}
+	baseImage, count := longestSharedPrefix(layers[:3])
+	assert.Equal(t, "docker-local/other:2", baseImage)
+	assert.Equal(t, 2, count)
+
+	suggestions := suggestBaseImages(layers)
+	require.Len(t, suggestions, 3)
+	assert.Contains(t, suggestions[0], "linux/amd64: The first 2 layers")
+	assert.Contains(t, suggestions[0], "docker-local/other:2")
+	assert.Contains(t, suggestions[1], "linux/arm64: All the layers are shared with docker-local/base:1")
+	assert.Contains(t, suggestions[2], "linux/arm/v7: The base layers aren't shared")
+}
+
+func TestFolderToImage(t *testing.T) {
+	assert.Equal(t, "docker-local/org/app:1.0", folderToImage("docker-local", "org/app/1.0"))
+	assert.Equal(t, "docker-local/app@sha256:abc", folderToImage("docker-local", "app/sha256__abc"))
+	assert.Equal(t, "sha256:0123456789ab", shortDigest("sha256:0123456789abcdef"))
+}
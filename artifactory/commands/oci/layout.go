@@ -0,0 +1,241 @@
+package oci
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	indexFileName  = "index.json"
+	layoutFileName = "oci-layout"
+	blobsDirName   = "blobs"
+
+	ociIndexMediaType       = "application/vnd.oci.image.index.v1+json"
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	dockerListMediaType     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// The annotation of the index.json descriptors, which holds the tag of the image.
+	refNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// The media types of manifests, which are accepted when a manifest is pulled.
+var manifestMediaTypes = []string{ociIndexMediaType, ociManifestMediaType, dockerListMediaType, dockerManifestMediaType}
+
+type descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Platform     *json.RawMessage  `json:"platform,omitempty"`
+}
+
+// The fields of image manifests and image indexes which reference other content.
+type manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        *descriptor       `json:"config,omitempty"`
+	Layers        []descriptor      `json:"layers,omitempty"`
+	Manifests     []descriptor      `json:"manifests,omitempty"`
+	Subject       *descriptor       `json:"subject,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+func isIndexMediaType(mediaType string) bool {
+	return mediaType == ociIndexMediaType || mediaType == dockerListMediaType
+}
+
+func parseManifest(content []byte) (*manifest, error) {
+	m := new(manifest)
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the manifest: %s", err.Error())
+	}
+	return m, nil
+}
+
+// Returns the digest of content, in the <algorithm>:<hex> form.
+func digestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// An OCI image layout directory, which stores the blobs by their digests under blobs/<algorithm>/<hex>.
+type ociLayout struct {
+	dir string
+}
+
+func (ol *ociLayout) blobPath(digest string) (string, error) {
+	algorithm, encoded, found := strings.Cut(digest, ":")
+	if !found || algorithm == "" || encoded == "" || strings.ContainsAny(encoded, `/\.`) || strings.ContainsAny(algorithm, `/\.`) {
+		return "", errorutils.CheckErrorf("invalid digest: %s", digest)
+	}
+	return filepath.Join(ol.dir, blobsDirName, algorithm, encoded), nil
+}
+
+func (ol *ociLayout) readIndex() (*manifest, []byte, error) {
+	content, err := os.ReadFile(filepath.Join(ol.dir, indexFileName))
+	if err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to read the OCI layout index: %s", err.Error())
+	}
+	index, err := parseManifest(content)
+	return index, content, err
+}
+
+func (ol *ociLayout) readBlob(digest string) ([]byte, error) {
+	blobPath, err := ol.blobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(blobPath)
+	return content, errorutils.CheckError(err)
+}
+
+func (ol *ociLayout) openBlob(digest string) (*os.File, int64, error) {
+	blobPath, err := ol.blobPath(digest)
+	if err != nil {
+		return nil, 0, err
+	}
+	file, err := os.Open(blobPath)
+	if err != nil {
+		return nil, 0, errorutils.CheckErrorf("the blob %s is missing from the OCI layout: %s", digest, err.Error())
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, errors.Join(errorutils.CheckError(err), file.Close())
+	}
+	return file, info.Size(), nil
+}
+
+// Writes a blob, and verifies that its content matches the digest.
+// The content is written to a temporary file, which is moved to the blob path only once verified,
+// so a failed download never leaves a corrupt blob behind.
+func (ol *ociLayout) writeBlob(digest string, reader io.Reader) (err error) {
+	blobPath, err := ol.blobPath(digest)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(blobPath), filepath.Base(blobPath)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(file.Name())))
+		}
+	}()
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), reader)
+	if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(file.Close())); err != nil {
+		return err
+	}
+	if actual := "sha256:" + hex.EncodeToString(hash.Sum(nil)); actual != digest {
+		return errorutils.CheckErrorf("the digest of the downloaded blob %s doesn't match: %s", digest, actual)
+	}
+	return errorutils.CheckError(os.Rename(file.Name(), blobPath))
+}
+
+// Writes the oci-layout and index.json files, with the index referencing the image.
+func (ol *ociLayout) writeIndex(image descriptor) error {
+	if err := os.WriteFile(filepath.Join(ol.dir, layoutFileName), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	content, err := json.MarshalIndent(manifest{SchemaVersion: 2, MediaType: ociIndexMediaType, Manifests: []descriptor{image}}, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(ol.dir, indexFileName), content, 0644))
+}
+
+// Extracts a tarball of an OCI image layout into the directory.
+func extractLayout(tarballPath, dir string) (err error) {
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(tarball.Close()))
+	}()
+	tarReader := tar.NewReader(tarball)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		targetPath := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(targetPath, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return errorutils.CheckErrorf("illegal file path in the tarball: %s", header.Name)
+		}
+		if err = writeFile(targetPath, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func writeFile(path string, reader io.Reader) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
+
+// Writes the files of the directory into a tarball.
+func archiveLayout(dir, tarballPath string) (err error) {
+	tarball, err := os.Create(tarballPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(tarball.Close()))
+	}()
+	tarWriter := tar.NewWriter(tarball)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if err = tarWriter.WriteHeader(&tar.Header{Name: filepath.ToSlash(relativePath), Mode: 0644, Size: info.Size(), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tarWriter, file)
+		return errors.Join(err, file.Close())
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(tarWriter.Close())
+}
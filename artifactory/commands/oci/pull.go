@@ -0,0 +1,201 @@
+package oci
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pulls an image from an Artifactory Docker repository into an OCI image layout, a directory or a tarball (if the target ends with .tar).
+// The blobs and manifests are downloaded with the registry API, so neither a Docker daemon nor a Docker client is needed.
+type OciPullCommand struct {
+	imageReference     string
+	target             string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewOciPullCommand() *OciPullCommand {
+	return &OciPullCommand{}
+}
+
+func (opc *OciPullCommand) SetImageReference(imageReference string) *OciPullCommand {
+	opc.imageReference = imageReference
+	return opc
+}
+
+func (opc *OciPullCommand) SetTarget(target string) *OciPullCommand {
+	opc.target = target
+	return opc
+}
+
+func (opc *OciPullCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPullCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPullCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *OciPullCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+func (opc *OciPullCommand) CommandName() string {
+	return "rt_oci_pull"
+}
+
+func (opc *OciPullCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPullCommand) Run() (err error) {
+	ref, err := parseImageReference(opc.imageReference)
+	if err != nil {
+		return err
+	}
+	layoutDir := opc.target
+	isTarball := strings.HasSuffix(opc.target, ".tar")
+	if isTarball {
+		if layoutDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(layoutDir))
+		}()
+	} else if err = os.MkdirAll(layoutDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	client, err := newRegistryClient(opc.serverDetails, ref.repo, ref.image)
+	if err != nil {
+		return err
+	}
+	if err = client.authenticate("pull"); err != nil {
+		return err
+	}
+	log.Info("Pulling the image", ref.String(), "to", opc.target)
+	layout := &ociLayout{dir: layoutDir}
+	digests, err := pullImage(client, layout, ref)
+	if err != nil {
+		return err
+	}
+	if isTarball {
+		if err = archiveLayout(layoutDir, opc.target); err != nil {
+			return err
+		}
+	}
+	log.Info("Pulled the image", ref.String())
+	collectBuildInfo, err := opc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	dependencies, err := getDependencies(layout, digests)
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveDependencies(opc.buildConfiguration, ref.moduleId(), ModuleType, dependencies)
+}
+
+// Pulls the manifest of the image reference, with all the content it references, into the layout, and adds it to the index of the layout.
+// Returns the digests of the pulled manifests and blobs.
+func pullImage(client *registryClient, layout *ociLayout, ref *imageReference) ([]string, error) {
+	manifestContent, mediaType, err := client.getManifest(ref.reference)
+	if err != nil {
+		return nil, err
+	}
+	image := descriptor{MediaType: mediaType, Digest: digestOf(manifestContent), Size: int64(len(manifestContent))}
+	if ref.isDigest() && image.Digest != ref.reference {
+		return nil, errorutils.CheckErrorf("the digest of the pulled manifest %s doesn't match: %s", ref.reference, image.Digest)
+	}
+	if !ref.isDigest() {
+		image.Annotations = map[string]string{refNameAnnotation: ref.reference}
+	}
+	digests, err := pullManifest(client, layout, image.Digest, manifestContent)
+	if err != nil {
+		return nil, err
+	}
+	return digests, layout.writeIndex(image)
+}
+
+func pullManifest(client *registryClient, layout *ociLayout, digest string, manifestContent []byte) ([]string, error) {
+	m, err := parseManifest(manifestContent)
+	if err != nil {
+		return nil, err
+	}
+	if err = layout.writeBlob(digest, bytes.NewReader(manifestContent)); err != nil {
+		return nil, err
+	}
+	digests := []string{digest}
+	for _, child := range m.Manifests {
+		childContent, _, err := client.getManifest(child.Digest)
+		if err != nil {
+			return nil, err
+		}
+		childDigests, err := pullManifest(client, layout, child.Digest, childContent)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, childDigests...)
+	}
+	blobs := m.Layers
+	if m.Config != nil {
+		blobs = append([]descriptor{*m.Config}, blobs...)
+	}
+	for _, blob := range blobs {
+		if err = pullBlob(client, layout, blob.Digest); err != nil {
+			return nil, err
+		}
+		digests = append(digests, blob.Digest)
+	}
+	return digests, nil
+}
+
+// Downloads a blob into the layout, unless the layout already has it.
+func pullBlob(client *registryClient, layout *ociLayout, digest string) (err error) {
+	blobPath, err := layout.blobPath(digest)
+	if err != nil {
+		return err
+	}
+	exists, err := fileutils.IsFileExists(blobPath, false)
+	if err != nil || exists {
+		return err
+	}
+	blob, err := client.getBlob(digest)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(blob.Close()))
+	}()
+	log.Info("Pulling the blob", digest)
+	return layout.writeBlob(digest, blob)
+}
+
+// Returns the pulled manifests and blobs as dependencies, with the IDs Artifactory gives them (sha256__<hex>).
+func getDependencies(layout *ociLayout, digests []string) ([]buildinfo.Dependency, error) {
+	var dependencies []buildinfo.Dependency
+	seen := make(map[string]bool)
+	for _, digest := range digests {
+		if seen[digest] {
+			continue
+		}
+		seen[digest] = true
+		blobPath, err := layout.blobPath(digest)
+		if err != nil {
+			return nil, err
+		}
+		details, err := fileutils.GetFileDetails(blobPath, true)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, buildinfo.Dependency{Id: strings.Replace(digest, ":", "__", 1), Checksum: details.Checksum})
+	}
+	return dependencies, nil
+}
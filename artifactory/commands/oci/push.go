@@ -0,0 +1,315 @@
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/imageindex"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const ModuleType = buildinfo.Docker
+
+// Pushes an image from an OCI image layout, a directory or a tarball, to an Artifactory Docker repository.
+// The blobs and manifests are uploaded with the registry API, so neither a Docker daemon nor a Docker client is needed.
+type OciPushCommand struct {
+	source             string
+	imageReference     string
+	mountFrom          string
+	detailedSummary    bool
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	result             *commandsutils.Result
+}
+
+func NewOciPushCommand() *OciPushCommand {
+	return &OciPushCommand{result: new(commandsutils.Result)}
+}
+
+func (opc *OciPushCommand) SetSource(source string) *OciPushCommand {
+	opc.source = source
+	return opc
+}
+
+func (opc *OciPushCommand) SetImageReference(imageReference string) *OciPushCommand {
+	opc.imageReference = imageReference
+	return opc
+}
+
+// Sets the image, in the same repository, from which the registry is requested to mount the blobs instead of uploading them.
+func (opc *OciPushCommand) SetMountFrom(mountFrom string) *OciPushCommand {
+	opc.mountFrom = mountFrom
+	return opc
+}
+
+func (opc *OciPushCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPushCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPushCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *OciPushCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+func (opc *OciPushCommand) SetDetailedSummary(detailedSummary bool) *OciPushCommand {
+	opc.detailedSummary = detailedSummary
+	return opc
+}
+
+func (opc *OciPushCommand) IsDetailedSummary() bool {
+	return opc.detailedSummary
+}
+
+func (opc *OciPushCommand) Result() *commandsutils.Result {
+	return opc.result
+}
+
+func (opc *OciPushCommand) CommandName() string {
+	return "rt_oci_push"
+}
+
+func (opc *OciPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPushCommand) Run() (err error) {
+	ref, err := parseImageReference(opc.imageReference)
+	if err != nil {
+		return err
+	}
+	if ref.isDigest() {
+		return errorutils.CheckErrorf("an image can only be pushed by its tag: %s", opc.imageReference)
+	}
+	layoutDir, err := opc.prepareLayout()
+	if err != nil {
+		return err
+	}
+	if layoutDir != opc.source {
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(layoutDir))
+		}()
+	}
+	client, err := newRegistryClient(opc.serverDetails, ref.repo, ref.image)
+	if err != nil {
+		return err
+	}
+	if err = client.authenticate("pull,push"); err != nil {
+		return err
+	}
+	log.Info("Pushing the image", opc.source, "to", ref.String())
+	childDigests, err := pushLayout(client, &ociLayout{dir: layoutDir}, ref.reference, opc.mountFrom)
+	if err != nil {
+		return err
+	}
+	log.Info("Pushed the image", ref.String())
+	return collectPushedFiles(opc.serverDetails, opc.buildConfiguration, ref, childDigests, opc.detailedSummary, opc.result)
+}
+
+// Returns the directory of the OCI image layout. A tarball is extracted into a temporary directory.
+func (opc *OciPushCommand) prepareLayout() (string, error) {
+	isDir, err := fileutils.IsDirExists(opc.source, false)
+	if err != nil || isDir {
+		return opc.source, err
+	}
+	if _, err = os.Stat(opc.source); err != nil {
+		return "", errorutils.CheckErrorf("the OCI image layout %s doesn't exist: %s", opc.source, err.Error())
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", err
+	}
+	if err = extractLayout(opc.source, tempDir); err != nil {
+		return "", errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}
+	return tempDir, nil
+}
+
+// Pushes the image of the layout and tags it. The image is the manifest or index which the index of the layout annotates with the tag,
+// or its only manifest or index. If the index of the layout references several untagged images, it is pushed itself as an image index and tagged.
+// Returns the digests of the manifests referenced by the tagged index, which Artifactory stores in their own folders.
+func pushLayout(client *registryClient, layout *ociLayout, tag, mountFrom string) (childDigests []string, err error) {
+	index, indexContent, err := layout.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	image, err := selectImage(index, tag)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("%s in the OCI image layout %s", err.Error(), layout.dir)
+	}
+	var tagged []byte
+	var mediaType string
+	if image != nil {
+		if tagged, err = layout.readBlob(image.Digest); err != nil {
+			return nil, err
+		}
+		mediaType = image.MediaType
+	} else {
+		tagged, mediaType = indexContent, ociIndexMediaType
+	}
+	taggedManifest, err := parseManifest(tagged)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType == "" {
+		mediaType = taggedManifest.MediaType
+	}
+	if childDigests, err = pushReferences(client, layout, taggedManifest, mountFrom); err != nil {
+		return nil, err
+	}
+	_, err = client.putManifest(tag, mediaType, tagged)
+	return childDigests, err
+}
+
+// Returns the descriptor of the index of a layout which is annotated with the tag, or its only descriptor.
+// Returns nil if the index references several untagged images, which are pushed together as an image index.
+func selectImage(index *manifest, tag string) (*descriptor, error) {
+	if len(index.Manifests) == 0 {
+		return nil, errors.New("no image was found")
+	}
+	var tags []string
+	for i, image := range index.Manifests {
+		// The annotation is either the tag, or a full image reference which ends with the tag.
+		refName := image.Annotations[refNameAnnotation]
+		if refName == tag || strings.HasSuffix(refName, ":"+tag) {
+			return &index.Manifests[i], nil
+		}
+		if refName != "" {
+			tags = append(tags, refName)
+		}
+	}
+	switch {
+	case len(index.Manifests) == 1:
+		return &index.Manifests[0], nil
+	case len(tags) > 0:
+		return nil, fmt.Errorf("no image is tagged '%s'. The tagged images are: %s", tag, strings.Join(tags, ", "))
+	default:
+		return nil, nil
+	}
+}
+
+// Pushes a manifest by its digest, after the content it references.
+func pushManifest(client *registryClient, source imageContent, manifestDescriptor descriptor, mountFrom string) error {
+	manifestContent, err := source.readManifest(manifestDescriptor.Digest)
+	if err != nil {
+		return err
+	}
+	m, err := parseManifest(manifestContent)
+	if err != nil {
+		return err
+	}
+	if _, err = pushReferences(client, source, m, mountFrom); err != nil {
+		return err
+	}
+	mediaType := manifestDescriptor.MediaType
+	if mediaType == "" {
+		mediaType = m.MediaType
+	}
+	_, err = client.putManifest(manifestDescriptor.Digest, mediaType, manifestContent)
+	return err
+}
+
+// Pushes the content which a manifest references: the manifests of an image index, or the config and the layers of an image manifest.
+// Returns the digests of the manifests of the index.
+func pushReferences(client *registryClient, source imageContent, m *manifest, mountFrom string) ([]string, error) {
+	var childDigests []string
+	for _, child := range m.Manifests {
+		if err := pushManifest(client, source, child, mountFrom); err != nil {
+			return nil, err
+		}
+		childDigests = append(childDigests, child.Digest)
+	}
+	blobs := m.Layers
+	if m.Config != nil {
+		blobs = append([]descriptor{*m.Config}, blobs...)
+	}
+	for _, blob := range blobs {
+		if err := pushBlob(client, source, blob, mountFrom); err != nil {
+			return nil, err
+		}
+	}
+	return childDigests, nil
+}
+
+// Streams a blob from the source to the registry, unless the registry already has it.
+func pushBlob(client *registryClient, source imageContent, blob descriptor, mountFrom string) (err error) {
+	exists, err := client.blobExists(blob.Digest)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Debug("The blob", blob.Digest, "already exists in the registry")
+		return nil
+	}
+	content, err := source.getBlob(blob.Digest)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(content.Close()))
+	}()
+	uploaded, err := client.pushBlob(blob.Digest, blob.Size, content, mountFrom)
+	if err != nil {
+		return err
+	}
+	if uploaded {
+		log.Info("Pushed the blob", blob.Digest)
+	}
+	return nil
+}
+
+// Searches the files which Artifactory stored for the pushed image, for the summary and for the build-info.
+// An image index is recorded as a module of the index and a module of each platform, as the docker commands record it.
+func collectPushedFiles(serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration, ref *imageReference, childDigests []string,
+	detailedSummary bool, result *commandsutils.Result) error {
+	var modules []imageindex.Module
+	var err error
+	if len(childDigests) > 0 {
+		modules, err = imageindex.GetModules(serverDetails, buildConfiguration, ref.repo, ref.image+"/"+ref.reference, ref.moduleId())
+	} else {
+		var artifacts []buildinfo.Artifact
+		artifacts, err = buildinfoutils.GetDeployedArtifacts(serverDetails, buildConfiguration, ref.repo, ref.image+"/"+ref.reference+"/*")
+		modules = []imageindex.Module{{Id: ref.moduleId(), Artifacts: artifacts}}
+	}
+	if err != nil {
+		return err
+	}
+	var transferDetails []clientutils.FileTransferDetails
+	for _, module := range modules {
+		for _, artifact := range module.Artifacts {
+			transferDetails = append(transferDetails, clientutils.FileTransferDetails{
+				TargetPath: path.Join(artifact.OriginalDeploymentRepo, artifact.Path),
+				RtUrl:      serverDetails.GetArtifactoryUrl(),
+				Sha256:     artifact.Sha256,
+			})
+		}
+	}
+	result.SetSuccessCount(len(transferDetails))
+	if detailedSummary {
+		tempFile, err := clientutils.SaveFileTransferDetailsInTempFile(&transferDetails)
+		if err != nil {
+			return err
+		}
+		result.SetReader(content.NewContentReader(tempFile, "files"))
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	if len(childDigests) > 0 {
+		return imageindex.SaveModules(buildConfiguration, ref.String(), modules)
+	}
+	return buildinfoutils.SaveArtifacts(buildConfiguration, ref.moduleId(), ModuleType, modules[0].Artifacts)
+}
@@ -0,0 +1,53 @@
+package oci
+
+import (
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// An image reference in Artifactory: <repository>/<image>:<tag> or <repository>/<image>@<digest>.
+type imageReference struct {
+	repo  string
+	image string
+	// The tag or the digest of the image.
+	reference string
+}
+
+func parseImageReference(ref string) (*imageReference, error) {
+	repo, nameAndReference, found := strings.Cut(ref, "/")
+	if !found || repo == "" {
+		return nil, errorutils.CheckErrorf("the image reference %s must start with the repository: <repository>/<image>:<tag>", ref)
+	}
+	image, reference := nameAndReference, ""
+	if name, digest, found := strings.Cut(nameAndReference, "@"); found {
+		image, reference = name, digest
+	} else if index := strings.LastIndex(nameAndReference, ":"); index > strings.LastIndex(nameAndReference, "/") {
+		image, reference = nameAndReference[:index], nameAndReference[index+1:]
+	}
+	if image == "" || reference == "" {
+		return nil, errorutils.CheckErrorf("the image reference %s must include the image and its tag: <repository>/<image>:<tag>", ref)
+	}
+	return &imageReference{repo: repo, image: image, reference: reference}, nil
+}
+
+func (ir *imageReference) isDigest() bool {
+	return strings.Contains(ir.reference, ":")
+}
+
+func (ir *imageReference) String() string {
+	if ir.isDigest() {
+		return ir.repo + "/" + ir.image + "@" + ir.reference
+	}
+	return ir.repo + "/" + ir.image + ":" + ir.reference
+}
+
+// Returns the default build-info module ID of the image, which is the image name without its path, as the docker commands record it:
+// <name>:<tag>, or <name>@<digest>.
+func (ir *imageReference) moduleId() string {
+	if ir.isDigest() {
+		return path.Base(ir.image) + "@" + ir.reference
+	}
+	return path.Base(ir.image) + ":" + ir.reference
+}
@@ -0,0 +1,139 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const createdAnnotation = "org.opencontainers.image.created"
+
+// Returns the tag of the referrers index of a manifest, which is used with registries that don't support the referrers API: sha256-<hex>.
+func referrersTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1)
+}
+
+// Returns the descriptor of a manifest in the registry, by its tag or its digest.
+func resolveSubject(client *registryClient, reference string) (descriptor, error) {
+	content, mediaType, err := client.getManifest(reference)
+	if err != nil {
+		return descriptor{}, err
+	}
+	return descriptor{MediaType: mediaType, Digest: digestOf(content), Size: int64(len(content))}, nil
+}
+
+// Pushes an artifact which refers to the subject manifest: an OCI manifest with an empty config, whose single layer is the content.
+// If the registry doesn't support the referrers API, the artifact is added to the referrers index of the subject.
+func pushReferrer(client *registryClient, subject descriptor, artifactType string, layer descriptor, layerContent []byte) (descriptor, error) {
+	config := descriptor{MediaType: emptyConfigMediaType, Digest: digestOf([]byte(emptyConfigContent)), Size: int64(len(emptyConfigContent))}
+	if _, err := client.pushBlob(config.Digest, config.Size, strings.NewReader(emptyConfigContent), ""); err != nil {
+		return descriptor{}, err
+	}
+	if _, err := client.pushBlob(layer.Digest, layer.Size, bytes.NewReader(layerContent), ""); err != nil {
+		return descriptor{}, err
+	}
+	content, err := json.Marshal(manifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  artifactType,
+		Config:        &config,
+		Layers:        []descriptor{layer},
+		Subject:       &subject,
+		Annotations:   map[string]string{createdAnnotation: time.Now().UTC().Format(time.RFC3339)},
+	})
+	if err != nil {
+		return descriptor{}, errorutils.CheckError(err)
+	}
+	referrer := descriptor{MediaType: ociManifestMediaType, Digest: digestOf(content), Size: int64(len(content)), ArtifactType: artifactType}
+	header, err := client.putManifest(referrer.Digest, ociManifestMediaType, content)
+	if err != nil {
+		return descriptor{}, err
+	}
+	if header.Get("OCI-Subject") != "" {
+		return referrer, nil
+	}
+	log.Debug("The registry doesn't support the referrers API. Adding the referrer to the", referrersTag(subject.Digest), "tag")
+	return referrer, updateReferrersTag(client, subject.Digest, referrer)
+}
+
+func updateReferrersTag(client *registryClient, subjectDigest string, referrer descriptor) error {
+	content, _, found, err := client.findManifest(referrersTag(subjectDigest))
+	if err != nil {
+		return err
+	}
+	index := &manifest{SchemaVersion: 2, MediaType: ociIndexMediaType}
+	if found {
+		if index, err = parseManifest(content); err != nil {
+			return err
+		}
+	}
+	index.Manifests = append(index.Manifests, referrer)
+	if content, err = json.Marshal(index); err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = client.putManifest(referrersTag(subjectDigest), ociIndexMediaType, content)
+	return err
+}
+
+// Returns the artifacts of the type, which refer to the subject manifest.
+// If the registry doesn't support the referrers API, they are read from the referrers index of the subject.
+func (rc *registryClient) getReferrers(subjectDigest, artifactType string) (referrers []descriptor, err error) {
+	resp, err := rc.send(http.MethodGet, rc.imageUrl("referrers/"+subjectDigest)+"?"+url.Values{"artifactType": {artifactType}}.Encode(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	var content []byte
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if content, err = io.ReadAll(resp.Body); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	case http.StatusNotFound:
+		var found bool
+		if content, _, found, err = rc.findManifest(referrersTag(subjectDigest)); err != nil || !found {
+			return nil, err
+		}
+	default:
+		return nil, checkResponse(resp, http.StatusOK)
+	}
+	index, err := parseManifest(content)
+	if err != nil {
+		return nil, err
+	}
+	// The registry may ignore the artifact type filter.
+	for _, referrer := range index.Manifests {
+		if referrer.ArtifactType == artifactType {
+			referrers = append(referrers, referrer)
+		}
+	}
+	return referrers, nil
+}
+
+// Returns the content of a blob, after verifying its digest.
+func (rc *registryClient) readBlob(digest string) (content []byte, err error) {
+	blob, err := rc.getBlob(digest)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(blob.Close()))
+	}()
+	if content, err = io.ReadAll(blob); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if digestOf(content) != digest {
+		return nil, errorutils.CheckErrorf("the digest of the blob %s doesn't match its content", digest)
+	}
+	return content, nil
+}
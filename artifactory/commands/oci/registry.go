@@ -0,0 +1,308 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A client of the Docker Registry HTTP API V2 (the OCI distribution API), of a single image.
+type registryClient struct {
+	// The URL of the API root, which ends with /v2.
+	baseUrl  string
+	image    string
+	username string
+	password string
+	// The token returned by the token service of the registry, if the registry requested one.
+	token      string
+	httpClient *http.Client
+}
+
+// Returns a client of the Docker registry API of an Artifactory repository.
+func newRegistryClient(serverDetails *config.ServerDetails, repo, image string) (*registryClient, error) {
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	// The client trusts the certificates of the JFrog CLI certificates directory, and presents the client certificate of the server, as the Artifactory client does.
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	jfrogHttpClient, err := httpclient.ClientBuilder().
+		SetCertificatesPath(certsPath).
+		SetInsecureTls(serverDetails.InsecureTls).
+		SetClientCertPath(serverDetails.GetClientCertPath()).
+		SetClientCertKeyPath(serverDetails.GetClientCertKeyPath()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return &registryClient{
+		baseUrl:    strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/docker/" + repo + "/v2",
+		image:      image,
+		username:   username,
+		password:   password,
+		httpClient: jfrogHttpClient.GetClient(),
+	}, nil
+}
+
+// Authenticates with the registry. If the registry responds with a Bearer challenge, a token of the requested actions
+// (pull, or pull,push) is requested from its token service. Otherwise, the requests are sent with basic authentication.
+func (rc *registryClient) authenticate(actions string) (err error) {
+	resp, err := rc.send(http.MethodGet, rc.baseUrl+"/", nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+	scheme, params := parseChallenge(resp.Header.Get("Www-Authenticate"))
+	if !strings.EqualFold(scheme, "Bearer") {
+		return nil
+	}
+	return rc.requestToken(params, actions)
+}
+
+func (rc *registryClient) requestToken(params map[string]string, actions string) (err error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return errorutils.CheckErrorf("the registry returned an invalid authentication realm: %s", params["realm"])
+	}
+	query := realm.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", "repository:"+rc.image+":"+actions)
+	realm.RawQuery = query.Encode()
+	resp, err := rc.send(http.MethodGet, realm.String(), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	if err = checkResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return errorutils.CheckErrorf("failed to parse the response of the registry token service: %s", err.Error())
+	}
+	if rc.token = tokenResponse.Token; rc.token == "" {
+		rc.token = tokenResponse.AccessToken
+	}
+	return nil
+}
+
+// Parses a WWW-Authenticate header, such as: Bearer realm="https://host/token",service="host",scope="...".
+func parseChallenge(header string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params = make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(strings.TrimSpace(rest), ",") {
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key], rest = value[1:end+1], value[end+2:]
+		} else {
+			params[key], rest, _ = strings.Cut(value, ",")
+		}
+	}
+	return scheme, params
+}
+
+func (rc *registryClient) send(method, requestUrl string, header http.Header, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, requestUrl, body)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	return rc.do(req)
+}
+
+// Sends the request with the credentials, or with the token returned by the token service.
+func (rc *registryClient) do(req *http.Request) (*http.Response, error) {
+	switch {
+	case rc.token != "":
+		req.Header.Set("Authorization", "Bearer "+rc.token)
+	case rc.username != "" || rc.password != "":
+		req.SetBasicAuth(rc.username, rc.password)
+	}
+	log.Debug("Sending", req.Method, "request to", req.URL.Redacted())
+	resp, err := rc.httpClient.Do(req)
+	return resp, errorutils.CheckError(err)
+}
+
+// Returns an error, including the errors returned by the registry, if the status code of the response isn't one of the expected.
+func checkResponse(resp *http.Response, expectedStatusCodes ...int) error {
+	for _, statusCode := range expectedStatusCodes {
+		if resp.StatusCode == statusCode {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return errorutils.CheckErrorf("the registry responded to %s %s with %s: %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+}
+
+func (rc *registryClient) imageUrl(suffix string) string {
+	return rc.baseUrl + "/" + rc.image + "/" + suffix
+}
+
+func (rc *registryClient) blobExists(digest string) (exists bool, err error) {
+	resp, err := rc.send(http.MethodHead, rc.imageUrl("blobs/"+digest), nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return true, checkResponse(resp, http.StatusOK)
+}
+
+// Starts an upload of a blob. If mountFrom is set, the registry is first requested to mount the blob from that image,
+// in which case no upload is needed and an empty location is returned.
+func (rc *registryClient) startUpload(digest, mountFrom string) (location string, err error) {
+	uploadUrl := rc.imageUrl("blobs/uploads/")
+	if mountFrom != "" {
+		uploadUrl += "?" + url.Values{"mount": {digest}, "from": {mountFrom}}.Encode()
+	}
+	resp, err := rc.send(http.MethodPost, uploadUrl, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	if err = checkResponse(resp, http.StatusCreated, http.StatusAccepted); err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusCreated {
+		log.Debug("Mounted the blob", digest, "from", mountFrom)
+		return "", nil
+	}
+	locationUrl, err := resp.Location()
+	if err != nil {
+		return "", errorutils.CheckErrorf("the registry didn't return the location of the upload of %s: %s", digest, err.Error())
+	}
+	return locationUrl.String(), nil
+}
+
+// Uploads a blob to the image, unless the registry already has it.
+// Returns true if the blob was uploaded, and false if the registry already had it.
+func (rc *registryClient) pushBlob(digest string, size int64, content io.Reader, mountFrom string) (uploaded bool, err error) {
+	exists, err := rc.blobExists(digest)
+	if err != nil || exists {
+		return false, err
+	}
+	location, err := rc.startUpload(digest, mountFrom)
+	if err != nil || location == "" {
+		return false, err
+	}
+	uploadUrl, err := url.Parse(location)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	query := uploadUrl.Query()
+	query.Set("digest", digest)
+	uploadUrl.RawQuery = query.Encode()
+	// The caller owns the content, so it isn't closed by the HTTP client.
+	req, err := http.NewRequest(http.MethodPut, uploadUrl.String(), io.NopCloser(content))
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := rc.do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	return true, checkResponse(resp, http.StatusCreated)
+}
+
+// Puts a manifest or an index, by its tag or its digest. Returns the headers of the response.
+func (rc *registryClient) putManifest(reference, mediaType string, content []byte) (header http.Header, err error) {
+	resp, err := rc.send(http.MethodPut, rc.imageUrl("manifests/"+reference), http.Header{"Content-Type": {mediaType}}, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	return resp.Header, checkResponse(resp, http.StatusCreated, http.StatusOK)
+}
+
+// Returns a manifest or an index, by its tag or its digest, and its media type.
+func (rc *registryClient) getManifest(reference string) (content []byte, mediaType string, err error) {
+	content, mediaType, found, err := rc.findManifest(reference)
+	if err == nil && !found {
+		err = errorutils.CheckErrorf("the manifest %s of %s doesn't exist in the registry", reference, rc.image)
+	}
+	return content, mediaType, err
+}
+
+// Returns a manifest or an index, by its tag or its digest, and its media type. Returns false if it doesn't exist.
+func (rc *registryClient) findManifest(reference string) (content []byte, mediaType string, found bool, err error) {
+	resp, err := rc.send(http.MethodGet, rc.imageUrl("manifests/"+reference), http.Header{"Accept": {strings.Join(manifestMediaTypes, ", ")}}, nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", false, nil
+	}
+	if err = checkResponse(resp, http.StatusOK); err != nil {
+		return nil, "", false, err
+	}
+	if content, err = io.ReadAll(resp.Body); err != nil {
+		return nil, "", false, errorutils.CheckError(err)
+	}
+	mediaType, _, _ = strings.Cut(resp.Header.Get("Content-Type"), ";")
+	return content, strings.TrimSpace(mediaType), true, nil
+}
+
+// Returns the content of a blob. The caller is responsible for closing it.
+func (rc *registryClient) getBlob(digest string) (io.ReadCloser, error) {
+	resp, err := rc.send(http.MethodGet, rc.imageUrl("blobs/"+digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkResponse(resp, http.StatusOK); err != nil {
+		return nil, errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,359 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A registry stand-in, which serves the registry API of the 'docker-local' repository under /artifactory/api/docker/docker-local/v2.
+type testRegistry struct {
+	mu sync.Mutex
+	// The blobs by <image>@<digest>.
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	types     map[string]string
+	uploads   int
+	mounts    int
+}
+
+func newTestRegistry() *testRegistry {
+	return &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (tr *testRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if r.URL.Path == "/token" {
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"test-token"}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.Header().Set("Www-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	apiPath := strings.TrimPrefix(r.URL.Path, "/artifactory/api/docker/docker-local/v2/")
+	switch {
+	case apiPath == "":
+		w.WriteHeader(http.StatusOK)
+	case strings.HasSuffix(apiPath, "/blobs/uploads/") && r.Method == http.MethodPost:
+		image := strings.TrimSuffix(apiPath, "/blobs/uploads/")
+		if digest, from := r.URL.Query().Get("mount"), r.URL.Query().Get("from"); digest != "" && tr.blobs[from+"@"+digest] != nil {
+			tr.blobs[image+"@"+digest] = tr.blobs[from+"@"+digest]
+			tr.mounts++
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.Header().Set("Location", "/artifactory/api/docker/docker-local/v2/"+strings.TrimSuffix(apiPath, "/")+"/upload-id")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasSuffix(apiPath, "/blobs/uploads/upload-id") && r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if digestOf(content) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr.blobs[strings.TrimSuffix(apiPath, "/blobs/uploads/upload-id")+"@"+digest] = content
+		tr.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(apiPath, "/blobs/"):
+		image, digest, _ := strings.Cut(apiPath, "/blobs/")
+		content, exists := tr.blobs[image+"@"+digest]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	case strings.Contains(apiPath, "/manifests/") && r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		tr.manifests[apiPath] = content
+		tr.types[apiPath] = r.Header.Get("Content-Type")
+		tr.manifests[apiPath[:strings.LastIndex(apiPath, "/")+1]+digestOf(content)] = content
+		tr.types[apiPath[:strings.LastIndex(apiPath, "/")+1]+digestOf(content)] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(apiPath, "/manifests/") && r.Method == http.MethodGet:
+		content, exists := tr.manifests[apiPath]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", tr.types[apiPath])
+		_, _ = w.Write(content)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, image string) *registryClient {
+	client, err := newRegistryClient(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/", User: "admin", Password: "password"}, "docker-local", image)
+	require.NoError(t, err)
+	require.NoError(t, client.authenticate("pull,push"))
+	assert.Equal(t, "test-token", client.token)
+	return client
+}
+
+// Writes an OCI image layout of a single-layer image, and returns the digest of its manifest.
+func writeTestLayout(t *testing.T, dir string) string {
+	layout := &ociLayout{dir: dir}
+	writeBlob := func(content []byte) descriptor {
+		digest := digestOf(content)
+		require.NoError(t, layout.writeBlob(digest, bytes.NewReader(content)))
+		return descriptor{Digest: digest, Size: int64(len(content))}
+	}
+	configDescriptor := writeBlob([]byte(`{"architecture":"amd64","os":"linux","history":[{"created_by":"ENV PORT=80","empty_layer":true},{"created_by":"COPY app /app"}]}`))
+	configDescriptor.MediaType = "application/vnd.oci.image.config.v1+json"
+	layerDescriptor := writeBlob([]byte("layer content"))
+	layerDescriptor.MediaType = "application/vnd.oci.image.layer.v1.tar+gzip"
+	manifestContent, err := json.Marshal(manifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Config: &configDescriptor, Layers: []descriptor{layerDescriptor}})
+	require.NoError(t, err)
+	manifestDescriptor := writeBlob(manifestContent)
+	manifestDescriptor.MediaType = ociManifestMediaType
+	require.NoError(t, layout.writeIndex(manifestDescriptor))
+	return manifestDescriptor.Digest
+}
+
+func TestPushAndPull(t *testing.T) {
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	sourceDir := t.TempDir()
+	manifestDigest := writeTestLayout(t, sourceDir)
+	childDigests, err := pushLayout(newTestClient(t, server, "org/app"), &ociLayout{dir: sourceDir}, "1.0", "")
+	require.NoError(t, err)
+	assert.Empty(t, childDigests)
+	assert.Equal(t, 2, registry.uploads)
+	assert.Equal(t, ociManifestMediaType, registry.types["org/app/manifests/1.0"])
+
+	// Pushing again doesn't upload the existing blobs, and pushing to another image mounts them.
+	_, err = pushLayout(newTestClient(t, server, "org/app"), &ociLayout{dir: sourceDir}, "1.1", "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, registry.uploads)
+	_, err = pushLayout(newTestClient(t, server, "org/other"), &ociLayout{dir: sourceDir}, "1.0", "org/app")
+	require.NoError(t, err)
+	assert.Equal(t, 2, registry.mounts)
+
+	targetDir := t.TempDir()
+	ref, err := parseImageReference("docker-local/org/app:1.0")
+	require.NoError(t, err)
+	digests, err := pullImage(newTestClient(t, server, "org/app"), &ociLayout{dir: targetDir}, ref)
+	require.NoError(t, err)
+	assert.Len(t, digests, 3)
+	assert.Equal(t, manifestDigest, digests[0])
+	for _, digest := range digests {
+		blobPath, err := (&ociLayout{dir: targetDir}).blobPath(digest)
+		require.NoError(t, err)
+		assert.FileExists(t, blobPath)
+	}
+	index, _, err := (&ociLayout{dir: targetDir}).readIndex()
+	require.NoError(t, err)
+	require.Len(t, index.Manifests, 1)
+	assert.Equal(t, manifestDigest, index.Manifests[0].Digest)
+	assert.Equal(t, "1.0", index.Manifests[0].Annotations[refNameAnnotation])
+
+	dependencies, err := getDependencies(&ociLayout{dir: targetDir}, digests)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 3)
+	assert.Equal(t, strings.Replace(manifestDigest, ":", "__", 1), dependencies[0].Id)
+	assert.Equal(t, strings.TrimPrefix(manifestDigest, "sha256:"), dependencies[0].Sha256)
+}
+
+func TestPushIndexOfLayout(t *testing.T) {
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	// A layout with two images is pushed as an image index.
+	sourceDir := t.TempDir()
+	manifestDigest := writeTestLayout(t, sourceDir)
+	layout := &ociLayout{dir: sourceDir}
+	index, _, err := layout.readIndex()
+	require.NoError(t, err)
+	otherConfig := []byte(`{"architecture":"arm64","os":"linux"}`)
+	require.NoError(t, layout.writeBlob(digestOf(otherConfig), bytes.NewReader(otherConfig)))
+	otherManifest, err := json.Marshal(manifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Config: &descriptor{Digest: digestOf(otherConfig), Size: int64(len(otherConfig))}})
+	require.NoError(t, err)
+	require.NoError(t, layout.writeBlob(digestOf(otherManifest), bytes.NewReader(otherManifest)))
+	index.Manifests = append(index.Manifests, descriptor{MediaType: ociManifestMediaType, Digest: digestOf(otherManifest), Size: int64(len(otherManifest))})
+	indexContent, err := json.Marshal(index)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, indexFileName), indexContent, 0644))
+
+	childDigests, err := pushLayout(newTestClient(t, server, "app"), layout, "latest", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{manifestDigest, digestOf(otherManifest)}, childDigests)
+	assert.Equal(t, ociIndexMediaType, registry.types["app/manifests/latest"])
+	assert.Contains(t, registry.manifests, "app/manifests/"+manifestDigest)
+}
+
+func TestPushTaggedImageOfLayout(t *testing.T) {
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	// A layout with two tagged images is pushed by the tag of one of them.
+	sourceDir := t.TempDir()
+	writeTestLayout(t, sourceDir)
+	layout := &ociLayout{dir: sourceDir}
+	index, _, err := layout.readIndex()
+	require.NoError(t, err)
+	otherConfig := []byte(`{"architecture":"arm64","os":"linux"}`)
+	require.NoError(t, layout.writeBlob(digestOf(otherConfig), bytes.NewReader(otherConfig)))
+	otherManifest, err := json.Marshal(manifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Config: &descriptor{Digest: digestOf(otherConfig), Size: int64(len(otherConfig))}})
+	require.NoError(t, err)
+	require.NoError(t, layout.writeBlob(digestOf(otherManifest), bytes.NewReader(otherManifest)))
+	index.Manifests[0].Annotations = map[string]string{refNameAnnotation: "1.0"}
+	index.Manifests = append(index.Manifests, descriptor{MediaType: ociManifestMediaType, Digest: digestOf(otherManifest), Size: int64(len(otherManifest)),
+		Annotations: map[string]string{refNameAnnotation: "docker.io/org/app:2.0"}})
+	indexContent, err := json.Marshal(index)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, indexFileName), indexContent, 0644))
+
+	childDigests, err := pushLayout(newTestClient(t, server, "app"), layout, "2.0", "")
+	require.NoError(t, err)
+	assert.Empty(t, childDigests)
+	assert.Equal(t, otherManifest, registry.manifests["app/manifests/2.0"])
+	assert.Equal(t, ociManifestMediaType, registry.types["app/manifests/2.0"])
+
+	_, err = pushLayout(newTestClient(t, server, "app"), layout, "3.0", "")
+	assert.ErrorContains(t, err, "no image is tagged '3.0'")
+}
+
+func TestStartUploadWithoutLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	client, err := newRegistryClient(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, "docker-local", "app")
+	require.NoError(t, err)
+	_, err = client.startUpload("sha256:1234", "")
+	assert.ErrorContains(t, err, "didn't return the location of the upload")
+}
+
+func TestCopyImage(t *testing.T) {
+	sourceRegistry, targetRegistry := newTestRegistry(), newTestRegistry()
+	sourceServer, targetServer := httptest.NewServer(sourceRegistry), httptest.NewServer(targetRegistry)
+	defer sourceServer.Close()
+	defer targetServer.Close()
+
+	sourceDir := t.TempDir()
+	manifestDigest := writeTestLayout(t, sourceDir)
+	_, err := pushLayout(newTestClient(t, sourceServer, "org/app"), &ociLayout{dir: sourceDir}, "1.0", "")
+	require.NoError(t, err)
+	ref, err := parseImageReference("docker-local/org/app:1.0")
+	require.NoError(t, err)
+	childDigests, err := copyImage(newTestClient(t, sourceServer, "org/app"), newTestClient(t, targetServer, "app"), ref, "2.0")
+	require.NoError(t, err)
+	assert.Empty(t, childDigests)
+	assert.Equal(t, 2, targetRegistry.uploads)
+	assert.Equal(t, sourceRegistry.manifests["org/app/manifests/1.0"], targetRegistry.manifests["app/manifests/2.0"])
+	assert.Equal(t, ociManifestMediaType, targetRegistry.types["app/manifests/2.0"])
+
+	// Copying the image by its digest to another tag doesn't upload the existing blobs.
+	ref, err = parseImageReference("docker-local/org/app@" + manifestDigest)
+	require.NoError(t, err)
+	_, err = copyImage(newTestClient(t, sourceServer, "org/app"), newTestClient(t, targetServer, "app"), ref, "latest")
+	require.NoError(t, err)
+	assert.Equal(t, 2, targetRegistry.uploads)
+	assert.Contains(t, targetRegistry.manifests, "app/manifests/latest")
+}
+
+func TestPullBlobAfterDigestMismatch(t *testing.T) {
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	content := []byte("layer content")
+	digest := digestOf(content)
+	// The registry first serves a corrupt blob.
+	registry.blobs["app@"+digest] = []byte("corrupt content")
+
+	client := newTestClient(t, server, "app")
+	layout := &ociLayout{dir: t.TempDir()}
+	assert.ErrorContains(t, pullBlob(client, layout, digest), "doesn't match")
+	blobPath, err := layout.blobPath(digest)
+	require.NoError(t, err)
+	assert.NoFileExists(t, blobPath)
+	entries, err := os.ReadDir(filepath.Dir(blobPath))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Retrying once the registry serves the right blob repairs the layout.
+	registry.blobs["app@"+digest] = content
+	require.NoError(t, pullBlob(client, layout, digest))
+	actual, err := layout.readBlob(digest)
+	require.NoError(t, err)
+	assert.Equal(t, content, actual)
+}
+
+func TestLayoutTarball(t *testing.T) {
+	sourceDir := t.TempDir()
+	manifestDigest := writeTestLayout(t, sourceDir)
+	tarballPath := filepath.Join(t.TempDir(), "image.tar")
+	require.NoError(t, archiveLayout(sourceDir, tarballPath))
+	targetDir := t.TempDir()
+	require.NoError(t, extractLayout(tarballPath, targetDir))
+	index, _, err := (&ociLayout{dir: targetDir}).readIndex()
+	require.NoError(t, err)
+	require.Len(t, index.Manifests, 1)
+	assert.Equal(t, manifestDigest, index.Manifests[0].Digest)
+	assert.FileExists(t, filepath.Join(targetDir, layoutFileName))
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		ref       string
+		expected  *imageReference
+		moduleId  string
+		expectErr bool
+	}{
+		{"docker-local/app:1.0", &imageReference{repo: "docker-local", image: "app", reference: "1.0"}, "app:1.0", false},
+		{"docker-local/org/app:1.0", &imageReference{repo: "docker-local", image: "org/app", reference: "1.0"}, "app:1.0", false},
+		{"docker-local/app@sha256:abc", &imageReference{repo: "docker-local", image: "app", reference: "sha256:abc"}, "app@sha256:abc", false},
+		{"docker-local/app", nil, "", true},
+		{"app:1.0", nil, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			ref, err := parseImageReference(test.ref)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ref)
+			assert.Equal(t, test.ref, ref.String())
+			assert.Equal(t, test.moduleId, ref.moduleId())
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/token",service="acme.jfrog.io",scope="repository:app:pull"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/token",
+		"service": "acme.jfrog.io",
+		"scope":   "repository:app:pull",
+	}, params)
+	scheme, params = parseChallenge(`Basic realm="Artifactory Realm"`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "Artifactory Realm"}, params)
+}
@@ -0,0 +1,403 @@
+package oci
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Signs an image in an Artifactory Docker repository with a local private key.
+// The cosign-compatible signature is pushed to the repository as an OCI referrer of the image manifest.
+type SignCommand struct {
+	imageReference     string
+	keyPath            string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewSignCommand() *SignCommand {
+	return &SignCommand{}
+}
+
+func (sc *SignCommand) SetImageReference(imageReference string) *SignCommand {
+	sc.imageReference = imageReference
+	return sc
+}
+
+func (sc *SignCommand) SetKeyPath(keyPath string) *SignCommand {
+	sc.keyPath = keyPath
+	return sc
+}
+
+func (sc *SignCommand) SetServerDetails(serverDetails *config.ServerDetails) *SignCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *SignCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *SignCommand {
+	sc.buildConfiguration = buildConfiguration
+	return sc
+}
+
+func (sc *SignCommand) CommandName() string {
+	return "rt_docker_sign"
+}
+
+func (sc *SignCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SignCommand) Run() error {
+	signer, err := loadPrivateKey(sc.keyPath, []byte(os.Getenv(KeyPasswordEnv)))
+	if err != nil {
+		return err
+	}
+	ref, client, subject, err := connectToSubject(sc.serverDetails, sc.imageReference, "pull,push")
+	if err != nil {
+		return err
+	}
+	payload, err := newSimpleSigningPayload(ref.repo+"/"+ref.image, subject.Digest)
+	if err != nil {
+		return err
+	}
+	signature, err := sign(signer, payload)
+	if err != nil {
+		return err
+	}
+	layer := descriptor{
+		MediaType:   simpleSigningMediaType,
+		Digest:      digestOf(payload),
+		Size:        int64(len(payload)),
+		Annotations: map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	}
+	log.Info("Signing the image", ref.String(), "("+subject.Digest+")")
+	referrer, err := pushReferrer(client, subject, signatureArtifactType, layer, payload)
+	if err != nil {
+		return err
+	}
+	log.Info("Pushed the signature", referrer.Digest)
+	return saveReferrerArtifacts(sc.serverDetails, sc.buildConfiguration, ref, referrer)
+}
+
+// Attaches a signed in-toto attestation to an image in an Artifactory Docker repository.
+// The statement about the image is signed with a local private key, in a DSSE envelope, as 'cosign attest' signs it,
+// and pushed to the repository as an OCI referrer of the image manifest.
+type AttestCommand struct {
+	imageReference     string
+	keyPath            string
+	predicatePath      string
+	predicateType      string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewAttestCommand() *AttestCommand {
+	return &AttestCommand{}
+}
+
+func (ac *AttestCommand) SetImageReference(imageReference string) *AttestCommand {
+	ac.imageReference = imageReference
+	return ac
+}
+
+func (ac *AttestCommand) SetKeyPath(keyPath string) *AttestCommand {
+	ac.keyPath = keyPath
+	return ac
+}
+
+func (ac *AttestCommand) SetPredicatePath(predicatePath string) *AttestCommand {
+	ac.predicatePath = predicatePath
+	return ac
+}
+
+// Sets the predicate type, as a cosign type name (such as slsaprovenance) or a URI.
+func (ac *AttestCommand) SetPredicateType(predicateType string) *AttestCommand {
+	ac.predicateType = predicateType
+	return ac
+}
+
+func (ac *AttestCommand) SetServerDetails(serverDetails *config.ServerDetails) *AttestCommand {
+	ac.serverDetails = serverDetails
+	return ac
+}
+
+func (ac *AttestCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *AttestCommand {
+	ac.buildConfiguration = buildConfiguration
+	return ac
+}
+
+func (ac *AttestCommand) CommandName() string {
+	return "rt_docker_attest"
+}
+
+func (ac *AttestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ac.serverDetails, nil
+}
+
+func (ac *AttestCommand) Run() error {
+	predicateType, err := getPredicateType(ac.predicateType)
+	if err != nil {
+		return err
+	}
+	predicate, err := os.ReadFile(ac.predicatePath)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read the predicate: %s", err.Error())
+	}
+	signer, err := loadPrivateKey(ac.keyPath, []byte(os.Getenv(KeyPasswordEnv)))
+	if err != nil {
+		return err
+	}
+	ref, client, subject, err := connectToSubject(ac.serverDetails, ac.imageReference, "pull,push")
+	if err != nil {
+		return err
+	}
+	attestation, err := newAttestationEnvelope(signer, ref.repo+"/"+ref.image, subject.Digest, predicateType, predicate)
+	if err != nil {
+		return err
+	}
+	layer := descriptor{
+		MediaType:   dsseEnvelopeMediaType,
+		Digest:      digestOf(attestation),
+		Size:        int64(len(attestation)),
+		Annotations: map[string]string{predicateTypeAnnotation: predicateType},
+	}
+	log.Info("Attaching the", predicateType, "attestation to the image", ref.String(), "("+subject.Digest+")")
+	referrer, err := pushReferrer(client, subject, dsseEnvelopeMediaType, layer, attestation)
+	if err != nil {
+		return err
+	}
+	log.Info("Pushed the attestation", referrer.Digest)
+	return saveReferrerArtifacts(ac.serverDetails, ac.buildConfiguration, ref, referrer)
+}
+
+// Verifies the signatures of an image in an Artifactory Docker repository, and optionally its attestations of a predicate type,
+// with a local public key. Fails unless at least one signature (and one attestation, if a type is set) is verified.
+type VerifyCommand struct {
+	imageReference string
+	keyPath        string
+	predicateType  string
+	serverDetails  *config.ServerDetails
+}
+
+func NewVerifyCommand() *VerifyCommand {
+	return &VerifyCommand{}
+}
+
+func (vc *VerifyCommand) SetImageReference(imageReference string) *VerifyCommand {
+	vc.imageReference = imageReference
+	return vc
+}
+
+func (vc *VerifyCommand) SetKeyPath(keyPath string) *VerifyCommand {
+	vc.keyPath = keyPath
+	return vc
+}
+
+// Sets the predicate type of the attestations to verify. If empty, only the signatures are verified.
+func (vc *VerifyCommand) SetPredicateType(predicateType string) *VerifyCommand {
+	vc.predicateType = predicateType
+	return vc
+}
+
+func (vc *VerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *VerifyCommand {
+	vc.serverDetails = serverDetails
+	return vc
+}
+
+func (vc *VerifyCommand) CommandName() string {
+	return "rt_docker_verify"
+}
+
+func (vc *VerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return vc.serverDetails, nil
+}
+
+func (vc *VerifyCommand) Run() error {
+	predicateType := ""
+	if vc.predicateType != "" {
+		var err error
+		if predicateType, err = getPredicateType(vc.predicateType); err != nil {
+			return err
+		}
+	}
+	publicKey, err := loadPublicKey(vc.keyPath)
+	if err != nil {
+		return err
+	}
+	ref, client, subject, err := connectToSubject(vc.serverDetails, vc.imageReference, "pull")
+	if err != nil {
+		return err
+	}
+	signatures, err := verifySignatures(client, publicKey, subject.Digest)
+	if err != nil {
+		return err
+	}
+	if signatures == 0 {
+		return errorutils.CheckErrorf("no valid signature of the image %s (%s) was found for the key %s", ref.String(), subject.Digest, vc.keyPath)
+	}
+	log.Info("Verified", signatures, "signature(s) of the image", ref.String(), "("+subject.Digest+")")
+	if predicateType == "" {
+		return nil
+	}
+	attestations, err := verifyAttestations(client, publicKey, subject.Digest, predicateType)
+	if err != nil {
+		return err
+	}
+	if attestations == 0 {
+		return errorutils.CheckErrorf("no valid %s attestation of the image %s (%s) was found for the key %s", predicateType, ref.String(), subject.Digest, vc.keyPath)
+	}
+	log.Info("Verified", attestations, predicateType, "attestation(s) of the image", ref.String())
+	return nil
+}
+
+// Parses the image reference, authenticates with the registry of its repository, and resolves the manifest of the image.
+func connectToSubject(serverDetails *config.ServerDetails, reference, actions string) (*imageReference, *registryClient, descriptor, error) {
+	ref, err := parseImageReference(reference)
+	if err != nil {
+		return nil, nil, descriptor{}, err
+	}
+	client, err := newRegistryClient(serverDetails, ref.repo, ref.image)
+	if err != nil {
+		return nil, nil, descriptor{}, err
+	}
+	if err = client.authenticate(actions); err != nil {
+		return nil, nil, descriptor{}, err
+	}
+	subject, err := resolveSubject(client, ref.reference)
+	if err != nil {
+		return nil, nil, descriptor{}, err
+	}
+	if ref.isDigest() && subject.Digest != ref.reference {
+		return nil, nil, descriptor{}, errorutils.CheckErrorf("the digest of the manifest %s doesn't match: %s", ref.reference, subject.Digest)
+	}
+	return ref, client, subject, nil
+}
+
+// Returns the number of signatures of the image, which are verified by the public key and sign the digest of its manifest.
+func verifySignatures(client *registryClient, publicKey crypto.PublicKey, subjectDigest string) (verified int, err error) {
+	referrers, err := client.getReferrers(subjectDigest, signatureArtifactType)
+	if err != nil {
+		return 0, err
+	}
+	for _, referrer := range referrers {
+		layers, err := getReferrerLayers(client, referrer, simpleSigningMediaType)
+		if err != nil {
+			return 0, err
+		}
+		for _, layer := range layers {
+			signature, err := base64.StdEncoding.DecodeString(layer.Annotations[signatureAnnotation])
+			if err != nil {
+				log.Debug("Skipping the signature", referrer.Digest+": its annotation isn't base64 encoded")
+				continue
+			}
+			payload, err := client.readBlob(layer.Digest)
+			if err != nil {
+				return 0, err
+			}
+			if !verify(publicKey, payload, signature) {
+				log.Debug("The signature", referrer.Digest, "isn't verified by the key")
+				continue
+			}
+			if signedDigest, err := getSignedDigest(payload); err != nil || signedDigest != subjectDigest {
+				log.Warn("The signature", referrer.Digest, "is verified by the key, but doesn't sign the manifest", subjectDigest)
+				continue
+			}
+			verified++
+		}
+	}
+	return verified, nil
+}
+
+func getSignedDigest(payload []byte) (string, error) {
+	var signed simpleSigning
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return "", errorutils.CheckErrorf("failed to parse the signature payload: %s", err.Error())
+	}
+	return signed.Critical.Image.DockerManifestDigest, nil
+}
+
+// Returns the number of attestations of the predicate type, which are verified by the public key and whose statement is about the image.
+func verifyAttestations(client *registryClient, publicKey crypto.PublicKey, subjectDigest, predicateType string) (verified int, err error) {
+	referrers, err := client.getReferrers(subjectDigest, dsseEnvelopeMediaType)
+	if err != nil {
+		return 0, err
+	}
+	algorithm, encoded, _ := strings.Cut(subjectDigest, ":")
+	for _, referrer := range referrers {
+		layers, err := getReferrerLayers(client, referrer, dsseEnvelopeMediaType)
+		if err != nil {
+			return 0, err
+		}
+		for _, layer := range layers {
+			if layer.Annotations[predicateTypeAnnotation] != "" && layer.Annotations[predicateTypeAnnotation] != predicateType {
+				continue
+			}
+			attestation, err := client.readBlob(layer.Digest)
+			if err != nil {
+				return 0, err
+			}
+			stmt, err := verifyAttestationEnvelope(publicKey, attestation)
+			if err != nil {
+				return 0, err
+			}
+			if stmt == nil {
+				log.Debug("The attestation", referrer.Digest, "isn't verified by the key")
+				continue
+			}
+			if stmt.PredicateType != predicateType {
+				continue
+			}
+			for _, statementSubject := range stmt.Subject {
+				if statementSubject.Digest[algorithm] == encoded {
+					verified++
+					break
+				}
+			}
+		}
+	}
+	return verified, nil
+}
+
+// Returns the layers of the media type in the manifest of a referrer.
+func getReferrerLayers(client *registryClient, referrer descriptor, mediaType string) ([]descriptor, error) {
+	content, _, err := client.getManifest(referrer.Digest)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseManifest(content)
+	if err != nil {
+		return nil, err
+	}
+	var layers []descriptor
+	for _, layer := range m.Layers {
+		if layer.MediaType == mediaType {
+			layers = append(layers, layer)
+		}
+	}
+	return layers, nil
+}
+
+// Records the files which Artifactory stored for the referrer in the module of the image, if build-info is collected.
+// The module is the one in which the docker and oci push commands record the image.
+// Artifactory stores a manifest pushed by its digest in a folder named by the digest (sha256__<hex>), next to the tag folders.
+func saveReferrerArtifacts(serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration, ref *imageReference, referrer descriptor) error {
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	referrerFolder := path.Join(ref.image, strings.Replace(referrer.Digest, ":", "__", 1))
+	artifacts, err := buildinfoutils.GetDeployedArtifacts(serverDetails, buildConfiguration, ref.repo, referrerFolder+"/*")
+	if err != nil {
+		return err
+	}
+	return buildinfoutils.SaveArtifacts(buildConfiguration, ref.moduleId(), ModuleType, artifacts)
+}
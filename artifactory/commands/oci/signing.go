@@ -0,0 +1,292 @@
+package oci
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// The environment variable of the password of an encrypted cosign key, as cosign reads it.
+	KeyPasswordEnv = "COSIGN_PASSWORD"
+
+	simpleSigningType        = "cosign container image signature"
+	simpleSigningMediaType   = "application/vnd.dev.cosign.simplesigning.v1+json"
+	signatureAnnotation      = "dev.cosignproject.cosign/signature"
+	signatureArtifactType    = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	dsseEnvelopeMediaType    = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType        = "application/vnd.in-toto+json"
+	inTotoStatementType      = "https://in-toto.io/Statement/v0.1"
+	predicateTypeAnnotation  = "predicateType"
+	emptyConfigMediaType     = "application/vnd.oci.empty.v1+json"
+	emptyConfigContent       = "{}"
+	encryptedCosignKeyType   = "ENCRYPTED COSIGN PRIVATE KEY"
+	encryptedSigstoreKeyType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+)
+
+// The predicate types of the --type option, as cosign names them. Other types are given as URIs.
+var predicateTypes = map[string]string{
+	"slsaprovenance":  "https://slsa.dev/provenance/v0.2",
+	"slsaprovenance1": "https://slsa.dev/provenance/v1",
+	"spdx":            "https://spdx.dev/Document",
+	"spdxjson":        "https://spdx.dev/Document",
+	"cyclonedx":       "https://cyclonedx.org/bom",
+	"link":            "https://in-toto.io/Link/v1",
+	"vuln":            "https://cosign.sigstore.dev/attestation/vuln/v1",
+	"openvex":         "https://openvex.dev/ns",
+	"custom":          "https://cosign.sigstore.dev/attestation/v1",
+}
+
+func getPredicateType(predicateType string) (string, error) {
+	if uri, ok := predicateTypes[predicateType]; ok {
+		return uri, nil
+	}
+	if strings.Contains(predicateType, "://") {
+		return predicateType, nil
+	}
+	return "", errorutils.CheckErrorf("unknown predicate type '%s'. Use one of slsaprovenance, slsaprovenance1, spdx, spdxjson, cyclonedx, link, vuln, openvex, custom, or a URI", predicateType)
+}
+
+// Reads a private key from a PEM file: a PKCS #8, EC or RSA private key, or an encrypted key generated by 'cosign generate-key-pair'.
+func loadPrivateKey(keyPath string, password []byte) (crypto.Signer, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the private key: %s", err.Error())
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("the private key %s isn't a PEM file", keyPath)
+	}
+	var key any
+	switch block.Type {
+	case encryptedCosignKeyType, encryptedSigstoreKeyType:
+		der, err := decryptCosignKey(block.Bytes, password)
+		if err != nil {
+			return nil, err
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	case "EC PRIVATE KEY":
+		if key, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	case "RSA PRIVATE KEY":
+		if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	case "PRIVATE KEY":
+		if key, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	default:
+		return nil, errorutils.CheckErrorf("unsupported private key type: %s", block.Type)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errorutils.CheckErrorf("unsupported private key: %T", key)
+	}
+	return signer, nil
+}
+
+// An encrypted cosign key: the PKCS #8 key, encrypted with nacl/secretbox by a key derived from the password with scrypt.
+type encryptedKey struct {
+	Kdf struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(content, password []byte) ([]byte, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(content, &key); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the encrypted private key: %s", err.Error())
+	}
+	if key.Kdf.Name != "scrypt" || key.Cipher.Name != "nacl/secretbox" || len(key.Cipher.Nonce) != 24 {
+		return nil, errorutils.CheckErrorf("unsupported encryption of the private key: %s, %s", key.Kdf.Name, key.Cipher.Name)
+	}
+	secretKey, err := scrypt.Key(password, key.Kdf.Salt, key.Kdf.Params.N, key.Kdf.Params.R, key.Kdf.Params.P, 32)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var nonce [24]byte
+	var secretKeyArray [32]byte
+	copy(nonce[:], key.Cipher.Nonce)
+	copy(secretKeyArray[:], secretKey)
+	decrypted, ok := secretbox.Open(nil, key.Ciphertext, &nonce, &secretKeyArray)
+	if !ok {
+		return nil, errorutils.CheckErrorf("failed to decrypt the private key. Please make sure the password is set in the %s environment variable", KeyPasswordEnv)
+	}
+	return decrypted, nil
+}
+
+// Reads a public key from a PEM file.
+func loadPublicKey(keyPath string) (crypto.PublicKey, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the public key: %s", err.Error())
+	}
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errorutils.CheckErrorf("the public key %s isn't a PEM public key", keyPath)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	return publicKey, errorutils.CheckError(err)
+}
+
+// Signs the message. ECDSA and RSA keys sign its SHA-256 digest, as cosign does.
+func sign(signer crypto.Signer, message []byte) ([]byte, error) {
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		signature, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+		return signature, errorutils.CheckError(err)
+	}
+	digest := sha256.Sum256(message)
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	return signature, errorutils.CheckError(err)
+}
+
+func verify(publicKey crypto.PublicKey, message, signature []byte) bool {
+	digest := sha256.Sum256(message)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, signature)
+	}
+	return false
+}
+
+// The payload of a cosign signature, which binds the image reference to the digest of its manifest.
+type simpleSigning struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+func newSimpleSigningPayload(dockerReference, digest string) ([]byte, error) {
+	var payload simpleSigning
+	payload.Critical.Identity.DockerReference = dockerReference
+	payload.Critical.Image.DockerManifestDigest = digest
+	payload.Critical.Type = simpleSigningType
+	content, err := json.Marshal(payload)
+	return content, errorutils.CheckError(err)
+}
+
+// An in-toto statement about the image.
+type statement struct {
+	Type          string             `json:"_type"`
+	PredicateType string             `json:"predicateType"`
+	Subject       []statementSubject `json:"subject"`
+	Predicate     json.RawMessage    `json:"predicate"`
+}
+
+type statementSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// A DSSE envelope, which signs the statement.
+type envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     string              `json:"payload"`
+	Signatures  []envelopeSignature `json:"signatures"`
+}
+
+type envelopeSignature struct {
+	KeyId string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Returns the pre-authentication encoding of a DSSE payload, which is the signed message.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Returns a signed DSSE envelope of an in-toto statement with the predicate about the image.
+func newAttestationEnvelope(signer crypto.Signer, dockerReference, digest, predicateType string, predicate []byte) ([]byte, error) {
+	if !json.Valid(predicate) {
+		return nil, errorutils.CheckErrorf("the predicate must be a JSON document")
+	}
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	payload, err := json.Marshal(statement{
+		Type:          inTotoStatementType,
+		PredicateType: predicateType,
+		Subject:       []statementSubject{{Name: dockerReference, Digest: map[string]string{algorithm: encoded}}},
+		Predicate:     predicate,
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	signature, err := sign(signer, preAuthEncoding(inTotoPayloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(envelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []envelopeSignature{{Sig: base64.StdEncoding.EncodeToString(signature)}},
+	})
+	return content, errorutils.CheckError(err)
+}
+
+// Verifies the envelope of an attestation, and returns its statement.
+func verifyAttestationEnvelope(publicKey crypto.PublicKey, content []byte) (*statement, error) {
+	var env envelope
+	if err := json.Unmarshal(content, &env); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the attestation envelope: %s", err.Error())
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	verified := false
+	for _, envSignature := range env.Signatures {
+		signature, err := base64.StdEncoding.DecodeString(envSignature.Sig)
+		if err == nil && verify(publicKey, preAuthEncoding(env.PayloadType, payload), signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, nil
+	}
+	stmt := new(statement)
+	if err = json.Unmarshal(payload, stmt); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the attestation statement: %s", err.Error())
+	}
+	return stmt, nil
+}
@@ -0,0 +1,106 @@
+package oci
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Writes an ECDSA key pair as PEM files, and returns their paths.
+func writeTestKeyPair(t *testing.T) (privateKeyPath, publicKeyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privateDer, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	publicDer, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	dir := t.TempDir()
+	privateKeyPath, publicKeyPath = filepath.Join(dir, "cosign.key"), filepath.Join(dir, "cosign.pub")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer}), 0600))
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0644))
+	return privateKeyPath, publicKeyPath
+}
+
+func TestSignAndVerify(t *testing.T) {
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	sourceDir := t.TempDir()
+	manifestDigest := writeTestLayout(t, sourceDir)
+	client := newTestClient(t, server, "app")
+	_, err := pushLayout(client, &ociLayout{dir: sourceDir}, "1.0", "")
+	require.NoError(t, err)
+
+	privateKeyPath, publicKeyPath := writeTestKeyPair(t)
+	signer, err := loadPrivateKey(privateKeyPath, nil)
+	require.NoError(t, err)
+	publicKey, err := loadPublicKey(publicKeyPath)
+	require.NoError(t, err)
+	subject, err := resolveSubject(client, "1.0")
+	require.NoError(t, err)
+	assert.Equal(t, manifestDigest, subject.Digest)
+
+	// The test registry doesn't support the referrers API, so the signature is added to the referrers index of the image.
+	payload, err := newSimpleSigningPayload("docker-local/app", subject.Digest)
+	require.NoError(t, err)
+	signature, err := sign(signer, payload)
+	require.NoError(t, err)
+	layer := descriptor{MediaType: simpleSigningMediaType, Digest: digestOf(payload), Size: int64(len(payload)),
+		Annotations: map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)}}
+	referrer, err := pushReferrer(client, subject, signatureArtifactType, layer, payload)
+	require.NoError(t, err)
+	assert.Contains(t, registry.manifests, "app/manifests/"+referrersTag(subject.Digest))
+	referrers, err := client.getReferrers(subject.Digest, signatureArtifactType)
+	require.NoError(t, err)
+	assert.Equal(t, []descriptor{referrer}, referrers)
+
+	verified, err := verifySignatures(client, publicKey, subject.Digest)
+	require.NoError(t, err)
+	assert.Equal(t, 1, verified)
+	_, otherPublicKeyPath := writeTestKeyPair(t)
+	otherPublicKey, err := loadPublicKey(otherPublicKeyPath)
+	require.NoError(t, err)
+	verified, err = verifySignatures(client, otherPublicKey, subject.Digest)
+	require.NoError(t, err)
+	assert.Zero(t, verified)
+
+	// Attach an attestation next to the signature.
+	predicateType, err := getPredicateType("slsaprovenance")
+	require.NoError(t, err)
+	attestation, err := newAttestationEnvelope(signer, "docker-local/app", subject.Digest, predicateType, []byte(`{"builder":{"id":"test"}}`))
+	require.NoError(t, err)
+	layer = descriptor{MediaType: dsseEnvelopeMediaType, Digest: digestOf(attestation), Size: int64(len(attestation)),
+		Annotations: map[string]string{predicateTypeAnnotation: predicateType}}
+	_, err = pushReferrer(client, subject, dsseEnvelopeMediaType, layer, attestation)
+	require.NoError(t, err)
+	verified, err = verifyAttestations(client, publicKey, subject.Digest, predicateType)
+	require.NoError(t, err)
+	assert.Equal(t, 1, verified)
+	verified, err = verifyAttestations(client, publicKey, subject.Digest, "https://spdx.dev/Document")
+	require.NoError(t, err)
+	assert.Zero(t, verified)
+	verified, err = verifySignatures(client, publicKey, subject.Digest)
+	require.NoError(t, err)
+	assert.Equal(t, 1, verified)
+}
+
+func TestGetPredicateType(t *testing.T) {
+	predicateType, err := getPredicateType("slsaprovenance")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://slsa.dev/provenance/v0.2", predicateType)
+	predicateType, err = getPredicateType("https://example.com/predicate/v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/predicate/v1", predicateType)
+	_, err = getPredicateType("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,310 @@
+package pnpm
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	LockfileName          = "pnpm-lock.yaml"
+	WorkspaceManifestName = "pnpm-workspace.yaml"
+
+	prodScope     = "prod"
+	devScope      = "dev"
+	optionalScope = "optional"
+)
+
+// The pnpm-lock.yaml file. Versions 5, 6 and 9 of the lockfile format are supported.
+type pnpmLockfile struct {
+	LockfileVersion string                   `yaml:"lockfileVersion"`
+	Importers       map[string]*lockImporter `yaml:"importers"`
+	// Lockfiles of a single project, prior to version 9, list the project dependencies at the top level.
+	lockImporter `yaml:",inline"`
+	Packages     map[string]*lockPackage `yaml:"packages"`
+	// Starting from version 9, the dependencies of each package are listed in the snapshots rather than in the packages.
+	Snapshots map[string]*lockPackage `yaml:"snapshots"`
+}
+
+// A project of the workspace.
+type lockImporter struct {
+	Dependencies         map[string]importerDependency `yaml:"dependencies"`
+	DevDependencies      map[string]importerDependency `yaml:"devDependencies"`
+	OptionalDependencies map[string]importerDependency `yaml:"optionalDependencies"`
+}
+
+type importerDependency struct {
+	Version string
+}
+
+// Starting from version 6, the importer dependencies include the specifier and the resolved version.
+// Prior to version 6, only the resolved version is listed.
+func (id *importerDependency) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&id.Version); err == nil {
+		return nil
+	}
+	var dependency struct {
+		Version string `yaml:"version"`
+	}
+	if err := unmarshal(&dependency); err != nil {
+		return err
+	}
+	id.Version = dependency.Version
+	return nil
+}
+
+type lockPackage struct {
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// The dependencies of a single project of the workspace.
+type lockfileModule struct {
+	// Path of the project, relative to the lockfile directory.
+	path         string
+	id           string
+	dependencies []buildinfo.Dependency
+}
+
+func readLockfile(lockfilePath string) (*pnpmLockfile, error) {
+	content, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lockfile := new(pnpmLockfile)
+	if err = yaml.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", lockfilePath, err.Error())
+	}
+	if lockfile.majorVersion() < 5 {
+		return nil, errorutils.CheckErrorf("pnpm lockfile version %s is not supported. Lockfile versions 5 and above are supported", lockfile.LockfileVersion)
+	}
+	if len(lockfile.Importers) == 0 {
+		// A single project.
+		lockfile.Importers = map[string]*lockImporter{".": &lockfile.lockImporter}
+	}
+	return lockfile, nil
+}
+
+func (lf *pnpmLockfile) majorVersion() int {
+	major, _, _ := strings.Cut(strings.Trim(lf.LockfileVersion, "'\""), ".")
+	version, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// Returns the key of the resolved package, in the packages or snapshots of the lockfile.
+// Returns an empty string for workspace packages and local directories, which aren't dependencies.
+func (lf *pnpmLockfile) packageKey(name, version string) string {
+	if strings.HasPrefix(version, "link:") || strings.HasPrefix(version, "file:") {
+		return ""
+	}
+	switch {
+	case lf.majorVersion() >= 9:
+		if isAlias(version) {
+			return version
+		}
+		return name + "@" + version
+	case lf.majorVersion() == 5:
+		if strings.HasPrefix(version, "/") {
+			return version
+		}
+		return "/" + name + "/" + version
+	default:
+		if strings.HasPrefix(version, "/") {
+			return version
+		}
+		return "/" + name + "@" + version
+	}
+}
+
+// Aliased dependencies are resolved to a version in the form of <package name>@<version>.
+// The version may be followed by the peer dependencies suffix, such as 18.2.0(react@18.2.0).
+func isAlias(version string) bool {
+	version, _, _ = strings.Cut(version, "(")
+	return strings.LastIndex(version, "@") > 0
+}
+
+func (lf *pnpmLockfile) getPackage(key string) *lockPackage {
+	if lf.majorVersion() >= 9 {
+		return lf.Snapshots[key]
+	}
+	return lf.Packages[key]
+}
+
+// Returns the build-info ID of the package, in the form of <name>:<version>.
+// The peer dependencies suffix of the package key is removed.
+func (lf *pnpmLockfile) dependencyId(key string) string {
+	key = strings.TrimPrefix(key, "/")
+	if lf.majorVersion() == 5 {
+		separator := strings.LastIndex(key, "/")
+		if separator <= 0 {
+			return key
+		}
+		version, _, _ := strings.Cut(key[separator+1:], "_")
+		return key[:separator] + ":" + version
+	}
+	key, _, _ = strings.Cut(key, "(")
+	separator := strings.LastIndex(key, "@")
+	if separator <= 0 {
+		return key
+	}
+	return key[:separator] + ":" + key[separator+1:]
+}
+
+// Calculates the dependencies of each project in the lockfile.
+// If projectPath isn't empty, only the project in this path, relative to the lockfile directory, is included.
+func (lf *pnpmLockfile) getModules(lockfileDir, projectPath string) ([]*lockfileModule, error) {
+	importerPaths := make([]string, 0, len(lf.Importers))
+	for importerPath := range lf.Importers {
+		if projectPath == "" || filepath.Clean(importerPath) == filepath.Clean(projectPath) {
+			importerPaths = append(importerPaths, importerPath)
+		}
+	}
+	sort.Strings(importerPaths)
+	var modules []*lockfileModule
+	for _, importerPath := range importerPaths {
+		moduleId, err := getProjectId(lockfileDir, importerPath)
+		if err != nil {
+			return nil, err
+		}
+		module := &lockfileModule{path: importerPath, id: moduleId, dependencies: lf.getImporterDependencies(moduleId, lf.Importers[importerPath])}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// The project ID is <name>:<version> taken from its package.json, or the project directory name if they aren't set.
+func getProjectId(lockfileDir, importerPath string) (string, error) {
+	projectDir := filepath.Join(lockfileDir, filepath.FromSlash(importerPath))
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(projectDir, nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if moduleId := packageInfo.BuildInfoModuleId(); moduleId != "" {
+		return moduleId, nil
+	}
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Base(absProjectDir), nil
+}
+
+// Walks the dependency graph of a single project.
+// Each dependency is requested by the shortest path to the project, through each of its dependents.
+// A package is walked again when it's reached through a new scope, so that its scopes reach its dependencies.
+func (lf *pnpmLockfile) getImporterDependencies(moduleId string, importer *lockImporter) []buildinfo.Dependency {
+	walker := buildinfoutils.NewDependencyWalker(func(key string) buildinfo.Dependency {
+		return buildinfo.Dependency{Id: lf.dependencyId(key)}
+	})
+	// The scopes of each package, and of each dependency ID.
+	keyScopes := make(map[string]map[string]bool)
+	idScopes := make(map[string]map[string]bool)
+	visit := func(key, scope string, pathToRoot []string) {
+		firstVisit := walker.Visit(key, pathToRoot)
+		if keyScopes[key] == nil {
+			keyScopes[key] = make(map[string]bool)
+		}
+		if keyScopes[key][scope] {
+			return
+		}
+		keyScopes[key][scope] = true
+		id := walker.GetDependency(key).Id
+		if idScopes[id] == nil {
+			idScopes[id] = make(map[string]bool)
+		}
+		idScopes[id][scope] = true
+		if !firstVisit {
+			walker.Enqueue(key, append([]string{id}, pathToRoot...))
+		}
+	}
+	for _, direct := range []struct {
+		dependencies map[string]importerDependency
+		scope        string
+	}{{importer.Dependencies, prodScope}, {importer.DevDependencies, devScope}, {importer.OptionalDependencies, optionalScope}} {
+		for _, name := range slices.Sorted(maps.Keys(direct.dependencies)) {
+			if key := lf.packageKey(name, direct.dependencies[name].Version); key != "" {
+				visit(key, direct.scope, []string{moduleId})
+			}
+		}
+	}
+	walker.Walk(func(key string, pathToRoot []string) {
+		pkg := lf.getPackage(key)
+		if pkg == nil {
+			return
+		}
+		for _, children := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+			for _, name := range slices.Sorted(maps.Keys(children)) {
+				childKey := lf.packageKey(name, children[name])
+				if childKey == "" {
+					continue
+				}
+				for _, scope := range []string{prodScope, devScope, optionalScope} {
+					if keyScopes[key][scope] {
+						visit(childKey, scope, pathToRoot)
+					}
+				}
+			}
+		}
+	})
+
+	// Packages resolved with different peer dependencies share the same ID.
+	var dependencies []buildinfo.Dependency
+	indexes := make(map[string]int)
+	for _, dependency := range walker.Dependencies() {
+		if index, exists := indexes[dependency.Id]; exists {
+			dependencies[index].RequestedBy = append(dependencies[index].RequestedBy, dependency.RequestedBy...)
+			continue
+		}
+		for _, scope := range []string{prodScope, devScope, optionalScope} {
+			if idScopes[dependency.Id][scope] {
+				dependency.Scopes = append(dependency.Scopes, scope)
+			}
+		}
+		indexes[dependency.Id] = len(dependencies)
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies
+}
+
+// Returns the directory of the lockfile, which is the workspace root when running inside a workspace project.
+// The parent directories are searched up to the workspace root.
+func findLockfileDir(workingDir string) (string, error) {
+	dir := workingDir
+	for {
+		if exists, err := isFileExists(filepath.Join(dir, LockfileName)); err != nil || exists {
+			return dir, err
+		}
+		if exists, err := isFileExists(filepath.Join(dir, WorkspaceManifestName)); err != nil || exists {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func isFileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, errorutils.CheckError(err)
+}
@@ -0,0 +1,240 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockfileV9 = `lockfileVersion: '9.0'
+
+importers:
+
+  .:
+    dependencies:
+      react-dom:
+        specifier: ^18.2.0
+        version: 18.2.0(react@18.2.0)
+      react:
+        specifier: ^18.2.0
+        version: 18.2.0
+    devDependencies:
+      lodash-alias:
+        specifier: npm:lodash@^4.17.21
+        version: lodash@4.17.21
+      '@types/node':
+        specifier: ^20.0.0
+        version: 20.11.0
+
+  packages/utils:
+    dependencies:
+      web:
+        specifier: workspace:*
+        version: link:../web
+      loose-envify:
+        specifier: ^1.4.0
+        version: 1.4.0
+
+packages:
+
+  '@types/node@20.11.0':
+    resolution: {integrity: sha512-o9bjXmDNcF7GbM4CNQpmi+TutCgap/K3w1JyKgxAjqx41zp9qlIAVFi0IhCNsJcXolEqLWhbFbEeL0PvYm4pcQ==}
+
+  js-tokens@4.0.0:
+    resolution: {integrity: sha512-RdJUflcE3cUzKiMqQgsCu06FPu9UdIJO0beYbPhHN4k6apgJtifcoCtT9bcxOpYBtpD2kCM6Sbzg4CausW/PKQ==}
+
+  lodash@4.17.21:
+    resolution: {integrity: sha512-v2kDEe57lecTulaDIuNTPy3Ry4gLGJ6Z1O3vE1krgXZNrsQ+LFTGHVxVjcXPs17LhbZVGedAJv8XZ1tvj5FvSg==}
+
+  loose-envify@1.4.0:
+    resolution: {integrity: sha512-lyuxPGr/Wfhrlem2CL/UcnUc1zcqKAImBDzukY7Y5F/yQiNdko6+fRLevlw1HgMySw7f611UIY408EtxRSoK3Q==}
+
+  react-dom@18.2.0:
+    resolution: {integrity: sha512-6IMTriUmvsjHUjNtEDudZfuDQUoWXVxKHhlEGSk81n4YFS+r/Kl99wXiwlVXtPBtJenozv2P+hxDsw9eA7Xo6g==}
+    peerDependencies:
+      react: ^18.2.0
+
+  react@18.2.0:
+    resolution: {integrity: sha512-/3IjMdb2L9QbBdWiW5e3P2/npwMBaU9mHCSCUzNln0ZCYbcfTsGbTJrU/kGemdH2IWmB2ioZ+zkxtmq6g09fGQ==}
+
+snapshots:
+
+  '@types/node@20.11.0': {}
+
+  js-tokens@4.0.0: {}
+
+  lodash@4.17.21: {}
+
+  loose-envify@1.4.0:
+    dependencies:
+      js-tokens: 4.0.0
+
+  react-dom@18.2.0(react@18.2.0):
+    dependencies:
+      loose-envify: 1.4.0
+      react: 18.2.0
+
+  react@18.2.0:
+    dependencies:
+      loose-envify: 1.4.0
+`
+
+const lockfileV6 = `lockfileVersion: '6.0'
+
+dependencies:
+  react:
+    specifier: ^18.2.0
+    version: 18.2.0
+
+devDependencies:
+  '@babel/core':
+    specifier: ^7.23.0
+    version: 7.23.0
+
+packages:
+
+  /@babel/core@7.23.0:
+    resolution: {integrity: sha512-97z/ju/Jy1rZmDxybphrBuI+jtJjFVoz7Mr9yUQVVVi+DNZE333uFQeMOqcCIy1x3WYBIbWftUSLmbNXNT7qFQ==}
+    dev: true
+    dependencies:
+      js-tokens: 4.0.0
+
+  /js-tokens@4.0.0:
+    resolution: {integrity: sha512-RdJUflcE3cUzKiMqQgsCu06FPu9UdIJO0beYbPhHN4k6apgJtifcoCtT9bcxOpYBtpD2kCM6Sbzg4CausW/PKQ==}
+
+  /loose-envify@1.4.0:
+    resolution: {integrity: sha512-lyuxPGr/Wfhrlem2CL/UcnUc1zcqKAImBDzukY7Y5F/yQiNdko6+fRLevlw1HgMySw7f611UIY408EtxRSoK3Q==}
+    dependencies:
+      js-tokens: 4.0.0
+
+  /react@18.2.0:
+    resolution: {integrity: sha512-/3IjMdb2L9QbBdWiW5e3P2/npwMBaU9mHCSCUzNln0ZCYbcfTsGbTJrU/kGemdH2IWmB2ioZ+zkxtmq6g09fGQ==}
+    dependencies:
+      loose-envify: 1.4.0
+`
+
+const lockfileV5 = `lockfileVersion: 5.4
+
+specifiers:
+  styled-components: ^5.3.0
+
+dependencies:
+  styled-components: 5.3.0_react@18.2.0
+
+packages:
+
+  /react/18.2.0:
+    resolution: {integrity: sha512-/3IjMdb2L9QbBdWiW5e3P2/npwMBaU9mHCSCUzNln0ZCYbcfTsGbTJrU/kGemdH2IWmB2ioZ+zkxtmq6g09fGQ==}
+
+  /styled-components/5.3.0_react@18.2.0:
+    resolution: {integrity: sha512-bPJKwZCHjJPf/hwTJl6TbkSZg/3evha+XPEizrZUGb535jLImwDUdjTNxXqjjaASt2M4qO4AVfoHJNe3XB/tpQ==}
+    dependencies:
+      react: 18.2.0
+`
+
+func TestLockfileV9Workspace(t *testing.T) {
+	dir := writeLockfile(t, lockfileV9)
+	writeFile(t, filepath.Join(dir, "package.json"), `{"name":"web","version":"1.0.0"}`)
+	writeFile(t, filepath.Join(dir, "packages", "utils", "package.json"), `{"name":"@acme/utils","version":"0.1.0"}`)
+	lockfile, err := readLockfile(filepath.Join(dir, LockfileName))
+	require.NoError(t, err)
+	modules, err := lockfile.getModules(dir, "")
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	assert.Equal(t, "web:1.0.0", modules[0].id)
+	dependencies := toDependenciesMap(modules[0].dependencies)
+	assert.Len(t, dependencies, 6)
+	assert.Equal(t, []string{"prod"}, dependencies["react-dom:18.2.0"].Scopes)
+	assert.Equal(t, [][]string{{"web:1.0.0"}}, dependencies["react-dom:18.2.0"].RequestedBy)
+	// The aliased dependency is collected by its real name.
+	assert.Equal(t, []string{"dev"}, dependencies["lodash:4.17.21"].Scopes)
+	assert.Equal(t, []string{"dev"}, dependencies["@types/node:20.11.0"].Scopes)
+	// A transitive dependency requested by several dependents.
+	assert.Equal(t, []string{"prod"}, dependencies["loose-envify:1.4.0"].Scopes)
+	assert.ElementsMatch(t, [][]string{{"react-dom:18.2.0", "web:1.0.0"}, {"react:18.2.0", "web:1.0.0"}}, dependencies["loose-envify:1.4.0"].RequestedBy)
+	assert.Equal(t, [][]string{{"web:1.0.0"}, {"react-dom:18.2.0", "web:1.0.0"}}, dependencies["react:18.2.0"].RequestedBy)
+	assert.Contains(t, dependencies, "js-tokens:4.0.0")
+
+	// Workspace links aren't dependencies. Scoped projects are named as npm modules are.
+	assert.Equal(t, "acme:utils:0.1.0", modules[1].id)
+	assert.Equal(t, "packages/utils", modules[1].path)
+	dependencies = toDependenciesMap(modules[1].dependencies)
+	assert.Len(t, dependencies, 2)
+	assert.Equal(t, [][]string{{"loose-envify:1.4.0", "acme:utils:0.1.0"}}, dependencies["js-tokens:4.0.0"].RequestedBy)
+
+	// Running inside a project of the workspace.
+	modules, err = lockfile.getModules(dir, "packages/utils")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "acme:utils:0.1.0", modules[0].id)
+}
+
+func TestLockfileV6(t *testing.T) {
+	dir := writeLockfile(t, lockfileV6)
+	lockfile, err := readLockfile(filepath.Join(dir, LockfileName))
+	require.NoError(t, err)
+	modules, err := lockfile.getModules(dir, "")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	// Without a package.json, the module is named after the project directory.
+	assert.Equal(t, filepath.Base(dir), modules[0].id)
+
+	dependencies := toDependenciesMap(modules[0].dependencies)
+	assert.Len(t, dependencies, 4)
+	assert.Equal(t, []string{"dev"}, dependencies["@babel/core:7.23.0"].Scopes)
+	// A transitive dependency of both production and development dependencies.
+	assert.Equal(t, []string{"prod", "dev"}, dependencies["js-tokens:4.0.0"].Scopes)
+}
+
+func TestLockfileV5(t *testing.T) {
+	dir := writeLockfile(t, lockfileV5)
+	lockfile, err := readLockfile(filepath.Join(dir, LockfileName))
+	require.NoError(t, err)
+	modules, err := lockfile.getModules(dir, "")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	dependencies := toDependenciesMap(modules[0].dependencies)
+	assert.Len(t, dependencies, 2)
+	assert.Contains(t, dependencies, "styled-components:5.3.0")
+	assert.Equal(t, [][]string{{"styled-components:5.3.0", filepath.Base(dir)}}, dependencies["react:18.2.0"].RequestedBy)
+}
+
+func TestFindLockfileDir(t *testing.T) {
+	dir := writeLockfile(t, lockfileV9)
+	projectDir := filepath.Join(dir, "packages", "utils")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+	lockfileDir, err := findLockfileDir(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, lockfileDir)
+
+	// The search stops at the workspace root.
+	workspaceDir := t.TempDir()
+	writeFile(t, filepath.Join(workspaceDir, WorkspaceManifestName), "packages:\n  - packages/*\n")
+	lockfileDir, err = findLockfileDir(workspaceDir)
+	require.NoError(t, err)
+	assert.Empty(t, lockfileDir)
+}
+
+func writeLockfile(t *testing.T, content string) string {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, LockfileName), content)
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func toDependenciesMap(dependencies []buildinfo.Dependency) map[string]buildinfo.Dependency {
+	dependenciesMap := make(map[string]buildinfo.Dependency)
+	for _, dependency := range dependencies {
+		dependenciesMap[dependency.Id] = dependency
+	}
+	return dependenciesMap
+}
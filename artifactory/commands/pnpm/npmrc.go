@@ -0,0 +1,70 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	npmrcFileName       = ".npmrc"
+	npmrcBackupFileName = "jfrog.npmrc.backup"
+)
+
+// Replaces the .npmrc file of the project with a temporary one, which sets the Artifactory npm repository as the registry.
+// The rest of the project configuration is kept, and the scoped registries are also resolved from Artifactory.
+// Returns a function which restores the original .npmrc file.
+func createTempNpmrc(workingDir, registry, npmAuth string) (restoreFunc func() error, err error) {
+	npmrcPath := filepath.Join(workingDir, npmrcFileName)
+	var originalContent []byte
+	if exists, err := isFileExists(npmrcPath); err != nil {
+		return nil, err
+	} else if exists {
+		if originalContent, err = os.ReadFile(npmrcPath); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	if restoreFunc, err = ioutils.BackupFile(npmrcPath, npmrcBackupFileName); err != nil {
+		return nil, err
+	}
+	log.Debug("Creating temporary .npmrc file.")
+	if err = os.WriteFile(npmrcPath, []byte(prepareNpmrc(string(originalContent), registry, npmAuth)), 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return restoreFunc, nil
+}
+
+// Creates the content of the temporary .npmrc file from the original project configuration.
+// The registry, scoped registries and registry credentials of the original configuration are replaced.
+func prepareNpmrc(originalContent, registry, npmAuth string) string {
+	registry = strings.TrimSuffix(registry, "/") + "/"
+	// The credentials are scoped to the registry, in the form of //<registry host and path>/:<key>.
+	registryWithoutProtocol := registry[strings.Index(registry, "://")+1:]
+	var npmrc strings.Builder
+	for _, line := range strings.Split(originalContent, "\n") {
+		key, _, _ := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		switch {
+		case key == "registry" || strings.HasPrefix(key, "//") || key == commandUtils.NpmConfigAuthKey || key == commandUtils.NpmConfigAuthTokenKey:
+			continue
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			npmrc.WriteString(key + "=" + registry + "\n")
+		case strings.TrimSpace(line) != "":
+			npmrc.WriteString(strings.TrimRight(line, "\r") + "\n")
+		}
+	}
+	npmrc.WriteString("registry=" + registry + "\n")
+	for _, line := range strings.Split(npmAuth, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if found && (key == commandUtils.NpmConfigAuthKey || key == commandUtils.NpmConfigAuthTokenKey) {
+			npmrc.WriteString(registryWithoutProtocol + ":" + key + "=" + value + "\n")
+		}
+	}
+	return npmrc.String()
+}
@@ -0,0 +1,36 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareNpmrc(t *testing.T) {
+	original := "registry=https://registry.npmjs.org/\n@acme:registry=https://npm.acme.com/\n//npm.acme.com/:_authToken=secret\nauto-install-peers=true\r\n\nstrict-peer-dependencies = false\n"
+	npmrc := prepareNpmrc(original, "https://acme.jfrog.io/artifactory/api/npm/npm-virtual", "_authToken = token\nalways-auth = true")
+	assert.Equal(t, "@acme:registry=https://acme.jfrog.io/artifactory/api/npm/npm-virtual/\n"+
+		"auto-install-peers=true\n"+
+		"strict-peer-dependencies = false\n"+
+		"registry=https://acme.jfrog.io/artifactory/api/npm/npm-virtual/\n"+
+		"//acme.jfrog.io/artifactory/api/npm/npm-virtual/:_authToken=token\n", npmrc)
+}
+
+func TestCreateTempNpmrc(t *testing.T) {
+	dir := t.TempDir()
+	npmrcPath := filepath.Join(dir, npmrcFileName)
+	writeFile(t, npmrcPath, "registry=https://registry.npmjs.org/\n")
+	restoreFunc, err := createTempNpmrc(dir, "https://acme.jfrog.io/artifactory/api/npm/npm-virtual", "_auth = dXNlcjpwYXNz")
+	require.NoError(t, err)
+	content, err := os.ReadFile(npmrcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "registry=https://acme.jfrog.io/artifactory/api/npm/npm-virtual/\n//acme.jfrog.io/artifactory/api/npm/npm-virtual/:_auth=dXNlcjpwYXNz\n", string(content))
+
+	require.NoError(t, restoreFunc())
+	content, err = os.ReadFile(npmrcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "registry=https://registry.npmjs.org/\n", string(content))
+}
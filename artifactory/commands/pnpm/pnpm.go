@@ -0,0 +1,262 @@
+package pnpm
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const executableName = "pnpm"
+
+// The pnpm commands which resolve the dependencies of the project and update the lockfile.
+var installCommands = map[string]bool{"install": true, "i": true, "add": true, "update": true, "up": true, "upgrade": true}
+
+// Runs any pnpm command, resolving the dependencies from an Artifactory npm repository.
+// When running an install command, the dependencies are collected from the pnpm-lock.yaml file into the build-info.
+type PnpmCommand struct {
+	cmdName            string
+	configFilePath     string
+	pnpmArgs           []string
+	repo               string
+	workingDirectory   string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewPnpmCommand() *PnpmCommand {
+	return &PnpmCommand{}
+}
+
+func (pc *PnpmCommand) SetCmdName(cmdName string) *PnpmCommand {
+	pc.cmdName = cmdName
+	return pc
+}
+
+func (pc *PnpmCommand) SetConfigFilePath(configFilePath string) *PnpmCommand {
+	pc.configFilePath = configFilePath
+	return pc
+}
+
+func (pc *PnpmCommand) SetArgs(args []string) *PnpmCommand {
+	pc.pnpmArgs = args
+	return pc
+}
+
+func (pc *PnpmCommand) SetServerDetails(serverDetails *config.ServerDetails) *PnpmCommand {
+	pc.serverDetails = serverDetails
+	return pc
+}
+
+func (pc *PnpmCommand) SetRepo(repo string) *PnpmCommand {
+	pc.repo = repo
+	return pc
+}
+
+func (pc *PnpmCommand) CommandName() string {
+	return "rt_pnpm_" + pc.cmdName
+}
+
+func (pc *PnpmCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the JFrog CLI options from the pnpm arguments.
+func (pc *PnpmCommand) Init() error {
+	_, _, _, filteredArgs, buildConfiguration, err := commandUtils.ExtractNpmOptionsFromArgs(pc.pnpmArgs)
+	if err != nil {
+		return err
+	}
+	pc.pnpmArgs = filteredArgs
+	pc.buildConfiguration = buildConfiguration
+	log.Debug("Preparing to read the config file", pc.configFilePath)
+	vConfig, err := project.ReadConfigFile(pc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(pc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := resolverParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	pc.SetServerDetails(serverDetails).SetRepo(resolverParams.TargetRepo())
+	return nil
+}
+
+func (pc *PnpmCommand) Run() (err error) {
+	log.Info("Running pnpm " + pc.cmdName + ".")
+	if pc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	restoreNpmrcFunc, err := pc.prepareNpmrc()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, restoreNpmrcFunc())
+	}()
+	if err = runPnpm(pc.workingDirectory, append([]string{pc.cmdName}, pc.pnpmArgs...)...); err != nil {
+		return err
+	}
+	if !installCommands[pc.cmdName] {
+		return nil
+	}
+	collectBuildInfo, err := pc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	if err = pc.collectDependencies(); err != nil {
+		return err
+	}
+	log.Info("pnpm " + pc.cmdName + " finished successfully.")
+	return nil
+}
+
+func (pc *PnpmCommand) prepareNpmrc() (restoreFunc func() error, err error) {
+	authArtDetails, err := pc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	if authArtDetails.GetSshAuthHeaders() != nil {
+		return nil, errorutils.CheckErrorf("SSH authentication is not supported in this command")
+	}
+	npmAuth, registry, err := commandUtils.GetArtifactoryNpmRepoDetails(pc.repo, authArtDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	return createTempNpmrc(pc.workingDirectory, registry, npmAuth)
+}
+
+// Collects the dependencies of the project, or of all projects in the workspace, from the lockfile.
+// Each project of the workspace is saved as a separate module, unless a module name was provided.
+func (pc *PnpmCommand) collectDependencies() error {
+	lockfileDir, err := findLockfileDir(pc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	if lockfileDir == "" {
+		return errorutils.CheckErrorf("%s was not found in %s or in its parent directories, and therefore the build-info dependencies can't be collected", LockfileName, pc.workingDirectory)
+	}
+	lockfile, err := readLockfile(filepath.Join(lockfileDir, LockfileName))
+	if err != nil {
+		return err
+	}
+	// When running inside a project of the workspace, only the current project is collected.
+	projectPath := ""
+	if lockfileDir != pc.workingDirectory {
+		if projectPath, err = filepath.Rel(lockfileDir, pc.workingDirectory); err != nil {
+			return errorutils.CheckError(err)
+		}
+		projectPath = filepath.ToSlash(projectPath)
+	}
+	modules, err := lockfile.getModules(lockfileDir, projectPath)
+	if err != nil {
+		return err
+	}
+	if err = pc.setChecksums(modules); err != nil {
+		return err
+	}
+
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := pc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	for _, module := range modules {
+		moduleId := module.id
+		if customModule := pc.buildConfiguration.GetModule(); customModule != "" {
+			moduleId = customModule
+		}
+		dependencies := module.dependencies
+		err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+			partial.ModuleId = moduleId
+			partial.ModuleType = buildinfo.Npm
+			partial.Dependencies = dependencies
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The checksums aren't included in the lockfile, and are therefore taken from Artifactory,
+// or from the build-info of the previous build if the dependency was already included in it.
+func (pc *PnpmCommand) setChecksums(modules []*lockfileModule) error {
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	previousBuildDependencies, err := commandUtils.GetDependenciesFromLatestBuild(servicesManager, buildName)
+	if err != nil {
+		return err
+	}
+	checksums := make(map[string]*buildinfo.Dependency)
+	var missingDependencies []string
+	for _, module := range modules {
+		for i := range module.dependencies {
+			dependency := &module.dependencies[i]
+			if found, checked := checksums[dependency.Id]; checked {
+				if found != nil {
+					dependency.Type = found.Type
+					dependency.Checksum = found.Checksum
+				}
+				continue
+			}
+			if found, err := collectChecksums(dependency, previousBuildDependencies, servicesManager); err != nil {
+				return err
+			} else if found {
+				checksums[dependency.Id] = dependency
+			} else {
+				checksums[dependency.Id] = nil
+				missingDependencies = append(missingDependencies, dependency.Id)
+			}
+		}
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return nil
+}
+
+func collectChecksums(dependency *buildinfo.Dependency, previousBuildDependencies map[string]*buildinfo.Dependency, servicesManager artifactory.ArtifactoryServicesManager) (bool, error) {
+	// The channel is buffered, since a missing dependency is also reported by the returned value.
+	missingDependenciesChan := make(chan string, 1)
+	return commandUtils.CreateCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDependenciesChan)(dependency)
+}
+
+func runPnpm(workingDir string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the pnpm executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Dir = workingDir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -0,0 +1,332 @@
+package pnpm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const distTagPropKey = "npm.disttag"
+
+// Packs the project with pnpm, or takes a provided tarball, and deploys it to an Artifactory npm repository.
+// Publishing all projects of a workspace recursively is not supported, and each project should be published separately.
+type PnpmPublishCommand struct {
+	configFilePath     string
+	pnpmArgs           []string
+	repo               string
+	distTag            string
+	workingDirectory   string
+	detailedSummary    bool
+	xrayScan           bool
+	scanOutputFormat   format.OutputFormat
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	result             *commandsutils.Result
+}
+
+func NewPnpmPublishCommand() *PnpmPublishCommand {
+	return &PnpmPublishCommand{result: new(commandsutils.Result)}
+}
+
+func (ppc *PnpmPublishCommand) SetConfigFilePath(configFilePath string) *PnpmPublishCommand {
+	ppc.configFilePath = configFilePath
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) SetArgs(args []string) *PnpmPublishCommand {
+	ppc.pnpmArgs = args
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *PnpmPublishCommand {
+	ppc.serverDetails = serverDetails
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) SetRepo(repo string) *PnpmPublishCommand {
+	ppc.repo = repo
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) SetDetailedSummary(detailedSummary bool) *PnpmPublishCommand {
+	ppc.detailedSummary = detailedSummary
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) IsDetailedSummary() bool {
+	return ppc.detailedSummary
+}
+
+func (ppc *PnpmPublishCommand) GetXrayScan() bool {
+	return ppc.xrayScan
+}
+
+func (ppc *PnpmPublishCommand) Result() *commandsutils.Result {
+	return ppc.result
+}
+
+func (ppc *PnpmPublishCommand) CommandName() string {
+	return "rt_pnpm_publish"
+}
+
+func (ppc *PnpmPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ppc.serverDetails, nil
+}
+
+// Reads the deployer configuration and extracts the JFrog CLI options from the pnpm arguments.
+func (ppc *PnpmPublishCommand) Init() error {
+	detailedSummary, xrayScan, scanOutputFormat, filteredArgs, buildConfiguration, err := commandsutils.ExtractNpmOptionsFromArgs(ppc.pnpmArgs)
+	if err != nil {
+		return err
+	}
+	filteredArgs, tag, err := coreutils.ExtractTagFromArgs(filteredArgs)
+	if err != nil {
+		return err
+	}
+	for _, arg := range filteredArgs {
+		if arg == "-r" || arg == "--recursive" {
+			return errorutils.CheckErrorf("publishing the projects of a workspace recursively is not supported. Run 'jf pnpm publish' from the directory of each project instead")
+		}
+	}
+	ppc.pnpmArgs = filteredArgs
+	ppc.buildConfiguration = buildConfiguration
+	ppc.detailedSummary, ppc.xrayScan, ppc.scanOutputFormat, ppc.distTag = detailedSummary, xrayScan, scanOutputFormat, tag
+	log.Debug("Preparing to read the config file", ppc.configFilePath)
+	vConfig, err := project.ReadConfigFile(ppc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	deployerParams, err := project.GetRepoConfigByPrefix(ppc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := deployerParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	ppc.SetServerDetails(serverDetails).SetRepo(deployerParams.TargetRepo())
+	return nil
+}
+
+func (ppc *PnpmPublishCommand) Run() (err error) {
+	log.Info("Running pnpm publish.")
+	if ppc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	artDetails, err := ppc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	if err = utils.ValidateRepoExists(ppc.repo, artDetails); err != nil {
+		return err
+	}
+	tarballPath, err := ppc.getTarballPath()
+	if err != nil {
+		return err
+	}
+	if tarballPath == "" {
+		var packDir string
+		if packDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(packDir))
+		}()
+		if tarballPath, err = ppc.pack(packDir); err != nil {
+			return err
+		}
+	}
+	packageInfo, err := readPackageInfoFromTarball(tarballPath)
+	if err != nil {
+		return err
+	}
+	if ppc.xrayScan {
+		// If a FailBuildError is returned by the scan, the package isn't deployed.
+		fileSpec := spec.NewBuilder().Pattern(tarballPath).Target(ppc.repo + "/").BuildSpec()
+		if err = commandsutils.ConditionalUploadScanFunc(ppc.serverDetails, fileSpec, 1, ppc.scanOutputFormat); err != nil {
+			return err
+		}
+	}
+	if err = ppc.deploy(tarballPath, packageInfo); err != nil {
+		return err
+	}
+	log.Info("pnpm publish finished successfully.")
+	return nil
+}
+
+// Returns the path of the tarball, if a tarball was provided as the first argument rather than a project directory.
+func (ppc *PnpmPublishCommand) getTarballPath() (string, error) {
+	if len(ppc.pnpmArgs) == 0 || strings.HasPrefix(ppc.pnpmArgs[0], "-") {
+		return "", nil
+	}
+	path := clientutils.ReplaceTildeWithUserHome(strings.TrimSpace(ppc.pnpmArgs[0]))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(ppc.workingDirectory, path)
+	}
+	isDir, err := fileutils.IsDirExists(path, false)
+	if err != nil || isDir {
+		// A project directory is packed by pnpm.
+		return "", err
+	}
+	log.Debug("The provided path is not a directory, we assume this is a compressed npm package")
+	return path, nil
+}
+
+// Packs the project into the provided directory, and returns the path of the created tarball.
+func (ppc *PnpmPublishCommand) pack(packDir string) (string, error) {
+	log.Debug("Creating npm package.")
+	args := []string{"pack", "--pack-destination", packDir}
+	if len(ppc.pnpmArgs) > 0 && !strings.HasPrefix(ppc.pnpmArgs[0], "-") {
+		// The project directory.
+		args = append(args, "--dir", ppc.pnpmArgs[0])
+	}
+	if err := runPnpm(ppc.workingDirectory, args...); err != nil {
+		return "", err
+	}
+	tarballs, err := filepath.Glob(filepath.Join(packDir, "*.tgz"))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if len(tarballs) != 1 {
+		return "", errorutils.CheckErrorf("expected pnpm pack to create a single package in %s, but found %d", packDir, len(tarballs))
+	}
+	return tarballs[0], nil
+}
+
+func (ppc *PnpmPublishCommand) deploy(tarballPath string, packageInfo *biutils.PackageInfo) (err error) {
+	collectBuildInfo, err := ppc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(ppc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	up := services.NewUploadParams()
+	up.CommonParams = &specutils.CommonParams{Pattern: tarballPath, Target: ppc.repo + "/" + packageInfo.GetDeployPath()}
+	if ppc.distTag != "" {
+		if up.TargetProps, err = specutils.ParseProperties(distTagPropKey + "=" + ppc.distTag); err != nil {
+			return err
+		}
+	}
+	var buildName, buildNumber, projectKey string
+	if collectBuildInfo {
+		if buildName, err = ppc.buildConfiguration.GetBuildName(); err != nil {
+			return err
+		}
+		if buildNumber, err = ppc.buildConfiguration.GetBuildNumber(); err != nil {
+			return err
+		}
+		projectKey = ppc.buildConfiguration.GetProject()
+		if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+			return err
+		}
+		if up.BuildProps, err = build.CreateBuildProperties(buildName, buildNumber, projectKey); err != nil {
+			return err
+		}
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, up)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close())
+	}()
+	if ppc.detailedSummary {
+		if err = ppc.setDetailedSummary(summary); err != nil {
+			return err
+		}
+	} else if err = summary.TransferDetailsReader.Close(); err != nil {
+		return err
+	}
+	// Only one package is deployed, so any failure fails the command.
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to upload the npm package to Artifactory. See Artifactory logs for more details")
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	moduleId := packageInfo.BuildInfoModuleId()
+	if customModule := ppc.buildConfiguration.GetModule(); customModule != "" {
+		moduleId = customModule
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+		partial.ModuleId = moduleId
+		partial.ModuleType = buildinfo.Npm
+		partial.Artifacts = artifacts
+	})
+}
+
+func (ppc *PnpmPublishCommand) setDetailedSummary(summary *specutils.OperationSummary) error {
+	ppc.result.SetFailCount(ppc.result.FailCount() + summary.TotalFailed)
+	ppc.result.SetSuccessCount(ppc.result.SuccessCount() + summary.TotalSucceeded)
+	if ppc.result.Reader() == nil {
+		ppc.result.SetReader(summary.TransferDetailsReader)
+		return nil
+	}
+	reader, err := content.MergeReaders([]*content.ContentReader{ppc.result.Reader(), summary.TransferDetailsReader}, content.DefaultKey)
+	if err != nil {
+		return err
+	}
+	ppc.result.SetReader(reader)
+	return nil
+}
+
+func readPackageInfoFromTarball(tarballPath string) (packageInfo *biutils.PackageInfo, err error) {
+	log.Debug("Extracting info from npm package:", tarballPath)
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(tarball.Close()))
+	}()
+	gzipReader, err := gzip.NewReader(tarball)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errorutils.CheckErrorf("could not find 'package.json' in the compressed npm package: %s", tarballPath)
+			}
+			return nil, errorutils.CheckError(err)
+		}
+		if header.Name == "package/package.json" {
+			packageJson, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			return biutils.ReadPackageInfo(packageJson, nil)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package ruby
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The gem and bundle commands share the gem configuration.
+	ToolName = "gem"
+
+	ModuleType buildinfo.ModuleType = "gem"
+
+	defaultSource = "https://rubygems.org/"
+)
+
+// The bundle commands which resolve the gems of the Gemfile.
+var resolveCommands = []string{"install", "update", "lock"}
+
+// Runs a bundle command, which resolves the gems from an Artifactory RubyGems repository instead of rubygems.org.
+// Bundler is configured by environment variables to use the repository as a mirror of rubygems.org, with the credentials of the server.
+// The gems resolved by install, update and lock are collected from the Gemfile.lock file into the build-info.
+type BundleCommand struct {
+	cmdName            string
+	configFilePath     string
+	bundleArgs         []string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewBundleCommand() *BundleCommand {
+	return &BundleCommand{}
+}
+
+func (bc *BundleCommand) SetCmdName(cmdName string) *BundleCommand {
+	bc.cmdName = cmdName
+	return bc
+}
+
+func (bc *BundleCommand) SetConfigFilePath(configFilePath string) *BundleCommand {
+	bc.configFilePath = configFilePath
+	return bc
+}
+
+func (bc *BundleCommand) SetArgs(args []string) *BundleCommand {
+	bc.bundleArgs = args
+	return bc
+}
+
+func (bc *BundleCommand) CommandName() string {
+	return "rt_bundle_" + bc.cmdName
+}
+
+func (bc *BundleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bc.serverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the build-info options from the bundle arguments.
+func (bc *BundleCommand) Init() (err error) {
+	if bc.bundleArgs, bc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(bc.bundleArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(bc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig == nil {
+		return errorutils.CheckErrorf("the resolver repository is missing from the config file (%s). Please run 'jf gem-config' with the --repo-resolve option", bc.configFilePath)
+	}
+	if bc.serverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	bc.repo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (bc *BundleCommand) Run() error {
+	env, err := bundlerEnv(bc.serverDetails, bc.repo)
+	if err != nil {
+		return err
+	}
+	if err = runRubyTool("bundle", env, append([]string{bc.cmdName}, bc.bundleArgs...)...); err != nil {
+		return err
+	}
+	if !isResolveCommand(bc.cmdName) {
+		return nil
+	}
+	collectBuildInfo, err := bc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return bc.collectDependencies()
+}
+
+func isResolveCommand(cmdName string) bool {
+	for _, resolveCommand := range resolveCommands {
+		if cmdName == resolveCommand {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the environment variables of the rubygems.org mirror and its credentials.
+// Bundler reads its settings from BUNDLE_<KEY> variables, in which the dots of the key are replaced by '__', and hyphens by '___'.
+func bundlerEnv(serverDetails *config.ServerDetails, repo string) ([]string, error) {
+	mirrorUrl := repositoryUrl(serverDetails, repo) + "/"
+	env := []string{bundlerSettingEnv("mirror."+defaultSource) + "=" + mirrorUrl}
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil || password == "" {
+		return env, err
+	}
+	parsedUrl, err := url.Parse(mirrorUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return append(env, bundlerSettingEnv(parsedUrl.Host)+"="+username+":"+password), nil
+}
+
+func bundlerSettingEnv(key string) string {
+	key = strings.ReplaceAll(key, ".", "__")
+	key = strings.ReplaceAll(key, "-", "___")
+	return "BUNDLE_" + strings.ToUpper(key)
+}
+
+// Returns the URL of an Artifactory RubyGems repository.
+func repositoryUrl(serverDetails *config.ServerDetails, repo string) string {
+	return strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/gems/" + repo
+}
+
+// Collects the gems of the Gemfile.lock file. The project gem, or the project directory, is the build-info module.
+func (bc *BundleCommand) collectDependencies() error {
+	lockfileDir, exists, err := fileutils.FindUpstream(LockfileName, fileutils.File)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Warn(LockfileName + " was not found, and therefore the dependencies are not included in the build-info.")
+		return nil
+	}
+	content, err := os.ReadFile(filepath.Join(lockfileDir, LockfileName))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockfile, err := parseLockfile(content)
+	if err != nil {
+		return err
+	}
+	moduleId := lockfile.moduleId(filepath.Base(lockfileDir))
+	return buildinfoutils.SaveDependencies(bc.buildConfiguration, moduleId, ModuleType, lockfile.getDependencies(moduleId))
+}
+
+func runRubyTool(executableName string, env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the %s executable in the system PATH: %s", executableName, err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -0,0 +1,24 @@
+package ruby
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundlerEnv(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme-prod.jfrog.io/artifactory/", User: "user", Password: "pass"}
+	env, err := bundlerEnv(serverDetails, "gems-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"BUNDLE_MIRROR__HTTPS://RUBYGEMS__ORG/=https://acme-prod.jfrog.io/artifactory/api/gems/gems-virtual/",
+		"BUNDLE_ACME___PROD__JFROG__IO=user:pass",
+	}, env)
+
+	// Without credentials, only the mirror is configured.
+	env, err = bundlerEnv(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, "gems-virtual")
+	require.NoError(t, err)
+	assert.Len(t, env, 1)
+}
@@ -0,0 +1,182 @@
+package ruby
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// The metadata file in a gem archive.
+const gemMetadataFileName = "metadata.gz"
+
+// Pushes a gem to the deployer RubyGems repository with 'gem push', and records it as an artifact in the build-info.
+// If another host is requested, the gem is pushed to it without collecting build-info.
+type GemPushCommand struct {
+	configFilePath     string
+	gemArgs            []string
+	gemPath            string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewGemPushCommand() *GemPushCommand {
+	return &GemPushCommand{}
+}
+
+func (gpc *GemPushCommand) SetConfigFilePath(configFilePath string) *GemPushCommand {
+	gpc.configFilePath = configFilePath
+	return gpc
+}
+
+func (gpc *GemPushCommand) SetArgs(args []string) *GemPushCommand {
+	gpc.gemArgs = args
+	return gpc
+}
+
+func (gpc *GemPushCommand) CommandName() string {
+	return "rt_gem_push"
+}
+
+func (gpc *GemPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gpc.serverDetails, nil
+}
+
+// Reads the deployer configuration and the gem path from the gem arguments.
+func (gpc *GemPushCommand) Init() (err error) {
+	if gpc.gemArgs, gpc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(gpc.gemArgs); err != nil {
+		return err
+	}
+	for _, arg := range gpc.gemArgs {
+		if strings.HasSuffix(arg, ".gem") && !strings.HasPrefix(arg, "-") {
+			gpc.gemPath = arg
+			break
+		}
+	}
+	if gpc.gemPath == "" {
+		return errorutils.CheckErrorf("the path of the gem is missing. Usage: jf gem push <gem>")
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(gpc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig == nil {
+		return errorutils.CheckErrorf("the deployer repository is missing from the config file (%s). Please run 'jf gem-config' with the --repo-deploy option", gpc.configFilePath)
+	}
+	if gpc.serverDetails, err = deployerConfig.ServerDetails(); err != nil {
+		return err
+	}
+	gpc.repo = deployerConfig.TargetRepo()
+	return nil
+}
+
+func (gpc *GemPushCommand) Run() error {
+	args := append([]string{"push"}, gpc.gemArgs...)
+	if hasFlag(gpc.gemArgs, "--host") {
+		return runRubyTool("gem", nil, args...)
+	}
+	apiKey, err := gemHostApiKey(gpc.serverDetails)
+	if err != nil {
+		return err
+	}
+	var env []string
+	if apiKey != "" {
+		env = append(env, "GEM_HOST_API_KEY="+apiKey)
+	}
+	if err = runRubyTool("gem", env, append(args, "--host", repositoryUrl(gpc.serverDetails, gpc.repo))...); err != nil {
+		return err
+	}
+	collectBuildInfo, err := gpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	spec, err := readGemSpec(gpc.gemPath)
+	if err != nil {
+		return err
+	}
+	// The gems are stored in the repository under gems/<name>-<version>[-<platform>].gem.
+	artifacts, err := buildinfoutils.GetDeployedArtifacts(gpc.serverDetails, gpc.buildConfiguration, gpc.repo, "gems/"+filepath.Base(gpc.gemPath))
+	if err != nil {
+		return err
+	}
+	for i := range artifacts {
+		artifacts[i].Type = gemType
+	}
+	return buildinfoutils.SaveArtifacts(gpc.buildConfiguration, spec.id(), ModuleType, artifacts)
+}
+
+// The API key is sent as is in the Authorization header by 'gem push', and Artifactory accepts the access token or the API key of the user.
+func gemHostApiKey(serverDetails *config.ServerDetails) (string, error) {
+	_, password, err := projectconfig.GetCredentials(serverDetails)
+	return password, err
+}
+
+func hasFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// The specification of a gem, from the metadata of the gem archive.
+type gemSpec struct {
+	Name    string `yaml:"name"`
+	Version struct {
+		Version string `yaml:"version"`
+	} `yaml:"version"`
+}
+
+func (gs *gemSpec) id() string {
+	return gs.Name + ":" + gs.Version.Version
+}
+
+// Reads the specification of a gem. The gem is a tar archive, which includes the gzipped YAML specification in metadata.gz.
+func readGemSpec(gemPath string) (spec *gemSpec, err error) {
+	gemFile, err := os.Open(gemPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(gemFile.Close()))
+	}()
+	tarReader := tar.NewReader(gemFile)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, errorutils.CheckErrorf("could not find %s in the gem: %s", gemMetadataFileName, gemPath)
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if header.Name != gemMetadataFileName {
+			continue
+		}
+		gzipReader, err := gzip.NewReader(tarReader)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		metadata, err := io.ReadAll(gzipReader)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		spec = new(gemSpec)
+		if err = yaml.Unmarshal(metadata, spec); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the specification of the gem %s: %s", gemPath, err.Error())
+		}
+		return spec, nil
+	}
+}
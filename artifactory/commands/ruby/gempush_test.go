@@ -0,0 +1,55 @@
+package ruby
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGemSpec(t *testing.T) {
+	metadata := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(metadata)
+	_, err := gzipWriter.Write([]byte(`--- !ruby/object:Gem::Specification
+name: mygem
+version: !ruby/object:Gem::Version
+  version: 0.3.0
+platform: ruby
+authors:
+- Acme
+`))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	gem := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(gem)
+	for name, content := range map[string][]byte{gemMetadataFileName: metadata.Bytes(), "data.tar.gz": []byte("data")} {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err = tarWriter.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	gemPath := filepath.Join(t.TempDir(), "mygem-0.3.0.gem")
+	require.NoError(t, os.WriteFile(gemPath, gem.Bytes(), 0644))
+
+	spec, err := readGemSpec(gemPath)
+	require.NoError(t, err)
+	assert.Equal(t, "mygem:0.3.0", spec.id())
+}
+
+func TestGemHostApiKey(t *testing.T) {
+	// The key is sent without an authentication scheme.
+	apiKey, err := gemHostApiKey(&config.ServerDetails{User: "user", AccessToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "token", apiKey)
+
+	apiKey, err = gemHostApiKey(&config.ServerDetails{User: "user", Password: "api-key"})
+	require.NoError(t, err)
+	assert.Equal(t, "api-key", apiKey)
+}
@@ -0,0 +1,140 @@
+package ruby
+
+import (
+	"bufio"
+	"bytes"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	LockfileName = "Gemfile.lock"
+	gemType      = "gem"
+)
+
+// A gem in the lockfile, and the names of the gems it depends on.
+type lockedGem struct {
+	name         string
+	version      string
+	sha256       string
+	dependencies []string
+}
+
+func (lg *lockedGem) id() string {
+	return lg.name + ":" + lg.version
+}
+
+// The Gemfile.lock file. The sections are separated by empty lines, and their content is indented:
+//
+//	GEM
+//	  remote: https://rubygems.org/
+//	  specs:
+//	    rack (2.2.8)
+//	    rails (7.1.2)
+//	      rack (>= 2.2.4)
+//
+//	DEPENDENCIES
+//	  rails (~> 7.1)
+type gemfileLock struct {
+	// The gems of the GEM, GIT and PATH sections, by name.
+	gems map[string]*lockedGem
+	// The gem of the project itself, which is the local gem of a PATH section with the current directory as its remote.
+	project *lockedGem
+	// The names of the gems in the Gemfile.
+	direct []string
+}
+
+func parseLockfile(content []byte) (*gemfileLock, error) {
+	lock := &gemfileLock{gems: make(map[string]*lockedGem)}
+	var section, remote string
+	var current *lockedGem
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case trimmed == "":
+			continue
+		case indent == 0:
+			section, remote, current = trimmed, "", nil
+		case section == "GEM" || section == "GIT" || section == "PATH":
+			switch {
+			case indent == 2 && strings.HasPrefix(trimmed, "remote:"):
+				remote = strings.TrimSpace(strings.TrimPrefix(trimmed, "remote:"))
+			case indent == 4:
+				name, version := parseSpec(trimmed)
+				current = &lockedGem{name: name, version: version}
+				lock.gems[name] = current
+				if section == "PATH" && remote == "." {
+					lock.project = current
+				}
+			case indent == 6 && current != nil:
+				name, _ := parseSpec(trimmed)
+				current.dependencies = append(current.dependencies, name)
+			}
+		case section == "DEPENDENCIES" && indent == 2:
+			name, _ := parseSpec(trimmed)
+			lock.direct = append(lock.direct, strings.TrimSuffix(name, "!"))
+		case section == "CHECKSUMS" && indent == 2:
+			// Each checksum line looks like: rack (2.2.8) sha256=<checksum>
+			spec, checksum, _ := strings.Cut(trimmed, ") ")
+			name, _ := parseSpec(spec + ")")
+			if gem := lock.gems[name]; gem != nil {
+				gem.sha256 = strings.TrimPrefix(checksum, "sha256=")
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	return lock, nil
+}
+
+// Returns the module ID of the project gem, or the default module ID if the project isn't a gem.
+func (gl *gemfileLock) moduleId(defaultModuleId string) string {
+	if gl.project != nil {
+		return gl.project.id()
+	}
+	return defaultModuleId
+}
+
+// Parses a 'name (version)' spec. Version requirements, such as 'rack (>= 2.2.4)', are returned as the version.
+func parseSpec(spec string) (name, version string) {
+	name, version, _ = strings.Cut(spec, " ")
+	return name, strings.TrimSuffix(strings.TrimPrefix(version, "("), ")")
+}
+
+// Walks the dependency graph from the gems in the Gemfile.
+// Each dependency is requested by the shortest path to the module, through each of its dependents.
+func (gl *gemfileLock) getDependencies(moduleId string) []buildinfo.Dependency {
+	walker := buildinfoutils.NewDependencyWalker(func(gem *lockedGem) buildinfo.Dependency {
+		dependency := buildinfo.Dependency{Id: gem.id(), Type: gemType}
+		if gem.sha256 != "" {
+			dependency.Checksum = buildinfo.Checksum{Sha256: gem.sha256}
+		}
+		return dependency
+	})
+	visit := func(names []string, pathToRoot []string) {
+		for _, name := range names {
+			if gem := gl.gems[name]; gem != nil && gem != gl.project {
+				walker.Visit(gem, pathToRoot)
+			}
+		}
+	}
+	direct := append([]string{}, gl.direct...)
+	if gl.project != nil {
+		// The runtime dependencies of the gem are included in the Gemfile through the gemspec.
+		direct = append(direct, gl.project.dependencies...)
+	}
+	sort.Strings(direct)
+	visit(direct, []string{moduleId})
+	walker.Walk(func(gem *lockedGem, pathToRoot []string) {
+		visit(gem.dependencies, pathToRoot)
+	})
+	return walker.Dependencies()
+}
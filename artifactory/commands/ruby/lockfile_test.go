@@ -0,0 +1,70 @@
+package ruby
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockfile = `PATH
+  remote: .
+  specs:
+    mygem (0.3.0)
+      faraday (~> 2.7)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    faraday (2.7.12)
+      base64
+      faraday-net_http (>= 2.0, < 3.1)
+    faraday-net_http (3.0.2)
+    base64 (0.2.0)
+    rake (13.1.0)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  mygem!
+  rake (~> 13.0)
+
+CHECKSUMS
+  base64 (0.2.0) sha256=01b1ea4c2d3d6e9a46d3a4ae4d2b2b5e8a1b1e9a3f6f0c2e7a8b5d4c3e2f1a0b
+  rake (13.1.0) sha256=a9c3b1a9e4e2d2d0f3b7e6c5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5
+
+BUNDLED WITH
+   2.5.3
+`
+
+func TestParseLockfile(t *testing.T) {
+	lockfile, err := parseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	moduleId := lockfile.moduleId("project")
+	assert.Equal(t, "mygem:0.3.0", moduleId)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "faraday:2.7.12", Type: gemType, RequestedBy: [][]string{{"mygem:0.3.0"}}},
+		{Id: "rake:13.1.0", Type: gemType, Checksum: buildinfo.Checksum{Sha256: "a9c3b1a9e4e2d2d0f3b7e6c5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5"}, RequestedBy: [][]string{{"mygem:0.3.0"}}},
+		{Id: "base64:0.2.0", Type: gemType, Checksum: buildinfo.Checksum{Sha256: "01b1ea4c2d3d6e9a46d3a4ae4d2b2b5e8a1b1e9a3f6f0c2e7a8b5d4c3e2f1a0b"}, RequestedBy: [][]string{{"faraday:2.7.12", "mygem:0.3.0"}}},
+		{Id: "faraday-net_http:3.0.2", Type: gemType, RequestedBy: [][]string{{"faraday:2.7.12", "mygem:0.3.0"}}},
+	}, lockfile.getDependencies(moduleId))
+}
+
+func TestParseLockfileOfApplication(t *testing.T) {
+	lockfile, err := parseLockfile([]byte(`GEM
+  remote: https://rubygems.org/
+  specs:
+    rack (3.0.8)
+
+PLATFORMS
+  ruby
+
+DEPENDENCIES
+  rack
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "app", lockfile.moduleId("app"))
+	assert.Equal(t, []buildinfo.Dependency{{Id: "rack:3.0.8", Type: gemType, RequestedBy: [][]string{{"app"}}}}, lockfile.getDependencies("app"))
+}
@@ -0,0 +1,107 @@
+package sbt
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The extension of the update reports, which are written by the update task of each project.
+const reportExtension = ".tsv"
+
+// The update report of a project. The first line of the report describes the project:
+//
+//	<organization>	<artifact name>	<version>	<publish / skip>
+//
+// It is followed by a line for each artifact of the resolved modules of each configuration:
+//
+//	<configuration>	<organization>	<name>	<revision>	<extension>	<path>
+type projectReport struct {
+	organization string
+	artifactName string
+	version      string
+	skipPublish  bool
+	dependencies []buildinfo.Dependency
+}
+
+func (pr *projectReport) id() string {
+	return pr.organization + ":" + pr.artifactName + ":" + pr.version
+}
+
+// Returns the path of the published files of the project in a Maven repository.
+func (pr *projectReport) publishedPath() string {
+	return strings.ReplaceAll(pr.organization, ".", "/") + "/" + pr.artifactName + "/" + pr.version + "/*"
+}
+
+// Reads the update reports in the directory, sorted by the project ID.
+func readReports(reportsDir string) ([]*projectReport, error) {
+	paths, err := fileutils.ListFiles(reportsDir, false)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	var reports []*projectReport
+	for _, path := range paths {
+		if filepath.Ext(path) != reportExtension {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		report, err := parseReport(content)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the update report %s: %s", path, err.Error())
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Parses an update report. Each artifact is a dependency, with the configurations which resolved it as its scopes.
+func parseReport(content []byte) (*projectReport, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	if !scanner.Scan() {
+		return nil, errorutils.CheckErrorf("the report is empty")
+	}
+	fields := strings.Split(scanner.Text(), "\t")
+	if len(fields) != 4 {
+		return nil, errorutils.CheckErrorf("unexpected project line: %s", scanner.Text())
+	}
+	report := &projectReport{organization: fields[0], artifactName: fields[1], version: fields[2], skipPublish: fields[3] == "true"}
+	indexes := make(map[string]int)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		fields = strings.Split(scanner.Text(), "\t")
+		if len(fields) != 6 {
+			return nil, errorutils.CheckErrorf("unexpected artifact line: %s", scanner.Text())
+		}
+		configuration, id, extension, path := fields[0], strings.Join(fields[1:4], ":"), fields[4], fields[5]
+		key := id + ":" + path
+		if index, exists := indexes[key]; exists {
+			report.dependencies[index].Scopes = append(report.dependencies[index].Scopes, configuration)
+			continue
+		}
+		details, err := fileutils.GetFileDetails(path, true)
+		if err != nil {
+			return nil, err
+		}
+		indexes[key] = len(report.dependencies)
+		report.dependencies = append(report.dependencies, buildinfo.Dependency{
+			Id:       id,
+			Type:     extension,
+			Scopes:   []string{configuration},
+			Checksum: buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5, Sha256: details.Checksum.Sha256},
+		})
+	}
+	return report, errorutils.CheckError(scanner.Err())
+}
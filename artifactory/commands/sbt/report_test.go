@@ -0,0 +1,51 @@
+package sbt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReports(t *testing.T) {
+	tempDir := t.TempDir()
+	jarPath := filepath.Join(tempDir, "cats-core_2.13-2.12.0.jar")
+	require.NoError(t, os.WriteFile(jarPath, []byte("redis"), 0644))
+	reportsDir := filepath.Join(tempDir, "reports")
+	require.NoError(t, os.Mkdir(reportsDir, 0755))
+	report := "com.acme\tservice_2.13\t1.0.0\tfalse\n" +
+		"compile\torg.typelevel\tcats-core_2.13\t2.12.0\tjar\t" + jarPath + "\n" +
+		"runtime\torg.typelevel\tcats-core_2.13\t2.12.0\tjar\t" + jarPath + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(reportsDir, "service"+reportExtension), []byte(report), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(reportsDir, "root"+reportExtension), []byte("com.acme\troot_2.13\t1.0.0\ttrue\n"), 0644))
+
+	reports, err := readReports(reportsDir)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "com.acme:root_2.13:1.0.0", reports[0].id())
+	assert.True(t, reports[0].skipPublish)
+	assert.Empty(t, reports[0].dependencies)
+
+	assert.Equal(t, "com.acme:service_2.13:1.0.0", reports[1].id())
+	assert.Equal(t, "com/acme/service_2.13/1.0.0/*", reports[1].publishedPath())
+	assert.Equal(t, []buildinfo.Dependency{{
+		Id:     "org.typelevel:cats-core_2.13:2.12.0",
+		Type:   "jar",
+		Scopes: []string{"compile", "runtime"},
+		Checksum: buildinfo.Checksum{
+			Sha1:   "b840fc02d524045429941cc15f59e41cb7be6c52",
+			Md5:    "86a1b907d54bf7010394bf316e183e67",
+			Sha256: "34fb46c847bb9df96e5205a39d382f648a6e8dce1e014cd85b4ca6a88d88ed03",
+		},
+	}}, reports[1].dependencies)
+}
+
+func TestParseReportErrors(t *testing.T) {
+	_, err := parseReport(nil)
+	assert.Error(t, err)
+	_, err = parseReport([]byte("com.acme\tservice_2.13\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package sbt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	repositoriesFileName = "repositories"
+	// The realm of the basic authentication challenge of Artifactory, which sbt matches to the credentials.
+	artifactoryRealm = "Artifactory Realm"
+	// The layout of Ivy repositories which host sbt plugins and modules published by sbt.
+	ivyPattern = "[organization]/[module]/(scala_[scalaVersion]/)(sbt_[sbtVersion]/)[revision]/[type]s/[artifact](-[classifier]).[ext]"
+)
+
+// Returns the URL of an Artifactory Maven repository.
+func repositoryUrl(serverDetails *config.ServerDetails, repo string) string {
+	return strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/" + repo + "/"
+}
+
+// Writes the repositories file of sbt, which replaces the resolvers of the launcher and of the build by the local Ivy repository and the repository.
+// The repository is resolved in both the Maven and the Ivy layouts.
+func writeRepositoriesFile(repositoriesPath, repoUrl string) error {
+	content := fmt.Sprintf("[repositories]\n  local\n  jfrog: %s\n  jfrog-ivy: %s, %s\n", repoUrl, repoUrl, ivyPattern)
+	return errorutils.CheckError(os.WriteFile(repositoriesPath, []byte(content), 0644))
+}
+
+// Writes a credentials file of sbt with the credentials of the server, or returns false if the server has no credentials.
+func writeCredentialsFile(credentialsPath, host string, serverDetails *config.ServerDetails) (bool, error) {
+	username, password, err := projectconfig.GetCredentials(serverDetails)
+	if err != nil || password == "" {
+		return false, err
+	}
+	content := fmt.Sprintf("realm=%s\nhost=%s\nuser=%s\npassword=%s\n", artifactoryRealm, host, username, password)
+	return true, errorutils.CheckError(os.WriteFile(credentialsPath, []byte(content), 0600))
+}
@@ -0,0 +1,288 @@
+package sbt
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ToolName       = "sbt"
+	executableName = "sbt"
+
+	ModuleType buildinfo.ModuleType = "sbt"
+)
+
+// Writes the update report of each project into the reports directory, after its update task resolves the modules.
+const writeReportsCommand = "set every update := { " +
+	"val report = update.value; " +
+	"val artifactName = CrossVersion(projectID.value.crossVersion, scalaVersion.value, scalaBinaryVersion.value).fold(moduleName.value)(_(moduleName.value)); " +
+	"val projectLine = Seq(organization.value, artifactName, version.value, (publish / skip).value.toString).mkString(\"\\t\"); " +
+	"val artifactLines = for { configuration <- report.configurations; module <- configuration.modules if !module.evicted; (artifact, file) <- module.artifacts } " +
+	"yield Seq(configuration.configuration.name, module.module.organization, module.module.name, module.module.revision, artifact.extension, file.getAbsolutePath).mkString(\"\\t\"); " +
+	"IO.writeLines(new java.io.File(%q, thisProject.value.id + %q), projectLine +: artifactLines); " +
+	"report }"
+
+// Runs sbt with the resolver repository replacing the resolvers of the build, and the deployer repository as the target of the publish task.
+// The repositories and their credentials are passed to sbt by system properties and 'set' commands, so that the build isn't modified.
+// The resolved modules of each project are collected from its update report into the build-info, and the published files are recorded as artifacts.
+type SbtCommand struct {
+	configFilePath     string
+	sbtArgs            []string
+	resolverDetails    *config.ServerDetails
+	resolverRepo       string
+	deployerDetails    *config.ServerDetails
+	deployerRepo       string
+	detailedSummary    bool
+	buildConfiguration *build.BuildConfiguration
+	result             *commandsutils.Result
+}
+
+func NewSbtCommand() *SbtCommand {
+	return &SbtCommand{result: new(commandsutils.Result)}
+}
+
+func (sc *SbtCommand) SetConfigFilePath(configFilePath string) *SbtCommand {
+	sc.configFilePath = configFilePath
+	return sc
+}
+
+func (sc *SbtCommand) SetArgs(args []string) *SbtCommand {
+	sc.sbtArgs = args
+	return sc
+}
+
+func (sc *SbtCommand) IsDetailedSummary() bool {
+	return sc.detailedSummary
+}
+
+func (sc *SbtCommand) SetDetailedSummary(detailedSummary bool) *SbtCommand {
+	sc.detailedSummary = detailedSummary
+	return sc
+}
+
+func (sc *SbtCommand) Result() *commandsutils.Result {
+	return sc.result
+}
+
+// Returns true if the tasks publish to the deployer repository.
+func (sc *SbtCommand) IsPublish() bool {
+	return sc.deployerDetails != nil && isPublish(sc.sbtArgs)
+}
+
+func (sc *SbtCommand) CommandName() string {
+	return "rt_sbt"
+}
+
+func (sc *SbtCommand) ServerDetails() (*config.ServerDetails, error) {
+	if sc.deployerDetails != nil {
+		return sc.deployerDetails, nil
+	}
+	return sc.resolverDetails, nil
+}
+
+// Reads the resolver and deployer configuration and extracts the build-info and summary options from the sbt arguments.
+func (sc *SbtCommand) Init() (err error) {
+	if sc.sbtArgs, sc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(sc.sbtArgs); err != nil {
+		return err
+	}
+	if sc.sbtArgs, sc.detailedSummary, err = coreutils.ExtractDetailedSummaryFromArgs(sc.sbtArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(sc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig != nil {
+		if sc.resolverDetails, err = resolverConfig.ServerDetails(); err != nil {
+			return err
+		}
+		sc.resolverRepo = resolverConfig.TargetRepo()
+	}
+	deployerConfig, err := projectconfig.GetRepoConfig(sc.configFilePath, project.ProjectConfigDeployerPrefix)
+	if err != nil {
+		return err
+	}
+	if deployerConfig != nil {
+		if sc.deployerDetails, err = deployerConfig.ServerDetails(); err != nil {
+			return err
+		}
+		sc.deployerRepo = deployerConfig.TargetRepo()
+	}
+	if resolverConfig == nil && deployerConfig == nil {
+		return errorutils.CheckErrorf("the resolver and deployer repositories are missing from the config file (%s). Please run 'jf sbt-config'", sc.configFilePath)
+	}
+	return nil
+}
+
+func (sc *SbtCommand) Run() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	collectBuildInfo, err := sc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	reportsDir := filepath.Join(tempDir, "reports")
+	if err = os.Mkdir(reportsDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	args, err := sc.createArgs(tempDir, reportsDir, collectBuildInfo)
+	if err != nil {
+		return err
+	}
+	if err = runSbt(append(args, sc.sbtArgs...)...); err != nil {
+		return err
+	}
+	reports, err := readReports(reportsDir)
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		for _, report := range reports {
+			if err = buildinfoutils.SaveDependencies(sc.buildConfiguration, report.id(), ModuleType, report.dependencies); err != nil {
+				return err
+			}
+		}
+	}
+	if !sc.IsPublish() {
+		return nil
+	}
+	return sc.collectPublishedFiles(reports, collectBuildInfo)
+}
+
+// Returns the system properties and the 'set' commands, which configure the repositories of the build and write the update reports.
+func (sc *SbtCommand) createArgs(tempDir, reportsDir string, collectBuildInfo bool) ([]string, error) {
+	var args, credentialsPaths []string
+	addCredentials := func(serverDetails *config.ServerDetails, repoUrl, fileName string) error {
+		parsedUrl, err := url.Parse(repoUrl)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		credentialsPath := filepath.Join(tempDir, fileName)
+		written, err := writeCredentialsFile(credentialsPath, parsedUrl.Hostname(), serverDetails)
+		if written {
+			credentialsPaths = append(credentialsPaths, credentialsPath)
+		}
+		return err
+	}
+	if sc.resolverDetails != nil {
+		repoUrl := repositoryUrl(sc.resolverDetails, sc.resolverRepo)
+		repositoriesPath := filepath.Join(tempDir, repositoriesFileName)
+		if err := writeRepositoriesFile(repositoriesPath, repoUrl); err != nil {
+			return nil, err
+		}
+		args = append(args, "-Dsbt.override.build.repos=true", "-Dsbt.repository.config="+repositoriesPath)
+		if err := addCredentials(sc.resolverDetails, repoUrl, "resolver.credentials"); err != nil {
+			return nil, err
+		}
+		if len(credentialsPaths) > 0 {
+			// The credentials of the launcher, which resolves sbt and Scala.
+			args = append(args, "-Dsbt.boot.credentials="+credentialsPaths[0])
+		}
+	}
+	if sc.deployerDetails != nil {
+		repoUrl := repositoryUrl(sc.deployerDetails, sc.deployerRepo)
+		if err := addCredentials(sc.deployerDetails, repoUrl, "deployer.credentials"); err != nil {
+			return nil, err
+		}
+		args = append(args, fmt.Sprintf("set every publishTo := Some(%q at %q)", artifactoryRealm, repoUrl))
+	}
+	if len(credentialsPaths) > 0 {
+		var files []string
+		for _, credentialsPath := range credentialsPaths {
+			files = append(files, fmt.Sprintf("Credentials(new java.io.File(%q))", filepath.ToSlash(credentialsPath)))
+		}
+		args = append(args, "set every credentials ++= Seq("+strings.Join(files, ", ")+")")
+	}
+	if collectBuildInfo || sc.deployerDetails != nil {
+		args = append(args, fmt.Sprintf(writeReportsCommand, filepath.ToSlash(reportsDir), reportExtension))
+	}
+	return args, nil
+}
+
+// Returns true if one of the tasks is the publish task of a project, or of all the projects.
+func isPublish(args []string) bool {
+	for _, arg := range args {
+		for _, task := range strings.FieldsFunc(arg, func(r rune) bool { return r == ';' || r == ' ' }) {
+			task = strings.TrimPrefix(task, "+")
+			if task == "publish" || strings.HasSuffix(task, "/publish") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Records the published files of each project as artifacts, and sets the result of the command.
+// Projects which skip the publish task are ignored.
+func (sc *SbtCommand) collectPublishedFiles(reports []*projectReport, collectBuildInfo bool) error {
+	var transferDetails []clientutils.FileTransferDetails
+	for _, report := range reports {
+		if report.skipPublish {
+			continue
+		}
+		artifacts, err := buildinfoutils.GetDeployedArtifacts(sc.deployerDetails, sc.buildConfiguration, sc.deployerRepo, report.publishedPath())
+		if err != nil {
+			return err
+		}
+		for _, artifact := range artifacts {
+			transferDetails = append(transferDetails, clientutils.FileTransferDetails{
+				TargetPath: path.Join(artifact.OriginalDeploymentRepo, artifact.Path),
+				RtUrl:      sc.deployerDetails.GetArtifactoryUrl(),
+				Sha256:     artifact.Sha256,
+			})
+		}
+		if collectBuildInfo {
+			if err = buildinfoutils.SaveArtifacts(sc.buildConfiguration, report.id(), ModuleType, artifacts); err != nil {
+				return err
+			}
+		}
+	}
+	sc.result.SetSuccessCount(len(transferDetails))
+	if !sc.detailedSummary {
+		return nil
+	}
+	tempFile, err := clientutils.SaveFileTransferDetailsInTempFile(&transferDetails)
+	if err != nil {
+		return err
+	}
+	sc.result.SetReader(content.NewContentReader(tempFile, "files"))
+	return nil
+}
+
+func runSbt(args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the sbt executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -0,0 +1,52 @@
+package sbt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublish(t *testing.T) {
+	assert.True(t, isPublish([]string{"clean", "publish"}))
+	assert.True(t, isPublish([]string{"+core/publish"}))
+	assert.True(t, isPublish([]string{"; clean ; publish"}))
+	assert.False(t, isPublish([]string{"publishLocal"}))
+	assert.False(t, isPublish([]string{"compile", "test"}))
+}
+
+func TestCreateArgs(t *testing.T) {
+	tempDir := t.TempDir()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "pass"}
+	sbtCmd := &SbtCommand{resolverDetails: serverDetails, resolverRepo: "sbt-virtual", deployerDetails: serverDetails, deployerRepo: "sbt-local"}
+	reportsDir := filepath.Join(tempDir, "reports")
+	args, err := sbtCmd.createArgs(tempDir, reportsDir, true)
+	require.NoError(t, err)
+	resolverCredentials, deployerCredentials := filepath.ToSlash(filepath.Join(tempDir, "resolver.credentials")), filepath.ToSlash(filepath.Join(tempDir, "deployer.credentials"))
+	require.Len(t, args, 6)
+	assert.Equal(t, []string{
+		"-Dsbt.override.build.repos=true",
+		"-Dsbt.repository.config=" + filepath.Join(tempDir, repositoriesFileName),
+		"-Dsbt.boot.credentials=" + filepath.Join(tempDir, "resolver.credentials"),
+		`set every publishTo := Some("Artifactory Realm" at "https://acme.jfrog.io/artifactory/sbt-local/")`,
+		`set every credentials ++= Seq(Credentials(new java.io.File("` + resolverCredentials + `")), Credentials(new java.io.File("` + deployerCredentials + `")))`,
+	}, args[:5])
+	assert.Contains(t, args[5], `IO.writeLines(new java.io.File("`+filepath.ToSlash(reportsDir)+`", thisProject.value.id + ".tsv")`)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, repositoriesFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "[repositories]\n  local\n  jfrog: https://acme.jfrog.io/artifactory/sbt-virtual/\n"+
+		"  jfrog-ivy: https://acme.jfrog.io/artifactory/sbt-virtual/, "+ivyPattern+"\n", string(content))
+	content, err = os.ReadFile(filepath.Join(tempDir, "resolver.credentials"))
+	require.NoError(t, err)
+	assert.Equal(t, "realm=Artifactory Realm\nhost=acme.jfrog.io\nuser=user\npassword=pass\n", string(content))
+
+	// Without credentials and build-info, only the resolvers are configured.
+	sbtCmd = &SbtCommand{resolverDetails: &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, resolverRepo: "sbt-virtual"}
+	args, err = sbtCmd.createArgs(tempDir, reportsDir, false)
+	require.NoError(t, err)
+	assert.Len(t, args, 2)
+}
@@ -0,0 +1,97 @@
+package terraform
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	LockfileName = ".terraform.lock.hcl"
+	// The manifest of the modules installed by 'terraform init', relative to the working directory.
+	modulesManifestPath = ".terraform/modules/modules.json"
+
+	providerType = "provider"
+	moduleType   = "module"
+)
+
+// Returns the providers locked in the dependency lock file.
+// The file is generated by terraform in a fixed format, in which each provider block looks like:
+//
+//	provider "registry.terraform.io/hashicorp/aws" {
+//	  version     = "5.31.0"
+//	  constraints = "~> 5.0"
+//	  hashes = [...]
+//	}
+func parseLockfile(content []byte) ([]buildinfo.Dependency, error) {
+	var dependencies []buildinfo.Dependency
+	var provider string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "provider "):
+			fields := strings.Fields(line)
+			address, err := strconv.Unquote(fields[1])
+			if err != nil {
+				return nil, errorutils.CheckErrorf("failed to parse %s: invalid provider address %s", LockfileName, fields[1])
+			}
+			provider = address
+		case provider != "" && strings.HasPrefix(line, "version"):
+			_, value, _ := strings.Cut(line, "=")
+			version, err := strconv.Unquote(strings.TrimSpace(value))
+			if err != nil {
+				return nil, errorutils.CheckErrorf("failed to parse %s: invalid version of provider %s", LockfileName, provider)
+			}
+			dependencies = append(dependencies, buildinfo.Dependency{Id: provider + ":" + version, Type: providerType})
+			provider = ""
+		}
+	}
+	return dependencies, errorutils.CheckError(scanner.Err())
+}
+
+type modulesManifest struct {
+	Modules []struct {
+		Key     string `json:"Key"`
+		Source  string `json:"Source"`
+		Version string `json:"Version"`
+	} `json:"Modules"`
+}
+
+// Returns the registry modules installed by 'terraform init'.
+// Local modules, and modules from sources other than a registry, have no version and aren't included.
+func parseModulesManifest(content []byte) ([]buildinfo.Dependency, error) {
+	manifest := new(modulesManifest)
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", modulesManifestPath, err.Error())
+	}
+	var dependencies []buildinfo.Dependency
+	for _, module := range manifest.Modules {
+		if module.Version == "" {
+			continue
+		}
+		dependency := buildinfo.Dependency{Id: module.Source + ":" + module.Version, Type: moduleType}
+		// A nested module is requested by its parent, whose key is the prefix of its key.
+		if parentKey, _, nested := cutLast(module.Key, "."); nested {
+			for _, parent := range manifest.Modules {
+				if parent.Key == parentKey && parent.Version != "" {
+					dependency.RequestedBy = [][]string{{parent.Source + ":" + parent.Version}}
+				}
+			}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,54 @@
+package terraform
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockfile(t *testing.T) {
+	dependencies, err := parseLockfile([]byte(`# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+    "zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+  hashes = [
+    "h1:R5Ucn26riKIEijcsiOMBR3uOAjuOMfI1x7XvH4P6B1w=",
+  ]
+}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "registry.terraform.io/hashicorp/aws:5.31.0", Type: providerType},
+		{Id: "registry.terraform.io/hashicorp/random:3.6.0", Type: providerType},
+	}, dependencies)
+}
+
+func TestParseModulesManifest(t *testing.T) {
+	dependencies, err := parseModulesManifest([]byte(`{"Modules":[
+  {"Key":"","Source":"","Dir":"."},
+  {"Key":"network","Source":"./modules/network","Dir":"modules/network"},
+  {"Key":"vpc","Source":"acme.jfrog.io/tf-virtual__terraform-aws-modules/vpc/aws","Version":"5.1.0","Dir":".terraform/modules/vpc"},
+  {"Key":"vpc.endpoints","Source":"acme.jfrog.io/tf-virtual__terraform-aws-modules/vpc/aws//modules/vpc-endpoints","Version":"5.1.0","Dir":".terraform/modules/vpc/modules/vpc-endpoints"}
+]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "acme.jfrog.io/tf-virtual__terraform-aws-modules/vpc/aws:5.1.0", Type: moduleType},
+		{
+			Id:          "acme.jfrog.io/tf-virtual__terraform-aws-modules/vpc/aws//modules/vpc-endpoints:5.1.0",
+			Type:        moduleType,
+			RequestedBy: [][]string{{"acme.jfrog.io/tf-virtual__terraform-aws-modules/vpc/aws:5.1.0"}},
+		},
+	}, dependencies)
+}
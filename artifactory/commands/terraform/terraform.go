@@ -0,0 +1,288 @@
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl/hcl/parser"
+	"github.com/hashicorp/hcl/hcl/printer"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	executableName = "terraform"
+	cliConfigEnv   = "TF_CLI_CONFIG_FILE"
+
+	providerInstallationBlock = "provider_installation"
+	credentialsBlock          = "credentials"
+)
+
+// Runs a terraform command, which resolves the providers and modules from an Artifactory Terraform repository.
+// Terraform runs with a temporary CLI configuration, which installs the providers from the repository as a network mirror,
+// and authenticates to the Artifactory host, from which the modules are downloaded.
+// The providers and modules installed by 'terraform init' are collected into the build-info.
+type TerraformCommand struct {
+	cmdName            string
+	configFilePath     string
+	terraformArgs      []string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewTerraformCommand() *TerraformCommand {
+	return &TerraformCommand{}
+}
+
+func (tc *TerraformCommand) SetCmdName(cmdName string) *TerraformCommand {
+	tc.cmdName = cmdName
+	return tc
+}
+
+func (tc *TerraformCommand) SetConfigFilePath(configFilePath string) *TerraformCommand {
+	tc.configFilePath = configFilePath
+	return tc
+}
+
+func (tc *TerraformCommand) SetArgs(args []string) *TerraformCommand {
+	tc.terraformArgs = args
+	return tc
+}
+
+func (tc *TerraformCommand) CommandName() string {
+	return "rt_terraform_" + tc.cmdName
+}
+
+func (tc *TerraformCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tc.serverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the build-info options from the terraform arguments.
+func (tc *TerraformCommand) Init() (err error) {
+	if tc.terraformArgs, tc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(tc.terraformArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(tc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig == nil {
+		return errorutils.CheckErrorf("the resolver repository is missing from the config file (%s). Please run 'jf terraform-config' with the --repo-resolve option", tc.configFilePath)
+	}
+	if tc.serverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	tc.repo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (tc *TerraformCommand) Run() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	cliConfigPath, err := writeCliConfig(tempDir, tc.serverDetails, tc.repo)
+	if err != nil {
+		return err
+	}
+	if err = runTerraform([]string{cliConfigEnv + "=" + cliConfigPath}, append([]string{tc.cmdName}, tc.terraformArgs...)...); err != nil {
+		return err
+	}
+	if tc.cmdName != "init" {
+		return nil
+	}
+	collectBuildInfo, err := tc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return tc.collectDependencies()
+}
+
+// Writes the terraform CLI configuration file into the temporary directory, and returns its path.
+// The user's CLI configuration is merged into the file, except for the provider installation and the credentials of the Artifactory host,
+// which are replaced by the generated configuration. The credentials which 'terraform login' stores in credentials.tfrc.json are read by terraform as usual.
+func writeCliConfig(tempDir string, serverDetails *config.ServerDetails, repo string) (string, error) {
+	content, err := createCliConfig(serverDetails, repo)
+	if err != nil {
+		return "", err
+	}
+	userConfig, err := readUserCliConfig(serverDetails)
+	if err != nil {
+		return "", err
+	}
+	if userConfig != "" {
+		content += "\n" + userConfig
+	}
+	cliConfigPath := filepath.Join(tempDir, "terraform.rc")
+	// The file includes credentials, and is therefore readable by the user only.
+	return cliConfigPath, errorutils.CheckError(os.WriteFile(cliConfigPath, []byte(content), 0600))
+}
+
+// Returns the content of a terraform CLI configuration, which installs the providers from the repository,
+// and includes the credentials of the Artifactory host.
+func createCliConfig(serverDetails *config.ServerDetails, repo string) (string, error) {
+	artifactoryUrl := strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/")
+	parsedUrl, err := url.Parse(artifactoryUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	token := serverDetails.GetAccessToken()
+	if token == "" {
+		// Terraform authenticates with a token only. Identity tokens are commonly configured as the password.
+		token = serverDetails.GetPassword()
+	}
+	var cliConfig strings.Builder
+	cliConfig.WriteString("provider_installation {\n  network_mirror {\n")
+	cliConfig.WriteString("    url = " + strconv.Quote(artifactoryUrl+"/api/terraform/"+repo+"/providers/") + "\n")
+	cliConfig.WriteString("  }\n}\n")
+	if token != "" {
+		cliConfig.WriteString("\ncredentials " + strconv.Quote(parsedUrl.Host) + " {\n")
+		cliConfig.WriteString("  token = " + strconv.Quote(token) + "\n")
+		cliConfig.WriteString("}\n")
+	} else {
+		log.Warn("No access token is configured for the server. Terraform will access Artifactory anonymously.")
+	}
+	return cliConfig.String(), nil
+}
+
+// Returns the user's CLI configuration, without the blocks which the generated configuration replaces,
+// or an empty string if the user has no CLI configuration file.
+func readUserCliConfig(serverDetails *config.ServerDetails) (string, error) {
+	userConfigPath, err := getUserCliConfigPath()
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(userConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errorutils.CheckError(err)
+	}
+	parsedUrl, err := url.Parse(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	userConfig, err := filterCliConfig(content, parsedUrl.Host)
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to parse the terraform CLI configuration file %s: %s", userConfigPath, err.Error())
+	}
+	log.Debug("Merging the terraform CLI configuration file", userConfigPath)
+	return userConfig, nil
+}
+
+// Returns the path of the CLI configuration file, which terraform reads when TF_CLI_CONFIG_FILE isn't replaced.
+func getUserCliConfigPath() (string, error) {
+	if userConfigPath := os.Getenv(cliConfigEnv); userConfigPath != "" {
+		return userConfigPath, nil
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "terraform.rc"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".terraformrc"), nil
+}
+
+// Removes the provider installation block and the credentials block of the host from the CLI configuration.
+func filterCliConfig(content []byte, host string) (string, error) {
+	file, err := parser.Parse(content)
+	if err != nil {
+		return "", err
+	}
+	objectList, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return "", nil
+	}
+	objectList.Items = slices.DeleteFunc(objectList.Items, func(item *ast.ObjectItem) bool {
+		switch getKey(item, 0) {
+		case providerInstallationBlock:
+			return true
+		case credentialsBlock:
+			return strings.EqualFold(getKey(item, 1), host)
+		}
+		return false
+	})
+	var filtered bytes.Buffer
+	if err = printer.Fprint(&filtered, objectList); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(filtered.String()) == "" {
+		return "", nil
+	}
+	return strings.TrimSpace(filtered.String()) + "\n", nil
+}
+
+// Returns the unquoted key of the block at the index, or an empty string if the block has fewer keys.
+func getKey(item *ast.ObjectItem, index int) string {
+	if index >= len(item.Keys) {
+		return ""
+	}
+	key, _ := item.Keys[index].Token.Value().(string)
+	return key
+}
+
+// Collects the providers from the dependency lock file, and the registry modules from the modules manifest.
+// The root module of the working directory is the build-info module.
+func (tc *TerraformCommand) collectDependencies() error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var dependencies []buildinfo.Dependency
+	for _, file := range []struct {
+		path  string
+		parse func([]byte) ([]buildinfo.Dependency, error)
+	}{{LockfileName, parseLockfile}, {modulesManifestPath, parseModulesManifest}} {
+		content, err := os.ReadFile(filepath.Join(workingDir, file.path))
+		if os.IsNotExist(err) {
+			log.Debug(file.path, "was not found in", workingDir)
+			continue
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		fileDependencies, err := file.parse(content)
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, fileDependencies...)
+	}
+	return buildinfoutils.SaveDependencies(tc.buildConfiguration, filepath.Base(workingDir), buildinfo.Terraform, dependencies)
+}
+
+func runTerraform(env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the terraform executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
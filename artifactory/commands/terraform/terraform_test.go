@@ -0,0 +1,84 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCliConfig(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}
+	cliConfig, err := createCliConfig(serverDetails, "terraform-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, `provider_installation {
+  network_mirror {
+    url = "https://acme.jfrog.io/artifactory/api/terraform/terraform-virtual/providers/"
+  }
+}
+
+credentials "acme.jfrog.io" {
+  token = "token"
+}
+`, cliConfig)
+
+	// Without credentials, Artifactory is accessed anonymously.
+	cliConfig, err = createCliConfig(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, "terraform-virtual")
+	require.NoError(t, err)
+	assert.NotContains(t, cliConfig, "credentials")
+}
+
+func TestWriteCliConfig(t *testing.T) {
+	userConfigPath := filepath.Join(t.TempDir(), ".terraformrc")
+	require.NoError(t, os.WriteFile(userConfigPath, []byte(`plugin_cache_dir = "/opt/terraform/plugins"
+
+provider_installation {
+  direct {}
+}
+
+credentials "acme.jfrog.io" {
+  token = "old-token"
+}
+
+credentials "app.terraform.io" {
+  token = "cloud-token"
+}
+`), 0600))
+	t.Setenv(cliConfigEnv, userConfigPath)
+
+	tempDir := t.TempDir()
+	cliConfigPath, err := writeCliConfig(tempDir, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}, "terraform-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tempDir, "terraform.rc"), cliConfigPath)
+	content, err := os.ReadFile(cliConfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, `provider_installation {
+  network_mirror {
+    url = "https://acme.jfrog.io/artifactory/api/terraform/terraform-virtual/providers/"
+  }
+}
+
+credentials "acme.jfrog.io" {
+  token = "token"
+}
+
+plugin_cache_dir = "/opt/terraform/plugins"
+
+credentials "app.terraform.io" {
+  token = "cloud-token"
+}
+`, string(content))
+
+	// Without a user configuration file, only the generated configuration is written.
+	t.Setenv(cliConfigEnv, filepath.Join(tempDir, "missing.rc"))
+	cliConfigPath, err = writeCliConfig(tempDir, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}, "terraform-virtual")
+	require.NoError(t, err)
+	content, err = os.ReadFile(cliConfigPath)
+	require.NoError(t, err)
+	expected, err := createCliConfig(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}, "terraform-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(content))
+}
@@ -0,0 +1,160 @@
+package uv
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const LockfileName = "uv.lock"
+
+type uvLockfile struct {
+	Packages []*lockPackage `toml:"package"`
+}
+
+type lockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  struct {
+		Registry string `toml:"registry"`
+		Editable string `toml:"editable"`
+		Virtual  string `toml:"virtual"`
+	} `toml:"source"`
+	Dependencies         []dependencyRef            `toml:"dependencies"`
+	OptionalDependencies map[string][]dependencyRef `toml:"optional-dependencies"`
+	DevDependencies      map[string][]dependencyRef `toml:"dev-dependencies"`
+	Sdist                *distribution              `toml:"sdist"`
+	Wheels               []distribution             `toml:"wheels"`
+}
+
+// A dependency is referenced by its name, followed by its version if several versions of the package are locked.
+type dependencyRef struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+}
+
+type distribution struct {
+	Hash string `toml:"hash"`
+}
+
+func (lp *lockPackage) id() string {
+	return lp.Name + ":" + lp.Version
+}
+
+// The project and the workspace members are locked as editable or virtual packages.
+func (lp *lockPackage) projectPath() string {
+	if lp.Source.Editable != "" {
+		return lp.Source.Editable
+	}
+	return lp.Source.Virtual
+}
+
+// Returns the sha256 of the source distribution, or of the wheel if the package has a single wheel.
+// Otherwise the installed distribution is unknown.
+func (lp *lockPackage) sha256() string {
+	hash := ""
+	switch {
+	case lp.Sdist != nil:
+		hash = lp.Sdist.Hash
+	case len(lp.Wheels) == 1:
+		hash = lp.Wheels[0].Hash
+	}
+	return strings.TrimPrefix(hash, "sha256:")
+}
+
+func parseLockfile(content []byte) (*uvLockfile, error) {
+	lockfile := new(uvLockfile)
+	if _, err := toml.Decode(string(content), lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	return lockfile, nil
+}
+
+// Returns the package of the project directory, or nil if the project isn't a package.
+func (ul *uvLockfile) project() *lockPackage {
+	for _, pkg := range ul.Packages {
+		if pkg.projectPath() == "." {
+			return pkg
+		}
+	}
+	return nil
+}
+
+func (ul *uvLockfile) findPackage(ref dependencyRef) *lockPackage {
+	for _, pkg := range ul.Packages {
+		if pkg.Name == ref.Name && (ref.Version == "" || pkg.Version == ref.Version) {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// Walks the dependency graph of the project. The dependencies of the project have the prod scope,
+// and the packages which are only required by its dependency groups have the dev scope.
+// The optional dependencies of a package are included if one of its dependents requests their extra.
+// Each dependency is requested by the shortest path to the module, through each of its dependents.
+func (ul *uvLockfile) getDependencies(project *lockPackage, moduleId string) []buildinfo.Dependency {
+	var scope string
+	walker := buildinfoutils.NewDependencyWalker(func(pkg *lockPackage) buildinfo.Dependency {
+		dependency := buildinfo.Dependency{Id: pkg.id(), Scopes: []string{scope}}
+		if sha256 := pkg.sha256(); sha256 != "" {
+			dependency.Checksum = buildinfo.Checksum{Sha256: sha256}
+		}
+		return dependency
+	})
+	// The requested extras of each package. A package is walked again when a new extra of it is requested.
+	extras := make(map[*lockPackage][]string)
+	visit := func(refs []dependencyRef, pathToRoot []string) {
+		for _, ref := range refs {
+			pkg := ul.findPackage(ref)
+			if pkg == nil || pkg.projectPath() != "" {
+				continue
+			}
+			firstVisit := walker.Visit(pkg, pathToRoot)
+			newExtras := false
+			for _, extra := range ref.Extra {
+				if !slices.Contains(extras[pkg], extra) {
+					extras[pkg] = append(extras[pkg], extra)
+					newExtras = true
+				}
+			}
+			if newExtras && !firstVisit {
+				walker.Enqueue(pkg, append([]string{pkg.id()}, pathToRoot...))
+			}
+		}
+	}
+	for _, scopeRefs := range []struct {
+		name string
+		refs []dependencyRef
+	}{{"prod", project.Dependencies}, {"dev", devDependencies(project)}} {
+		scope = scopeRefs.name
+		visit(scopeRefs.refs, []string{moduleId})
+		walker.Walk(func(pkg *lockPackage, pathToRoot []string) {
+			visit(pkg.Dependencies, pathToRoot)
+			for _, extra := range extras[pkg] {
+				visit(pkg.OptionalDependencies[extra], pathToRoot)
+			}
+		})
+	}
+	return walker.Dependencies()
+}
+
+// Returns the dependencies of all the dependency groups of the project, sorted by group.
+func devDependencies(project *lockPackage) []dependencyRef {
+	groups := make([]string, 0, len(project.DevDependencies))
+	for group := range project.DevDependencies {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	var refs []dependencyRef
+	for _, group := range groups {
+		refs = append(refs, project.DevDependencies[group]...)
+	}
+	return refs
+}
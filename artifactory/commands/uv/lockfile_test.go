@@ -0,0 +1,100 @@
+package uv
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockfile = `version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "myapp"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "requests", extra = ["socks"] },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "pytest" },
+]
+
+[[package]]
+name = "pysocks"
+version = "1.7.1"
+source = { registry = "https://pypi.org/simple" }
+sdist = { url = "https://files.pythonhosted.org/pysocks-1.7.1.tar.gz", hash = "sha256:3f8804571ebe159c380ac6de37643bb4685970655d3bba243530d6558b799aa0", size = 284429 }
+
+[[package]]
+name = "requests"
+version = "2.32.3"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "urllib3" },
+]
+wheels = [
+    { url = "https://files.pythonhosted.org/requests-2.32.3-py3-none-any.whl", hash = "sha256:70761cfe03c773ceb22aa2f671b4757976145175cdfca038c02654d061d6dcc6", size = 64928 },
+]
+
+[package.optional-dependencies]
+socks = [
+    { name = "pysocks" },
+]
+
+[[package]]
+name = "pytest"
+version = "8.3.3"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "urllib3" },
+]
+wheels = [
+    { url = "https://files.pythonhosted.org/pytest-8.3.3-py3-none-any.whl", hash = "sha256:a6853c7375b2663155079443d2e45de913a911a11d669df02a50814944db57b2", size = 342341 },
+    { url = "https://files.pythonhosted.org/pytest-8.3.3-py2-none-any.whl", hash = "sha256:b6853c7375b2663155079443d2e45de913a911a11d669df02a50814944db57b2", size = 342341 },
+]
+
+[[package]]
+name = "urllib3"
+version = "2.2.3"
+source = { registry = "https://pypi.org/simple" }
+`
+
+func TestGetDependencies(t *testing.T) {
+	lockfile, err := parseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	project := lockfile.project()
+	require.NotNil(t, project)
+	moduleId := project.id()
+	assert.Equal(t, "myapp:0.1.0", moduleId)
+
+	assert.Equal(t, []buildinfo.Dependency{
+		{
+			Id:          "requests:2.32.3",
+			Scopes:      []string{"prod"},
+			Checksum:    buildinfo.Checksum{Sha256: "70761cfe03c773ceb22aa2f671b4757976145175cdfca038c02654d061d6dcc6"},
+			RequestedBy: [][]string{{moduleId}},
+		},
+		{
+			Id:          "urllib3:2.2.3",
+			Scopes:      []string{"prod"},
+			RequestedBy: [][]string{{"requests:2.32.3", moduleId}, {"pytest:8.3.3", moduleId}},
+		},
+		{
+			Id:          "pysocks:1.7.1",
+			Scopes:      []string{"prod"},
+			Checksum:    buildinfo.Checksum{Sha256: "3f8804571ebe159c380ac6de37643bb4685970655d3bba243530d6558b799aa0"},
+			RequestedBy: [][]string{{"requests:2.32.3", moduleId}},
+		},
+		{
+			// The installed wheel of pytest is unknown.
+			Id:          "pytest:8.3.3",
+			Scopes:      []string{"dev"},
+			RequestedBy: [][]string{{moduleId}},
+		},
+	}, lockfile.getDependencies(project, moduleId))
+}
@@ -0,0 +1,157 @@
+package uv
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	python "github.com/jfrog/jfrog-cli-core/v2/utils/python"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ToolName       = "uv"
+	executableName = "uv"
+
+	ModuleType = buildinfo.Python
+
+	// Replaces PyPI as the default index of uv, including in the 'uv pip' commands.
+	defaultIndexEnv = "UV_DEFAULT_INDEX"
+)
+
+// The uv commands which resolve the packages of the project into uv.lock.
+var resolveCommands = []string{"sync", "lock", "add", "remove"}
+
+// Runs a uv command, which resolves the packages from an Artifactory PyPI repository instead of PyPI.
+// The repository is configured as the default index of uv, and the packages resolved by sync, lock, add and remove
+// are collected from the uv.lock file into the build-info. Distributions are published with 'jf twine', which reads the deployer of the uv configuration.
+type UvCommand struct {
+	cmdName            string
+	configFilePath     string
+	uvArgs             []string
+	serverDetails      *config.ServerDetails
+	repo               string
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewUvCommand() *UvCommand {
+	return &UvCommand{}
+}
+
+func (uc *UvCommand) SetCmdName(cmdName string) *UvCommand {
+	uc.cmdName = cmdName
+	return uc
+}
+
+func (uc *UvCommand) SetConfigFilePath(configFilePath string) *UvCommand {
+	uc.configFilePath = configFilePath
+	return uc
+}
+
+func (uc *UvCommand) SetArgs(args []string) *UvCommand {
+	uc.uvArgs = args
+	return uc
+}
+
+func (uc *UvCommand) CommandName() string {
+	return "rt_uv_" + uc.cmdName
+}
+
+func (uc *UvCommand) ServerDetails() (*config.ServerDetails, error) {
+	return uc.serverDetails, nil
+}
+
+// Reads the resolver configuration and extracts the build-info options from the uv arguments.
+func (uc *UvCommand) Init() (err error) {
+	if uc.uvArgs, uc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(uc.uvArgs); err != nil {
+		return err
+	}
+	resolverConfig, err := projectconfig.GetRepoConfig(uc.configFilePath, project.ProjectConfigResolverPrefix)
+	if err != nil {
+		return err
+	}
+	if resolverConfig == nil {
+		return errorutils.CheckErrorf("the resolver repository is missing from the config file (%s). Please run 'jf uv-config' with the --repo-resolve option", uc.configFilePath)
+	}
+	if uc.serverDetails, err = resolverConfig.ServerDetails(); err != nil {
+		return err
+	}
+	uc.repo = resolverConfig.TargetRepo()
+	return nil
+}
+
+func (uc *UvCommand) Run() error {
+	indexUrl, err := python.GetPypiRepoUrl(uc.serverDetails, uc.repo, false)
+	if err != nil {
+		return err
+	}
+	if err = runUv([]string{defaultIndexEnv + "=" + indexUrl}, append([]string{uc.cmdName}, uc.uvArgs...)...); err != nil {
+		return err
+	}
+	if !isResolveCommand(uc.cmdName) {
+		return nil
+	}
+	collectBuildInfo, err := uc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return uc.collectDependencies()
+}
+
+func isResolveCommand(cmdName string) bool {
+	for _, resolveCommand := range resolveCommands {
+		if cmdName == resolveCommand {
+			return true
+		}
+	}
+	return false
+}
+
+// Collects the locked packages of the project. The project package is the build-info module.
+func (uc *UvCommand) collectDependencies() error {
+	lockfileDir, exists, err := fileutils.FindUpstream(LockfileName, fileutils.File)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Warn(LockfileName + " was not found, and therefore the dependencies are not included in the build-info.")
+		return nil
+	}
+	content, err := os.ReadFile(filepath.Join(lockfileDir, LockfileName))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockfile, err := parseLockfile(content)
+	if err != nil {
+		return err
+	}
+	projectPackage := lockfile.project()
+	if projectPackage == nil {
+		log.Warn("The project was not found in " + LockfileName + ", and therefore the dependencies are not included in the build-info.")
+		return nil
+	}
+	moduleId := projectPackage.id()
+	return buildinfoutils.SaveDependencies(uc.buildConfiguration, moduleId, ModuleType, lockfile.getDependencies(projectPackage, moduleId))
+}
+
+func runUv(env []string, args ...string) error {
+	executablePath, err := exec.LookPath(executableName)
+	if err != nil {
+		return errorutils.CheckErrorf("could not find the uv executable in the system PATH: %s", err.Error())
+	}
+	log.Debug("Running command:", executablePath, args)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-security/utils/techutils"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/terraform"
 	commandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/yarn"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
@@ -31,34 +33,73 @@ import (
 	securityCLI "github.com/jfrog/jfrog-cli-security/cli"
 	securityDocs "github.com/jfrog/jfrog-cli-security/cli/docs"
 	"github.com/jfrog/jfrog-cli-security/commands/scan"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/bazel"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/cargo"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/composer"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/conan"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/conda"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/execute"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/gomodules"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/helm"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/nugetlock"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/oci"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/pnpm"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/ruby"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/sbt"
+	terraformcmd "github.com/jfrog/jfrog-cli/artifactory/commands/terraform"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/uv"
 	terraformdocs "github.com/jfrog/jfrog-cli/docs/artifactory/terraform"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/terraformconfig"
 	twinedocs "github.com/jfrog/jfrog-cli/docs/artifactory/twine"
+	bazeldocs "github.com/jfrog/jfrog-cli/docs/buildtools/bazel"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/bazelconfig"
+	bundledocs "github.com/jfrog/jfrog-cli/docs/buildtools/bundle"
+	cargodocs "github.com/jfrog/jfrog-cli/docs/buildtools/cargo"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/cargoconfig"
+	composerdocs "github.com/jfrog/jfrog-cli/docs/buildtools/composer"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/composerconfig"
+	conandocs "github.com/jfrog/jfrog-cli/docs/buildtools/conan"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/conanconfig"
+	condadocs "github.com/jfrog/jfrog-cli/docs/buildtools/conda"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/condaconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/docker"
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/buildtools/dotnet"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/dotnetconfig"
+	execdocs "github.com/jfrog/jfrog-cli/docs/buildtools/exec"
+	gemdocs "github.com/jfrog/jfrog-cli/docs/buildtools/gem"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/gemconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gocommand"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/goconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gopublish"
 	gradledoc "github.com/jfrog/jfrog-cli/docs/buildtools/gradle"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gradleconfig"
+	helmdocs "github.com/jfrog/jfrog-cli/docs/buildtools/helm"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/helmconfig"
 	mvndoc "github.com/jfrog/jfrog-cli/docs/buildtools/mvn"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/mvnconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/npmcommand"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/npmconfig"
 	nugetdocs "github.com/jfrog/jfrog-cli/docs/buildtools/nuget"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/nugetconfig"
+	ocidocs "github.com/jfrog/jfrog-cli/docs/buildtools/oci"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipenvconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipenvinstall"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipinstall"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/pnpmcommand"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pnpmconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/poetry"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/poetryconfig"
+	sbtdocs "github.com/jfrog/jfrog-cli/docs/buildtools/sbt"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/sbtconfig"
+	uvdocs "github.com/jfrog/jfrog-cli/docs/buildtools/uv"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/uvconfig"
 	yarndocs "github.com/jfrog/jfrog-cli/docs/buildtools/yarn"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/imageindex"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
@@ -66,6 +107,9 @@ import (
 
 const (
 	buildToolsCategory = "Build Tools"
+
+	goModVendorExport = "mod-vendor-export"
+	goModVendorImport = "mod-vendor-import"
 )
 
 func GetCommands() []cli.Command {
@@ -222,6 +266,9 @@ func GetCommands() []cli.Command {
 			Category:        buildToolsCategory,
 			Action: func(c *cli.Context) (err error) {
 				cmdName, _ := getCommandName(c.Args())
+				if cmdName == goModVendorExport || cmdName == goModVendorImport {
+					return goModVendorCmd(c, cmdName)
+				}
 				return securityCLI.WrapCmdWithCurationPostFailureRun(c, GoCmd, techutils.Go, cmdName)
 			},
 		},
@@ -315,6 +362,62 @@ func GetCommands() []cli.Command {
 			Category:        buildToolsCategory,
 			Action:          PoetryCmd,
 		},
+		{
+			Name:         "uv-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.UvConfig),
+			Aliases:      []string{"uvc"},
+			Usage:        uvconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("uv-config", uvconfig.GetDescription(), uvconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, uv.ToolName)
+			},
+		},
+		{
+			Name:            "uv",
+			Flags:           cliutils.GetCommandFlags(cliutils.Uv),
+			Usage:           uvdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("uv", uvdocs.GetDescription(), uvdocs.Usage),
+			UsageText:       uvdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("sync", "lock", "add", "remove", "pip"),
+			Category:        buildToolsCategory,
+			Action:          UvCmd,
+		},
+		{
+			Name:         "conda-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.CondaConfig),
+			Aliases:      []string{"condac"},
+			Usage:        condaconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("conda-config", condaconfig.GetDescription(), condaconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, conda.ToolName)
+			},
+		},
+		{
+			Name:            "conda",
+			Flags:           cliutils.GetCommandFlags(cliutils.Conda),
+			Usage:           condadocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("conda", condadocs.GetDescription(), condadocs.Usage),
+			UsageText:       condadocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "create", "update"),
+			Category:        buildToolsCategory,
+			Action:          CondaCmd,
+		},
 		{
 			Name:         "npm-config",
 			Flags:        cliutils.GetCommandFlags(cliutils.NpmConfig),
@@ -358,6 +461,238 @@ func GetCommands() []cli.Command {
 				return cliutils.CreateConfigCmd(c, project.Pnpm)
 			},
 		},
+		{
+			Name:            "pnpm",
+			Flags:           cliutils.GetCommandFlags(cliutils.Pnpm),
+			Usage:           pnpmcommand.GetDescription(),
+			HelpName:        corecommon.CreateUsage("pnpm", pnpmcommand.GetDescription(), pnpmcommand.Usage),
+			UsageText:       pnpmcommand.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "i", "add", "update", "up", "publish", "p"),
+			Category:        buildToolsCategory,
+			Action:          PnpmCmd,
+		},
+		{
+			Name:         "cargo-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.CargoConfig),
+			Aliases:      []string{"cargoc"},
+			Usage:        cargoconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("cargo-config", cargoconfig.GetDescription(), cargoconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, cargo.ToolName)
+			},
+		},
+		{
+			Name:            "cargo",
+			Flags:           cliutils.GetCommandFlags(cliutils.Cargo),
+			Usage:           cargodocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("cargo", cargodocs.GetDescription(), cargodocs.Usage),
+			UsageText:       cargodocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action:          cargoCmd,
+		},
+		{
+			Name:         "helm-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.HelmConfig),
+			Aliases:      []string{"helmc"},
+			Usage:        helmconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("helm-config", helmconfig.GetDescription(), helmconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, helm.ToolName)
+			},
+		},
+		{
+			Name:            "helm",
+			Flags:           cliutils.GetCommandFlags(cliutils.Helm),
+			Usage:           helmdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("helm", helmdocs.GetDescription(), helmdocs.Usage),
+			UsageText:       helmdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("package", "push", "dependency"),
+			Category:        buildToolsCategory,
+			Action:          helmCmd,
+		},
+		{
+			Name:         "conan-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ConanConfig),
+			Aliases:      []string{"conanc"},
+			Usage:        conanconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("conan-config", conanconfig.GetDescription(), conanconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, conan.ToolName)
+			},
+		},
+		{
+			Name:            "conan",
+			Flags:           cliutils.GetCommandFlags(cliutils.Conan),
+			Usage:           conandocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("conan", conandocs.GetDescription(), conandocs.Usage),
+			UsageText:       conandocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "create", "upload"),
+			Category:        buildToolsCategory,
+			Action:          conanCmd,
+		},
+		{
+			Name:         "gem-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.GemConfig),
+			Aliases:      []string{"gemc"},
+			Usage:        gemconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("gem-config", gemconfig.GetDescription(), gemconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, ruby.ToolName)
+			},
+		},
+		{
+			Name:            "bundle",
+			Flags:           cliutils.GetCommandFlags(cliutils.Bundle),
+			Usage:           bundledocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("bundle", bundledocs.GetDescription(), bundledocs.Usage),
+			UsageText:       bundledocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "update", "lock"),
+			Category:        buildToolsCategory,
+			Action:          BundleCmd,
+		},
+		{
+			Name:            "gem",
+			Flags:           cliutils.GetCommandFlags(cliutils.Gem),
+			Usage:           gemdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("gem", gemdocs.GetDescription(), gemdocs.Usage),
+			UsageText:       gemdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("push"),
+			Category:        buildToolsCategory,
+			Action:          GemCmd,
+		},
+		{
+			Name:         "bazel-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.BazelConfig),
+			Aliases:      []string{"bazelc"},
+			Usage:        bazelconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("bazel-config", bazelconfig.GetDescription(), bazelconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, bazel.ToolName)
+			},
+		},
+		{
+			Name:            "bazel",
+			Flags:           cliutils.GetCommandFlags(cliutils.Bazel),
+			Usage:           bazeldocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("bazel", bazeldocs.GetDescription(), bazeldocs.Usage),
+			UsageText:       bazeldocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("build", "test", "run", "coverage", "fetch"),
+			Category:        buildToolsCategory,
+			Action:          BazelCmd,
+		},
+		{
+			Name:         "sbt-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.SbtConfig),
+			Aliases:      []string{"sbtc"},
+			Usage:        sbtconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("sbt-config", sbtconfig.GetDescription(), sbtconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, sbt.ToolName)
+			},
+		},
+		{
+			Name:            "sbt",
+			Flags:           cliutils.GetCommandFlags(cliutils.Sbt),
+			Usage:           sbtdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("sbt", sbtdocs.GetDescription(), sbtdocs.Usage),
+			UsageText:       sbtdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action:          SbtCmd,
+		},
+		{
+			Name:            "exec",
+			Flags:           cliutils.GetCommandFlags(cliutils.Exec),
+			Usage:           execdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("exec", execdocs.GetDescription(), execdocs.Usage),
+			UsageText:       execdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action:          ExecCmd,
+		},
+		{
+			Name:         "composer-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ComposerConfig),
+			Aliases:      []string{"composerc"},
+			Usage:        composerconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("composer-config", composerconfig.GetDescription(), composerconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 0 {
+					return cliutils.WrongNumberOfArgumentsHandler(c)
+				}
+				return projectconfig.CreateConfigCmd(c, composer.ToolName)
+			},
+		},
+		{
+			Name:            "composer",
+			Flags:           cliutils.GetCommandFlags(cliutils.Composer),
+			Usage:           composerdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("composer", composerdocs.GetDescription(), composerdocs.Usage),
+			UsageText:       composerdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "update", "publish"),
+			Category:        buildToolsCategory,
+			Action:          composerCmd,
+		},
 		{
 			Name:      "docker",
 			Flags:     cliutils.GetCommandFlags(cliutils.Docker),
@@ -373,10 +708,22 @@ func GetCommands() []cli.Command {
 				}
 				return true
 			}(),
-			BashComplete: corecommon.CreateBashCompletionFunc("push", "pull", "scan"),
+			BashComplete: corecommon.CreateBashCompletionFunc("push", "pull", "scan", "sign", "attest", "verify", "copy", "inspect-layers"),
 			Category:     buildToolsCategory,
 			Action:       dockerCmd,
 		},
+		{
+			Name:            "oci",
+			Flags:           cliutils.GetCommandFlags(cliutils.Oci),
+			Usage:           ocidocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("oci", ocidocs.GetDescription(), ocidocs.Usage),
+			UsageText:       ocidocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("push", "pull"),
+			Category:        buildToolsCategory,
+			Action:          ociCmd,
+		},
 		{
 			Name:         "terraform-config",
 			Flags:        cliutils.GetCommandFlags(cliutils.TerraformConfig),
@@ -386,9 +733,7 @@ func GetCommands() []cli.Command {
 			ArgsUsage:    common.CreateEnvVars(),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Category:     buildToolsCategory,
-			Action: func(c *cli.Context) error {
-				return cliutils.CreateConfigCmd(c, project.Terraform)
-			},
+			Action:       terraformConfigCmd,
 		},
 		{
 			Name:            "terraform",
@@ -613,7 +958,7 @@ func DotnetCmd(c *cli.Context) error {
 	if len(filteredDotnetArgs) > 1 {
 		dotnetCmd.SetArgAndFlags(filteredDotnetArgs[1:])
 	}
-	return commands.Exec(dotnetCmd)
+	return commands.Exec(nugetlock.NewDotnetCommand().SetDotnetCommand(dotnetCmd).SetBuildConfiguration(buildConfiguration))
 }
 
 func getNugetAndDotnetConfigFields(configFilePath string) (rtDetails *coreConfig.ServerDetails, targetRepo string, useNugetV2 bool, err error) {
@@ -666,6 +1011,30 @@ func GoCmd(c *cli.Context) error {
 	return commands.Exec(goCommand)
 }
 
+// Exports the module graph of go.sum from the resolver repository to a directory, or imports such a directory to the deployer repository.
+func goModVendorCmd(c *cli.Context, cmdName string) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	configFilePath, err := goCmdVerification(c)
+	if err != nil {
+		return err
+	}
+	_, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	if cmdName == goModVendorExport {
+		exportCmd := gomodules.NewModVendorExportCommand().SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = exportCmd.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(exportCmd)
+	}
+	importCmd := gomodules.NewModVendorImportCommand().SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+	if err = importCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(importCmd)
+}
+
 func GoPublishCmd(c *cli.Context) (err error) {
 	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
 		return err
@@ -725,6 +1094,12 @@ func dockerCmd(c *cli.Context) error {
 		err = pushCmd(c, image)
 	case "scan":
 		return dockerScanCmd(c, image)
+	case "sign", "attest", "verify":
+		return dockerSigningCmd(c, cmd)
+	case "copy":
+		return dockerCopyCmd(c)
+	case "inspect-layers":
+		return dockerInspectLayersCmd(c)
 	default:
 		err = dockerNativeCmd(c)
 	}
@@ -776,6 +1151,13 @@ func pushCmd(c *cli.Context, image string) (err error) {
 		return cliutils.NotSupportedNativeDockerCommand("docker-push")
 	}
 	err = commands.Exec(PushCommand)
+	if err == nil {
+		// Docker pushes an image index, if the image has several platforms or attestations.
+		var repo string
+		if repo, err = PushCommand.GetRepo(); err == nil {
+			_, err = imageindex.CollectBuildInfo(rtDetails, buildConfiguration, repo, image)
+		}
+	}
 	result := PushCommand.Result()
 	defer cliutils.CleanupResult(result, &err)
 	err = cliutils.PrintCommandSummary(PushCommand.Result(), detailedSummary, printDeploymentView, false, err)
@@ -790,6 +1172,118 @@ func dockerScanCmd(c *cli.Context, imageTag string) error {
 	return securityCLI.DockerScan(convertedCtx, imageTag)
 }
 
+// Signs an image, attaches an attestation to it, or verifies them, with the registry API of its Artifactory repository.
+func dockerSigningCmd(c *cli.Context, cmdName string) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, serverDetails, _, _, filteredArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	filteredArgs, keyPath, err := coreutils.ExtractStringOptionFromArgs(filteredArgs, "key")
+	if err != nil {
+		return err
+	}
+	filteredArgs, predicatePath, err := coreutils.ExtractStringOptionFromArgs(filteredArgs, "predicate")
+	if err != nil {
+		return err
+	}
+	filteredArgs, predicateType, err := coreutils.ExtractStringOptionFromArgs(filteredArgs, "type")
+	if err != nil {
+		return err
+	}
+	_, filteredArgs = getCommandName(filteredArgs)
+	if len(filteredArgs) != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if keyPath == "" {
+		return errorutils.CheckErrorf("the --key option is mandatory")
+	}
+	switch cmdName {
+	case "sign":
+		return commands.Exec(oci.NewSignCommand().SetImageReference(filteredArgs[0]).SetKeyPath(keyPath).SetServerDetails(serverDetails).SetBuildConfiguration(buildConfiguration))
+	case "attest":
+		if predicatePath == "" {
+			return errorutils.CheckErrorf("the --predicate option is mandatory")
+		}
+		if predicateType == "" {
+			predicateType = "custom"
+		}
+		attestCmd := oci.NewAttestCommand().SetImageReference(filteredArgs[0]).SetKeyPath(keyPath).SetPredicatePath(predicatePath).SetPredicateType(predicateType).
+			SetServerDetails(serverDetails).SetBuildConfiguration(buildConfiguration)
+		return commands.Exec(attestCmd)
+	default:
+		return commands.Exec(oci.NewVerifyCommand().SetImageReference(filteredArgs[0]).SetKeyPath(keyPath).SetPredicateType(predicateType).SetServerDetails(serverDetails))
+	}
+}
+
+// Copies an image between Docker repositories, which may be on different servers: <server ID>/<repository>/<image>:<tag>.
+func dockerCopyCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, _, detailedSummary, _, filteredArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	_, filteredArgs = getCommandName(filteredArgs)
+	if len(filteredArgs) != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	sourceServerDetails, sourceImage, err := getServerAndImage(filteredArgs[0])
+	if err != nil {
+		return err
+	}
+	targetServerDetails, targetImage, err := getServerAndImage(filteredArgs[1])
+	if err != nil {
+		return err
+	}
+	printDeploymentView := log.IsStdErrTerminal()
+	copyCmd := oci.NewCopyCommand().SetSourceImageReference(sourceImage).SetTargetImageReference(targetImage).
+		SetSourceServerDetails(sourceServerDetails).SetTargetServerDetails(targetServerDetails).
+		SetBuildConfiguration(buildConfiguration).SetDetailedSummary(detailedSummary || printDeploymentView)
+	err = commands.Exec(copyCmd)
+	result := copyCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+	return
+}
+
+// Splits <server ID>/<repository>/<image>:<tag> into the configured server and the image reference.
+func getServerAndImage(arg string) (*coreConfig.ServerDetails, string, error) {
+	serverId, image, found := strings.Cut(arg, "/")
+	if !found || serverId == "" {
+		return nil, "", errorutils.CheckErrorf("the image %s must start with the server ID: <server ID>/<repository>/<image>:<tag>", arg)
+	}
+	serverDetails, err := coreConfig.GetSpecificConfig(serverId, false, true)
+	return serverDetails, image, err
+}
+
+// Lists the layers of an image in Artifactory, or of a local OCI image layout.
+func dockerInspectLayersCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, serverDetails, _, _, filteredArgs, _, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	filteredArgs, format, err := coreutils.ExtractStringOptionFromArgs(filteredArgs, "format")
+	if err != nil {
+		return err
+	}
+	_, filteredArgs = getCommandName(filteredArgs)
+	if len(filteredArgs) != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	inspectCmd := oci.NewInspectLayersCommand().SetSource(filteredArgs[0]).SetServerDetails(serverDetails)
+	if format != "" {
+		inspectCmd.SetFormat(format)
+	}
+	return commands.Exec(inspectCmd)
+}
+
 func dockerNativeCmd(c *cli.Context) error {
 	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
 		return err
@@ -802,6 +1296,39 @@ func dockerNativeCmd(c *cli.Context) error {
 	return cm.RunNativeCmd(cleanArgs)
 }
 
+func ociCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, serverDetails, detailedSummary, _, filteredArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	filteredArgs, mountFrom, err := coreutils.ExtractStringOptionFromArgs(filteredArgs, "mount-from")
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(filteredArgs)
+	if cmdName != "push" && cmdName != "pull" {
+		return errorutils.CheckErrorf("OCI command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
+	}
+	if len(filteredArgs) != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if cmdName == "pull" {
+		pullCmd := oci.NewOciPullCommand().SetImageReference(filteredArgs[0]).SetTarget(filteredArgs[1]).SetServerDetails(serverDetails).SetBuildConfiguration(buildConfiguration)
+		return commands.Exec(pullCmd)
+	}
+	printDeploymentView := log.IsStdErrTerminal()
+	pushCmd := oci.NewOciPushCommand().SetSource(filteredArgs[0]).SetImageReference(filteredArgs[1]).SetMountFrom(mountFrom).
+		SetServerDetails(serverDetails).SetBuildConfiguration(buildConfiguration).SetDetailedSummary(detailedSummary || printDeploymentView)
+	err = commands.Exec(pushCmd)
+	result := pushCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+	return
+}
+
 // Assuming command name is the first argument that isn't a flag.
 // Returns the command name, and the filtered arguments slice without it.
 func getCommandName(orgArgs []string) (string, []string) {
@@ -887,6 +1414,137 @@ func NpmPublishCmd(c *cli.Context) (err error) {
 	return
 }
 
+func PnpmCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := getProjectConfigPathOrThrow(project.Pnpm, "pnpm", "pnpm-config")
+	if err != nil {
+		return err
+	}
+	cmdName, args := getCommandName(c.Args())
+	if cmdName == "publish" || cmdName == "p" {
+		return pnpmPublishCmd(configFilePath, args)
+	}
+	pnpmCmd := pnpm.NewPnpmCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = pnpmCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(pnpmCmd)
+}
+
+func pnpmPublishCmd(configFilePath string, args []string) (err error) {
+	pnpmCmd := pnpm.NewPnpmPublishCommand().SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = pnpmCmd.Init(); err != nil {
+		return err
+	}
+	if pnpmCmd.GetXrayScan() {
+		commandsUtils.ConditionalUploadScanFunc = scan.ConditionalUploadDefaultScanFunc
+	}
+	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), pnpmCmd.IsDetailedSummary()
+	if !detailedSummary {
+		pnpmCmd.SetDetailedSummary(printDeploymentView)
+	}
+	err = commands.Exec(pnpmCmd)
+	result := pnpmCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+	return
+}
+
+func cargoCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(cargo.ToolName)
+	if err != nil {
+		return err
+	}
+	cmdName, args := getCommandName(c.Args())
+	cargoCmd := cargo.NewCargoCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = cargoCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(cargoCmd)
+}
+
+func helmCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(helm.ToolName)
+	if err != nil {
+		return err
+	}
+	cmdName, args := getCommandName(c.Args())
+	switch cmdName {
+	case "package", "dependency", "dep", "dependencies":
+		helmCmd := helm.NewHelmCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+		if err = helmCmd.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(helmCmd)
+	case "push":
+		return helmPushCmd(configFilePath, args, c)
+	default:
+		return errorutils.CheckErrorf("Helm command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
+	}
+}
+
+func helmPushCmd(configFilePath string, args []string, c *cli.Context) error {
+	helmCmd := helm.NewHelmPushCommand().SetConfigFilePath(configFilePath).SetArgs(args)
+	if err := helmCmd.Init(); err != nil {
+		return err
+	}
+	err := commands.Exec(helmCmd)
+	result := helmCmd.Result()
+	return cliutils.PrintBriefSummaryReport(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
+}
+
+func conanCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(conan.ToolName)
+	if err != nil {
+		return err
+	}
+	cmdName, args := getCommandName(c.Args())
+	switch cmdName {
+	case "install", "create", "upload":
+	default:
+		return errorutils.CheckErrorf("Conan command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
+	}
+	conanCmd := conan.NewConanCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = conanCmd.Init(); err != nil {
+		return err
+	}
+	if cmdName != "upload" {
+		return commands.Exec(conanCmd)
+	}
+	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), conanCmd.IsDetailedSummary()
+	if !detailedSummary {
+		conanCmd.SetDetailedSummary(printDeploymentView)
+	}
+	err = commands.Exec(conanCmd)
+	result := conanCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+	return
+}
+
 func GetNpmConfigAndArgs(c *cli.Context) (configFilePath string, args []string, err error) {
 	configFilePath, err = getProjectConfigPathOrThrow(project.Npm, "npm", "npm-config")
 	if err != nil {
@@ -948,6 +1606,234 @@ func pythonCmd(c *cli.Context, projectType project.ProjectType) error {
 	return errorutils.CheckErrorf("%s is not supported", projectType)
 }
 
+func UvCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(uv.ToolName)
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	uvCmd := uv.NewUvCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+	if err = uvCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(uvCmd)
+}
+
+func CondaCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(conda.ToolName)
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	condaCmd := conda.NewCondaCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+	if err = condaCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(condaCmd)
+}
+
+func BazelCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(bazel.ToolName)
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	bazelCmd := bazel.NewBazelCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+	if err = bazelCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(bazelCmd)
+}
+
+func SbtCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(sbt.ToolName)
+	if err != nil {
+		return err
+	}
+	sbtCmd := sbt.NewSbtCommand().SetConfigFilePath(configFilePath).SetArgs(cliutils.ExtractCommand(c))
+	if err = sbtCmd.Init(); err != nil {
+		return err
+	}
+	if !sbtCmd.IsPublish() {
+		return commands.Exec(sbtCmd)
+	}
+	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), sbtCmd.IsDetailedSummary()
+	if !detailedSummary {
+		sbtCmd.SetDetailedSummary(printDeploymentView)
+	}
+	err = commands.Exec(sbtCmd)
+	result := sbtCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+	return
+}
+
+// Runs any command, after '--'. The options of jf exec precede the '--'.
+func ExecCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	args := cliutils.ExtractCommand(c)
+	separator := slices.Index(args, "--")
+	if separator < 0 || separator == len(args)-1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	options, cmdArgs := args[:separator], args[separator+1:]
+	options, buildConfiguration, err := build.ExtractBuildDetailsFromArgs(options)
+	if err != nil {
+		return err
+	}
+	options, serverId, err := coreutils.ExtractServerIdFromCommand(options)
+	if err != nil {
+		return err
+	}
+	options, artifacts, err := coreutils.ExtractStringOptionFromArgs(options, "artifacts")
+	if err != nil {
+		return err
+	}
+	options, target, err := coreutils.ExtractStringOptionFromArgs(options, "target")
+	if err != nil {
+		return err
+	}
+	if len(options) > 0 {
+		return errorutils.CheckErrorf("unknown options: %s", strings.Join(options, " "))
+	}
+	serverDetails, err := coreConfig.GetSpecificConfig(serverId, true, true)
+	if err != nil {
+		return err
+	}
+	execCmd := execute.NewExecCommand().SetArgs(cmdArgs).SetServerDetails(serverDetails).SetBuildConfiguration(buildConfiguration).SetTarget(target)
+	if artifacts != "" {
+		execCmd.SetArtifacts(strings.Split(artifacts, ";"))
+	}
+	return commands.Exec(execCmd)
+}
+
+func BundleCmd(c *cli.Context) error {
+	return rubyCmd(c, "bundle")
+}
+
+func GemCmd(c *cli.Context) error {
+	return rubyCmd(c, "gem")
+}
+
+func rubyCmd(c *cli.Context, executableName string) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(ruby.ToolName)
+	if err != nil {
+		return err
+	}
+	orgArgs := cliutils.ExtractCommand(c)
+	cmdName, filteredArgs := getCommandName(orgArgs)
+	switch executableName {
+	case "bundle":
+		bundleCmd := ruby.NewBundleCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = bundleCmd.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(bundleCmd)
+	case "gem":
+		if cmdName != "push" {
+			return errorutils.CheckErrorf("Gem command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
+		}
+		gemPushCmd := ruby.NewGemPushCommand().SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = gemPushCmd.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(gemPushCmd)
+	}
+	return errorutils.CheckErrorf("%s is not supported", executableName)
+}
+
+func composerCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, err := projectconfig.GetConfigFilePathOrThrow(composer.ToolName)
+	if err != nil {
+		return err
+	}
+	orgArgs := cliutils.ExtractCommand(c)
+	cmdName, filteredArgs := getCommandName(orgArgs)
+	switch cmdName {
+	case "install", "update":
+		composerCommand := composer.NewComposerCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = composerCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(composerCommand)
+	case "publish":
+		publishCmd := composer.NewComposerPublishCommand().SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = publishCmd.Init(); err != nil {
+			return err
+		}
+		printDeploymentView, detailedSummary := log.IsStdErrTerminal(), publishCmd.IsDetailedSummary()
+		if !detailedSummary {
+			publishCmd.SetDetailedSummary(printDeploymentView)
+		}
+		err = commands.Exec(publishCmd)
+		result := publishCmd.Result()
+		defer cliutils.CleanupResult(result, &err)
+		err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+		return
+	}
+	return errorutils.CheckErrorf("Composer command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
+}
+
+// Creates the terraform configuration. The interactive configuration of jfrog-cli-core asks for the deployer only,
+// so the resolver and deployer are asked here, and the file is created with the answers.
+func terraformConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if !projectconfig.IsInteractive(c) {
+		return cliutils.CreateConfigCmd(c, project.Terraform)
+	}
+	resolver, err := projectconfig.AskRepository("Resolve providers and modules from Artifactory?", "Set repository for providers and modules resolution", rtUtils.Virtual, rtUtils.Remote)
+	if err != nil {
+		return err
+	}
+	deployer, err := projectconfig.AskRepository("Deploy project artifacts to Artifactory?", "Set repository for artifacts deployment", rtUtils.Virtual, rtUtils.Local)
+	if err != nil {
+		return err
+	}
+	return commands.CreateBuildConfigWithOptions(c.Bool("global"), project.Terraform,
+		commands.WithResolverServerId(resolver.ServerId), commands.WithResolverRepo(resolver.Repo),
+		commands.WithDeployerServerId(deployer.ServerId), commands.WithDeployerRepo(deployer.Repo))
+}
+
 func terraformCmd(c *cli.Context) error {
 	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
 		return err
@@ -961,6 +1847,12 @@ func terraformCmd(c *cli.Context) error {
 	// Aliases accepted by terraform.
 	case "publish", "p":
 		return terraformPublishCmd(configFilePath, filteredArgs, c)
+	case "init", "plan", "apply", "providers":
+		terraformCmd := terraformcmd.NewTerraformCommand().SetCmdName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = terraformCmd.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(terraformCmd)
 	default:
 		return errorutils.CheckErrorf("Terraform command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
 	}
@@ -1043,5 +1935,12 @@ func getTwineConfigPath() (configFilePath string, err error) {
 			return
 		}
 	}
-	return "", errorutils.CheckErrorf(getMissingConfigErrMsg("twine", "pip-config OR pipenv-config"))
+	// The uv and conda configurations deploy the distributions with twine as well.
+	for _, toolName := range []string{uv.ToolName, conda.ToolName} {
+		configFilePath, exists, err = projectconfig.GetConfigFilePath(toolName)
+		if err != nil || exists {
+			return
+		}
+	}
+	return "", errorutils.CheckErrorf(getMissingConfigErrMsg("twine", "pip-config OR pipenv-config OR uv-config OR conda-config"))
 }
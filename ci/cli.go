@@ -0,0 +1,45 @@
+package ci
+
+import (
+	"encoding/json"
+
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/ci/info"
+	"github.com/jfrog/jfrog-cli/docs/common"
+	ciutils "github.com/jfrog/jfrog-cli/utils/ci"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "info",
+			Usage:        info.GetDescription(),
+			HelpName:     corecommon.CreateUsage("ci info", info.GetDescription(), info.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       infoCmd,
+		},
+	})
+}
+
+func infoCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	// The details are shown even when JFROG_CLI_CI_DETECTION isn't set, so they can be checked before enabling the detection.
+	ciBuild := ciutils.DetectBuild()
+	if ciBuild == nil {
+		log.Output("No supported CI provider was detected.")
+		return nil
+	}
+	content, err := json.MarshalIndent(ciBuild, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(content))
+	return nil
+}
@@ -0,0 +1,81 @@
+package deps
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	depsCommands "github.com/jfrog/jfrog-cli/artifactory/commands/deps"
+	"github.com/jfrog/jfrog-cli/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/deps/exportcmd"
+	"github.com/jfrog/jfrog-cli/docs/deps/importcmd"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/urfave/cli"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "export",
+			Flags:        cliutils.GetCommandFlags(cliutils.DepsExport),
+			Usage:        exportcmd.GetDescription(),
+			HelpName:     corecommon.CreateUsage("deps export", exportcmd.GetDescription(), exportcmd.Usage),
+			UsageText:    exportcmd.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       exportCmd,
+		},
+		{
+			Name:         "import",
+			Flags:        cliutils.GetCommandFlags(cliutils.DepsImport),
+			Usage:        importcmd.GetDescription(),
+			HelpName:     corecommon.CreateUsage("deps import", importcmd.GetDescription(), importcmd.Usage),
+			UsageText:    importcmd.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       importCmd,
+		},
+	})
+}
+
+func exportCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	// The build name may include slashes, so the build number follows the last one.
+	separator := strings.LastIndex(c.String("build"), "/")
+	if separator <= 0 || separator == len(c.String("build"))-1 {
+		return cliutils.PrintHelpAndReturnError("The --build option is mandatory, in the form of <build name>/<build number>.", c)
+	}
+	buildName, buildNumber := c.String("build")[:separator], c.String("build")[separator+1:]
+	serverDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	var repos []string
+	if c.String("repos") != "" {
+		repos = strings.Split(c.String("repos"), ";")
+	}
+	exportCommand := depsCommands.NewExportCommand().SetServerDetails(serverDetails).SetBuild(buildName, buildNumber, c.String("project")).
+		SetRepos(repos).SetArchivePath(c.Args().Get(0))
+	return commands.Exec(exportCommand)
+}
+
+func importCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if !c.IsSet("target-repo-map") {
+		return cliutils.PrintHelpAndReturnError("The --target-repo-map option is mandatory.", c)
+	}
+	repoMap, err := depsCommands.ParseRepoMap(c.String("target-repo-map"))
+	if err != nil {
+		return err
+	}
+	serverDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	importCommand := depsCommands.NewImportCommand().SetServerDetails(serverDetails).SetRepoMap(repoMap).SetArchivePath(c.Args().Get(0))
+	return commands.Exec(importCommand)
+}
@@ -3,7 +3,9 @@ package buildaddgit
 var Usage = []string{"rt bag [command options] <build name> <build number> [Path To .git]"}
 
 func GetDescription() string {
-	return `Collects the Git revision and URL from the local .git directory and adds it to the build-info.`
+	return `Collects the Git revision and URL from the local .git directory and adds it to the build-info.
+If no .git directory is found and JFROG_CLI_CI_DETECTION is set to true, adds the revision and branch exposed by the CI provider instead.
+Optionally adds the pull request metadata exposed by the CI provider and the list of files changed since the latest published build.`
 }
 
 func GetArguments() string {
@@ -1,13 +1,15 @@
 package builddockercreate
 
-var Usage = []string{"rt build-docker-create <target repo> --image-file=<Image file path>"}
+var Usage = []string{"rt build-docker-create <target repo> --image-file=<Image file path> [--image-name=<Image tag>]"}
 
 func GetDescription() string {
-	return "Add a published docker image to the build-info."
+	return "Add published docker images to the build-info."
 }
 
 func GetArguments() string {
 	return `	target repo
-		The repository to which the image was pushed.
+		The repository to which the images were pushed.
+		The image file may be the metadata written by buildx (--metadata-file), Kaniko (--image-name-with-digest-file, --image-name-tag-with-digest-file or --digest-file), Buildah (--digestfile) or ko, and may include several images.
+		Each image must exist in the repository.
 `
 }
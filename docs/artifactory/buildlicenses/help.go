@@ -0,0 +1,15 @@
+package buildlicenses
+
+var Usage = []string{"rt bli [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Create a license inventory of the build dependencies, using the package manifests in the local caches, and optionally evaluate it against a license policy."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
@@ -0,0 +1,7 @@
+package buildslist
+
+var Usage = []string{"rt builds list [command options]"}
+
+func GetDescription() string {
+	return "List the published builds, with their promotion status and history, and their artifact and dependency counts."
+}
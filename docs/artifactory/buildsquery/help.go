@@ -0,0 +1,7 @@
+package buildsquery
+
+var Usage = []string{"rt builds query [command options]"}
+
+func GetDescription() string {
+	return "Search the published builds by build-info property, promotion status, VCS revision or the SHA-256 checksum of an artifact they produced."
+}
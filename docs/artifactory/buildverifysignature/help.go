@@ -0,0 +1,15 @@
+package buildverifysignature
+
+var Usage = []string{"rt bvs [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Verify the signature of a published build-info, which was signed using the 'build-publish --sign-key' command option."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
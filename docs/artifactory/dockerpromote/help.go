@@ -3,7 +3,7 @@ package dockerpromote
 var Usage = []string{"rt docker-promote <source docker image> <source repo> <target repo>"}
 
 func GetDescription() string {
-	return "Promotes a Docker image from one repository to another. Supported by local repositories only."
+	return "Promotes a Docker image from one repository to another. Supported by local repositories only. The manifests of a multi-platform image are promoted along with its tag."
 }
 
 func GetArguments() string {
@@ -1,7 +1,15 @@
 package nuget
 
-var Usage = []string{"rt ndt"}
+var Usage = []string{"rt ndt [command options]"}
 
 func GetDescription() string {
 	return "Show solution dependency tree."
 }
+
+func GetArguments() string {
+	return `	lock files
+		If the projects under the current directory have packages.lock.json files, the dependency graph is read from them.
+		For each project and target framework, the graph includes the resolved packages as nodes, and the version ranges which they request as edges.
+		The requested versions which the lock files don't include are read from the Directory.Packages.props files of central package management.
+		Without lock files, the tree is read from the assets files of the projects, and printed as JSON.`
+}
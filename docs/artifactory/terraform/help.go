@@ -8,5 +8,9 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	terraform commands
-		Arguments and options for the terraform command.`
+		Arguments and options for the terraform command.
+	init | plan | apply | providers
+		Runs the terraform command, which installs the providers and modules from the configured Artifactory Terraform repository.
+	publish
+		Publishes the terraform modules to the configured deployer repository.`
 }
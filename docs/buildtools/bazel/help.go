@@ -0,0 +1,19 @@
+package bazel
+
+var Usage = []string{"bazel <bazel arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run bazel command."
+}
+
+func GetArguments() string {
+	return `	bazel sub-command
+		Arguments and options for the bazel command. The downloads from all the hosts are resolved from the configured resolver repository,
+		under <repository>/<host>/<path>, so the repository must serve the files of every host the build downloads from.
+		The configured deployer repository is the remote cache.
+		The downloads of build, test, run and coverage are collected into the build-info, together with the downloads listed in MODULE.bazel.lock,
+		which are already in the repository cache. Without MODULE.bazel.lock, cached downloads of WORKSPACE rules aren't collected.
+
+	--deploy-outputs
+		[Default: false] Set to true to deploy the outputs of the requested targets to the configured deployer repository, and add them to the build-info.`
+}
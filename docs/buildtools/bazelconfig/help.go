@@ -0,0 +1,7 @@
+package bazelconfig
+
+var Usage = []string{"bazel-config [command options]"}
+
+func GetDescription() string {
+	return "Generate bazel configuration."
+}
@@ -0,0 +1,12 @@
+package bundle
+
+var Usage = []string{"bundle <bundle arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run bundle command."
+}
+
+func GetArguments() string {
+	return `	bundle sub-command
+		Arguments and options for the bundle command. The gems are resolved from the configured Artifactory RubyGems repository, which mirrors rubygems.org.`
+}
@@ -0,0 +1,14 @@
+package cargo
+
+var Usage = []string{"cargo <cargo arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run cargo command."
+}
+
+func GetArguments() string {
+	return `	cargo sub-command
+		Arguments and options for the cargo command. The crates are resolved from the configured Artifactory Cargo repository.
+	publish
+		Publishes the crate to the configured deployer repository.`
+}
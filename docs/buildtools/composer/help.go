@@ -0,0 +1,19 @@
+package composer
+
+var Usage = []string{"composer <composer arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run composer command."
+}
+
+func GetArguments() string {
+	return `	install
+		Installs the packages of the project from the configured resolver Artifactory Composer repository.
+
+	update
+		Updates the packages of the project from the configured resolver Artifactory Composer repository.
+
+	publish
+		Archives the package and deploys it to the configured deployer Artifactory Composer repository.
+		The version is read from composer.json, or from the --version option.`
+}
@@ -0,0 +1,7 @@
+package composerconfig
+
+var Usage = []string{"composer-config [command options]"}
+
+func GetDescription() string {
+	return "Generate composer configuration."
+}
@@ -0,0 +1,14 @@
+package conan
+
+var Usage = []string{"conan <conan arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run conan command."
+}
+
+func GetArguments() string {
+	return `	install | create
+		Arguments and options for the conan command. The recipes and packages are resolved from the configured Artifactory Conan repository, which is added as 'jfrog' to the remotes of a temporary copy of the Conan home, so the remotes of the Conan home aren't changed.
+	upload
+		Uploads the recipes and packages to the configured deployer repository, which is added as 'jfrog-deploy' to the remotes of the temporary Conan home.`
+}
@@ -0,0 +1,7 @@
+package conanconfig
+
+var Usage = []string{"conan-config [command options]"}
+
+func GetDescription() string {
+	return "Generate conan configuration."
+}
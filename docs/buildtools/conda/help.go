@@ -0,0 +1,13 @@
+package conda
+
+var Usage = []string{"conda <conda arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run conda command."
+}
+
+func GetArguments() string {
+	return `	conda sub-command
+		Arguments and options for the conda command. Unless channels are provided, the install, create and update commands
+		resolve the packages from the configured Artifactory Conda repository, and collect the packages of the environment into the build-info.`
+}
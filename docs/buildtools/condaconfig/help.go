@@ -0,0 +1,7 @@
+package condaconfig
+
+var Usage = []string{"conda-config [command options]"}
+
+func GetDescription() string {
+	return "Generate conda configuration."
+}
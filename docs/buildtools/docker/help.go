@@ -1,13 +1,25 @@
 package docker
 
-var Usage = []string{"docker <docker arguments> [command options]"}
+var Usage = []string{"docker <docker arguments> [command options]",
+	"docker sign <repository>/<image>:<tag> --key=<private key> [command options]",
+	"docker attest <repository>/<image>:<tag> --key=<private key> --predicate=<predicate file> [--type=<predicate type>] [command options]",
+	"docker verify <repository>/<image>:<tag> --key=<public key> [--type=<predicate type>] [command options]",
+	"docker copy <source server ID>/<repository>/<image>:<tag> <target server ID>/<repository>/<image>:<tag> [command options]",
+	"docker inspect-layers <repository>/<image>:<tag> | <oci layout> [--format=table|json] [command options]"}
 
 func GetDescription() string {
-	return `Run any docker command, including ‘jf docker scan’ for scanning a local image with Xray.`
+	return `Run any docker command, including ‘jf docker scan’ for scanning a local image with Xray, and ‘jf docker sign’, ‘jf docker attest’ and ‘jf docker verify’ for signing images in Artifactory.`
 }
 
 func GetArguments() string {
 	return `	push                        Run docker push.
 	pull                        Run docker pull.
-	scan                        Scan a local Docker image for security vulnerabilities with JFrog Xray.`
+	scan                        Scan a local Docker image for security vulnerabilities with JFrog Xray.
+	sign                        Sign an image in an Artifactory Docker repository with a local key. The cosign-compatible signature is pushed to the repository as an OCI referrer of the image.
+	attest                      Attach a signed in-toto attestation of the predicate to an image in an Artifactory Docker repository, as an OCI referrer of the image.
+	verify                      Verify the signatures of an image in an Artifactory Docker repository with a public key, and its attestations of the --type predicate type, if set.
+	copy                        Copy an image, or a multi-platform image index, between Artifactory Docker repositories, which may be on different servers, without pulling it locally. Blobs which already exist in the target repository are skipped. The build-info is recorded with the target image.
+	inspect-layers              List the layers of an image in an Artifactory Docker repository, or of a local OCI image layout, with their sizes and the instructions which created them. The layers of an image in Artifactory are searched in the other tags of its repository, to show which layers they share and to suggest a common base image.
+
+	The image references of sign, attest, verify and copy start with the Artifactory repository (after the server ID, for copy), and may reference the image by its digest: <repository>/<image>@sha256:<digest>.`
 }
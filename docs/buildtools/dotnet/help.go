@@ -8,5 +8,8 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	dotnet sub-command
-		 Arguments and options for the dotnet command.`
+		 Arguments and options for the dotnet command.
+		 If the projects under the current directory have packages.lock.json files, the build-info dependencies are collected from the lock files.
+		 The packages are verified against the SHA-512 content hashes of the lock files.
+		 The build-info can't be collected if only some of the projects have lock files.`
 }
@@ -0,0 +1,16 @@
+package exec
+
+var Usage = []string{"exec [command options] -- <command> <arguments>"}
+
+func GetDescription() string {
+	return "Run any command, and collect the files it downloads from Artifactory and the files it creates into the build-info."
+}
+
+func GetArguments() string {
+	return `	command
+		The command to run, and its arguments, after '--'. The command runs with a local proxy in its HTTP_PROXY and HTTPS_PROXY environment variables.
+		The files it downloads from Artifactory through the proxy are added to the build-info as dependencies, with their checksums.
+		To observe the HTTPS downloads, the proxy presents a certificate of a temporary CA for the Artifactory host.
+		The CA is added to the CA bundle of SSL_CERT_FILE, REQUESTS_CA_BUNDLE, CURL_CA_BUNDLE, PIP_CERT, GIT_SSL_CAINFO, CARGO_HTTP_CAINFO and NODE_EXTRA_CA_CERTS.
+		Tools which read their trusted certificates from elsewhere, such as the JVM, need to be configured to trust it.`
+}
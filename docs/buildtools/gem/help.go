@@ -0,0 +1,12 @@
+package gem
+
+var Usage = []string{"gem push <gem> [command options]"}
+
+func GetDescription() string {
+	return "Run gem command."
+}
+
+func GetArguments() string {
+	return `	push
+		Pushes the gem to the configured deployer RubyGems repository.`
+}
@@ -1,6 +1,8 @@
 package gocommand
 
-var Usage = []string{"go <go arguments> [command options]"}
+var Usage = []string{"go <go arguments> [command options]",
+	"go mod-vendor-export <output directory> [command options]",
+	"go mod-vendor-import <exported directory> [command options]"}
 
 func GetDescription() string {
 	return "Runs go."
@@ -8,5 +10,14 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	go commands
-		Arguments and options for the go command.`
+		Arguments and options for the go command.
+
+	mod-vendor-export
+		Downloads every module version of go.sum from the configured resolver repository, verifies it against its go.sum hash,
+		and writes it to <output directory>/cache/download in the GOPROXY layout. The directory can be used offline with GOPROXY=file://<output directory>/cache/download.
+		The module zips are added to the build-info as dependencies.
+
+	mod-vendor-import
+		Uploads the modules of <exported directory>/cache/download to the configured deployer repository, after verifying each module zip against its .ziphash file.
+		The uploaded files are added to the build-info as artifacts.`
 }
@@ -0,0 +1,18 @@
+package helm
+
+var Usage = []string{"helm <helm arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run helm command."
+}
+
+func GetArguments() string {
+	return `	package
+		Packages the chart. The chart dependencies are resolved from the configured Artifactory Helm repository.
+	dependency update | build
+		Resolves the chart dependencies from the configured Artifactory Helm repository, which is available under the 'jfrog' repository name.
+		Chart.yaml isn't modified, so only the dependencies whose repository is '@jfrog' or the URL of the configured repository are resolved from Artifactory.
+		The dependencies of other remote repositories are resolved from them directly, and a warning is logged for each of them.
+	push
+		Pushes the packaged chart to the configured deployer repository, which may be a Helm or an OCI repository.`
+}
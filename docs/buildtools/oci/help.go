@@ -0,0 +1,20 @@
+package oci
+
+var Usage = []string{"oci push <oci layout> <repository>/<image>:<tag> [command options]",
+	"oci pull <repository>/<image>:<tag> <oci layout> [command options]"}
+
+func GetDescription() string {
+	return "Push and pull images to and from an Artifactory Docker repository, without a Docker daemon or client."
+}
+
+func GetArguments() string {
+	return `	push
+		Pushes the image of an OCI image layout, a directory or a tarball, to the Artifactory Docker repository, and tags it.
+		If the layout includes several images, they are pushed as an image index.
+		Use the --mount-from=<image> option to mount the existing blobs of another image in the repository instead of uploading them.
+
+	pull
+		Pulls the image from the Artifactory Docker repository into an OCI image layout directory, or a tarball if the target ends with .tar.
+
+	The image reference starts with the Artifactory repository, and may reference the image by its digest: <repository>/<image>@sha256:<digest>.`
+}
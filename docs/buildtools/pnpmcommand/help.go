@@ -0,0 +1,13 @@
+package pnpmcommand
+
+var Usage = []string{"pnpm <pnpm arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run pnpm command."
+}
+
+func GetArguments() string {
+	return `	install, i, add, update, up  Run pnpm install and collect the dependencies from pnpm-lock.yaml into the build-info.
+	publish, p                   Packs and deploys the npm package to the designated npm repository.
+	help, h`
+}
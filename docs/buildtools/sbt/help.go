@@ -0,0 +1,13 @@
+package sbt
+
+var Usage = []string{"sbt <tasks and options> [command options]"}
+
+func GetDescription() string {
+	return "Run sbt."
+}
+
+func GetArguments() string {
+	return `	tasks and options
+		Tasks and options to run sbt with. The modules are resolved from the configured resolver repository,
+		and the publish task deploys to the configured deployer repository.`
+}
@@ -0,0 +1,14 @@
+package uv
+
+var Usage = []string{"uv <uv arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run uv command."
+}
+
+func GetArguments() string {
+	return `	uv sub-command
+		Arguments and options for the uv command. The packages are resolved from the configured Artifactory PyPI repository.
+		The packages resolved by sync, lock, add and remove are collected from uv.lock into the build-info.
+		To publish the distributions to the configured deployer repository, run 'jf twine upload'.`
+}
@@ -0,0 +1,7 @@
+package info
+
+var Usage = []string{"ci info"}
+
+func GetDescription() string {
+	return "Show the build name, build number, build URL, VCS revision and branch detected from the CI provider running the current job."
+}
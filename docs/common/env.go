@@ -107,6 +107,16 @@ var (
 		The "` + coreutils.GetCliExecutableName() + ` rt build-publish" command uses the value of this environment variable,
 		unless the --build-url command option is sent.`
 
+	JfrogCliCiDetection = `	JFROG_CLI_CI_DETECTION
+		[Default: false]
+		Set to true to detect the build name, build number, build URL, VCS revision and VCS branch from the CI provider running the job.
+		When enabled, commands which collect or publish build-info use the values exposed by GitHub Actions, GitLab CI, Jenkins, Azure Pipelines,
+		CircleCI, Bitbucket Pipelines, Buildkite or TeamCity, unless sent as command arguments or options, set by the JFROG_CLI_BUILD_NAME and JFROG_CLI_BUILD_NUMBER environment variables, or set by the build config file.
+		The build-add-git command collects the detected VCS revision and branch when no .git directory is found.
+		The build-discard command never uses the detected build name.
+		The detection is disabled by default, because commands which support build-info collect it whenever a build name and number are available.
+		Enabling it by default would make every such command running on a CI provider start collecting build-info, which existing pipelines don't expect.`
+
 	JfrogCliEnvExclude = `	JFROG_CLI_ENV_EXCLUDE
 		[Default: *password*;*psw*;*secret*;*key*;*token*;*auth*]
 		List of case insensitive semicolon-separated(;) patterns in the form of "value1;value2;...".
@@ -135,6 +145,7 @@ func GetGlobalEnvVars() string {
 		JfrogCliUploadEmptyArchive,
 		JfrogCliBuildUrl,
 		JfrogCliEnvExclude,
+		JfrogCliCiDetection,
 		JfrogCliFailNoOp,
 		JfrogCliEncryptionKey,
 		JfrogCliAvoidNewVersionWarning,
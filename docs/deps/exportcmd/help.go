@@ -0,0 +1,14 @@
+package exportcmd
+
+var Usage = []string{"deps export --build=<build name>/<build number> [command options] <archive path>"}
+
+func GetDescription() string {
+	return "Export the dependencies of a published build into a portable archive, which can be imported to another Artifactory."
+}
+
+func GetArguments() string {
+	return `	archive path
+		The path of the gzipped tar archive to create. The archive includes a manifest.json file, followed by the files of the dependencies under files/<package type>/<path>.
+		The dependencies are located in Artifactory by their sha1, and keep their paths in the repositories from which they were resolved.
+		Dependencies which are not found in Artifactory are listed in the manifest as missing.`
+}
@@ -0,0 +1,13 @@
+package importcmd
+
+var Usage = []string{"deps import --target-repo-map=<package type>=<repository>[,<package type>=<repository>...] [command options] <archive path>"}
+
+func GetDescription() string {
+	return "Import an archive created by the deps export command, by uploading its dependencies to local repositories."
+}
+
+func GetArguments() string {
+	return `	archive path
+		The path of the archive to import. Each dependency is verified against its sha1, and is uploaded to the repository of its package type, in its original path.
+		The archive is rejected before any upload if it includes package types which are not mapped by --target-repo-map.`
+}
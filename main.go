@@ -15,8 +15,10 @@ import (
 	securityCLI "github.com/jfrog/jfrog-cli-security/cli"
 	"github.com/jfrog/jfrog-cli/artifactory"
 	"github.com/jfrog/jfrog-cli/buildtools"
+	"github.com/jfrog/jfrog-cli/ci"
 	"github.com/jfrog/jfrog-cli/completion"
 	"github.com/jfrog/jfrog-cli/config"
+	"github.com/jfrog/jfrog-cli/deps"
 	"github.com/jfrog/jfrog-cli/distribution"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	aiDocs "github.com/jfrog/jfrog-cli/docs/general/ai"
@@ -244,6 +246,18 @@ func getCommands() ([]cli.Command, error) {
 			Subcommands: plugins.GetCommands(),
 			Category:    commandNamespacesCategory,
 		},
+		{
+			Name:        cliutils.CmdCi,
+			Usage:       "CI environment commands.",
+			Subcommands: ci.GetCommands(),
+			Category:    otherCategory,
+		},
+		{
+			Name:        cliutils.CmdDeps,
+			Usage:       "Export and import the dependencies of builds.",
+			Subcommands: deps.GetCommands(),
+			Category:    commandNamespacesCategory,
+		},
 		{
 			Name:        cliutils.CmdConfig,
 			Aliases:     []string{"c"},
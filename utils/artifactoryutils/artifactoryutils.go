@@ -0,0 +1,38 @@
+// Package artifactoryutils reads from Artifactory through the subsets of the services manager which the commands need.
+package artifactoryutils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs AQL queries. The Artifactory services manager implements it.
+type AqlRunner interface {
+	Aql(aql string) (io.ReadCloser, error)
+}
+
+// Reads files from Artifactory, by their path relative to the Artifactory URL. The Artifactory services manager implements it.
+type RemoteFileReader interface {
+	ReadRemoteFile(readPath string) (io.ReadCloser, error)
+}
+
+// Runs the AQL query, and decodes its result into the result argument.
+func RunAql(runner AqlRunner, query string, result any) (err error) {
+	log.Debug("Searching Artifactory using AQL query:\n", query)
+	stream, err := runner.Aql(query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
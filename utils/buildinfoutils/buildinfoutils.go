@@ -0,0 +1,115 @@
+// Package buildinfoutils saves the build-info collected by the build tool integrations which run the native client of the tool.
+package buildinfoutils
+
+import (
+	"errors"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Saves the dependencies of a module as a partial build-info.
+// The module ID is replaced by the module name of the build configuration, if provided.
+func SaveDependencies(buildConfiguration *build.BuildConfiguration, moduleId string, moduleType buildinfo.ModuleType, dependencies []buildinfo.Dependency) error {
+	return savePartial(buildConfiguration, moduleId, func(partial *buildinfo.Partial) {
+		partial.ModuleType = moduleType
+		partial.Dependencies = dependencies
+	})
+}
+
+// Saves the artifacts of a module as a partial build-info.
+func SaveArtifacts(buildConfiguration *build.BuildConfiguration, moduleId string, moduleType buildinfo.ModuleType, artifacts []buildinfo.Artifact) error {
+	return savePartial(buildConfiguration, moduleId, func(partial *buildinfo.Partial) {
+		partial.ModuleType = moduleType
+		partial.Artifacts = artifacts
+	})
+}
+
+func savePartial(buildConfiguration *build.BuildConfiguration, moduleId string, populatePartial func(partial *buildinfo.Partial)) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	if buildConfiguration.GetModule() != "" {
+		moduleId = buildConfiguration.GetModule()
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+		partial.ModuleId = moduleId
+		populatePartial(partial)
+	})
+}
+
+// Returns the artifacts deployed by the native client of a build tool, and sets the build properties on them if build-info is collected.
+// The paths are relative to the repository, and may include wildcards. Paths which aren't found in the repository are skipped with a warning.
+func GetDeployedArtifacts(serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration, repo string, paths ...string) (artifacts []buildinfo.Artifact, err error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return nil, err
+	}
+	var buildProps string
+	if collectBuildInfo {
+		if buildProps, err = build.CreateBuildPropsFromConfiguration(buildConfiguration); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range paths {
+		searchParams := services.NewSearchParams()
+		searchParams.Pattern = repo + "/" + path
+		reader, err := servicesManager.SearchFiles(searchParams)
+		if err != nil {
+			return nil, err
+		}
+		pathArtifacts, err := readArtifacts(reader)
+		if err != nil {
+			return nil, errors.Join(err, reader.Close())
+		}
+		if len(pathArtifacts) == 0 {
+			log.Warn("The deployed artifact " + repo + "/" + path + " was not found in Artifactory, and therefore is not included in the build-info.")
+			if err = reader.Close(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if buildProps != "" {
+			if _, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: buildProps}); err != nil {
+				return nil, errors.Join(err, reader.Close())
+			}
+		}
+		if err = reader.Close(); err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, pathArtifacts...)
+	}
+	return artifacts, nil
+}
+
+func readArtifacts(reader *content.ContentReader) ([]buildinfo.Artifact, error) {
+	var artifacts []buildinfo.Artifact
+	for item := new(specutils.ResultItem); reader.NextRecord(item) == nil; item = new(specutils.ResultItem) {
+		artifacts = append(artifacts, item.ToArtifact())
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	reader.Reset()
+	return artifacts, nil
+}
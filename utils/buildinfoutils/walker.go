@@ -0,0 +1,89 @@
+package buildinfoutils
+
+import (
+	buildinfo "github.com/jfrog/build-info-go/entities"
+)
+
+// Walks a dependency graph breadth first, and collects the build-info dependencies of its nodes.
+// Each dependency is requested by the shortest path to the root, through each of its dependents:
+// Since the nodes are walked breadth first, the first path through which a dependent requests the dependency is the shortest.
+// The graph may have cycles, since each node is added once, and its children are walked once unless the node is queued again.
+type DependencyWalker[K comparable] struct {
+	newDependency func(key K) buildinfo.Dependency
+	pathId        func(key K, dependency *buildinfo.Dependency) string
+	dependencies  []buildinfo.Dependency
+	indexes       map[K]int
+	requestedBy   map[K]map[string]bool
+	queue         []walkerItem[K]
+}
+
+type walkerItem[K comparable] struct {
+	key        K
+	pathToRoot []string
+}
+
+// The newDependency function returns the dependency of a node, and is called when the node is visited for the first time.
+func NewDependencyWalker[K comparable](newDependency func(key K) buildinfo.Dependency) *DependencyWalker[K] {
+	return &DependencyWalker[K]{
+		newDependency: newDependency,
+		pathId: func(_ K, dependency *buildinfo.Dependency) string {
+			return dependency.Id
+		},
+		indexes:     make(map[K]int),
+		requestedBy: make(map[K]map[string]bool),
+	}
+}
+
+// Sets the ID of a node in the requested-by paths of its children. By default, it's the ID of its dependency.
+func (dw *DependencyWalker[K]) SetPathId(pathId func(key K, dependency *buildinfo.Dependency) string) *DependencyWalker[K] {
+	dw.pathId = pathId
+	return dw
+}
+
+// Visits a node, which is requested by the first node of the path to the root. An empty path means the node is requested by the root itself,
+// and isn't recorded as a requested-by path. The node is added and queued for walking its children on its first visit, which is reported by the returned value.
+func (dw *DependencyWalker[K]) Visit(key K, pathToRoot []string) bool {
+	index, visited := dw.indexes[key]
+	if !visited {
+		index = len(dw.dependencies)
+		dw.indexes[key] = index
+		dw.requestedBy[key] = make(map[string]bool)
+		dw.dependencies = append(dw.dependencies, dw.newDependency(key))
+		dw.Enqueue(key, append([]string{dw.pathId(key, &dw.dependencies[index])}, pathToRoot...))
+	}
+	if len(pathToRoot) > 0 && !dw.requestedBy[key][pathToRoot[0]] {
+		dw.requestedBy[key][pathToRoot[0]] = true
+		dw.dependencies[index].RequestedBy = append(dw.dependencies[index].RequestedBy, pathToRoot)
+	}
+	return !visited
+}
+
+// Queues a node for walking its children, with the path from the node to the root.
+// Visited nodes are queued again when their children change, such as when they are reached through a new scope.
+func (dw *DependencyWalker[K]) Enqueue(key K, pathToRoot []string) {
+	dw.queue = append(dw.queue, walkerItem[K]{key: key, pathToRoot: pathToRoot})
+}
+
+// Walks the queued nodes until the queue is empty. The visitChildren function visits the children of a node,
+// with the path from the node to the root as their path.
+func (dw *DependencyWalker[K]) Walk(visitChildren func(key K, pathToRoot []string)) {
+	for len(dw.queue) > 0 {
+		item := dw.queue[0]
+		dw.queue = dw.queue[1:]
+		visitChildren(item.key, item.pathToRoot)
+	}
+}
+
+// Returns the dependency of a visited node, or nil if the node wasn't visited.
+func (dw *DependencyWalker[K]) GetDependency(key K) *buildinfo.Dependency {
+	index, visited := dw.indexes[key]
+	if !visited {
+		return nil
+	}
+	return &dw.dependencies[index]
+}
+
+// Returns the dependencies of the visited nodes, in the order of their first visit.
+func (dw *DependencyWalker[K]) Dependencies() []buildinfo.Dependency {
+	return dw.dependencies
+}
@@ -0,0 +1,53 @@
+package buildinfoutils
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyWalker(t *testing.T) {
+	// a -> b -> d, a -> c -> d -> b (a cycle between b and d).
+	graph := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"b"},
+	}
+	walker := NewDependencyWalker(func(key string) buildinfo.Dependency {
+		return buildinfo.Dependency{Id: key + ":1.0.0"}
+	})
+	walker.Enqueue("a", []string{"module"})
+	walker.Walk(func(key string, pathToRoot []string) {
+		for _, child := range graph[key] {
+			walker.Visit(child, pathToRoot)
+		}
+	})
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "b:1.0.0", RequestedBy: [][]string{{"module"}, {"d:1.0.0", "b:1.0.0", "module"}}},
+		{Id: "c:1.0.0", RequestedBy: [][]string{{"module"}}},
+		{Id: "d:1.0.0", RequestedBy: [][]string{{"b:1.0.0", "module"}, {"c:1.0.0", "module"}}},
+	}, walker.Dependencies())
+	assert.Nil(t, walker.GetDependency("a"))
+	assert.Equal(t, "c:1.0.0", walker.GetDependency("c").Id)
+}
+
+func TestDependencyWalkerPathId(t *testing.T) {
+	walker := NewDependencyWalker(func(key string) buildinfo.Dependency {
+		return buildinfo.Dependency{Id: key + ":1.0.0#0"}
+	}).SetPathId(func(key string, _ *buildinfo.Dependency) string {
+		return key + ":1.0.0"
+	})
+	// A root which isn't a dependency doesn't request its children.
+	walker.Visit("a", nil)
+	walker.Walk(func(key string, pathToRoot []string) {
+		if key == "a" {
+			walker.Visit("b", pathToRoot)
+		}
+	})
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "a:1.0.0#0"},
+		{Id: "b:1.0.0#0", RequestedBy: [][]string{{"a:1.0.0"}}},
+	}, walker.Dependencies())
+}
@@ -0,0 +1,184 @@
+package ci
+
+import (
+	"os"
+	"strings"
+)
+
+type Provider string
+
+const (
+	GitHubActions      Provider = "GitHub Actions"
+	GitLab             Provider = "GitLab CI"
+	Jenkins            Provider = "Jenkins"
+	AzurePipelines     Provider = "Azure Pipelines"
+	CircleCI           Provider = "CircleCI"
+	BitbucketPipelines Provider = "Bitbucket Pipelines"
+	Buildkite          Provider = "Buildkite"
+	TeamCity           Provider = "TeamCity"
+)
+
+// Build details, as exposed by the CI provider running the current job.
+type BuildDetails struct {
+	Provider    Provider `json:"provider,omitempty"`
+	BuildName   string   `json:"buildName,omitempty"`
+	BuildNumber string   `json:"buildNumber,omitempty"`
+	BuildUrl    string   `json:"buildUrl,omitempty"`
+	VcsRevision string   `json:"vcsRevision,omitempty"`
+	VcsBranch   string   `json:"vcsBranch,omitempty"`
+}
+
+// Returns the CI provider running the current job, or an empty string if the job isn't running on a supported CI provider.
+func DetectProvider() Provider {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return GitHubActions
+	case os.Getenv("GITLAB_CI") == "true":
+		return GitLab
+	case strings.EqualFold(os.Getenv("TF_BUILD"), "true"):
+		return AzurePipelines
+	case os.Getenv("CIRCLECI") == "true":
+		return CircleCI
+	case os.Getenv("BITBUCKET_BUILD_NUMBER") != "":
+		return BitbucketPipelines
+	case os.Getenv("BUILDKITE") == "true":
+		return Buildkite
+	// TeamCity and Jenkins both set BUILD_NUMBER, so they are identified by their own variables.
+	case os.Getenv("TEAMCITY_VERSION") != "":
+		return TeamCity
+	case os.Getenv("JENKINS_URL") != "":
+		return Jenkins
+	}
+	return ""
+}
+
+// Reads the build details from the standard environment variables of the CI provider running the current job.
+// Returns nil if the job isn't running on a supported CI provider.
+func DetectBuild() *BuildDetails {
+	switch DetectProvider() {
+	case GitHubActions:
+		return detectGitHubBuild()
+	case GitLab:
+		return detectGitLabBuild()
+	case AzurePipelines:
+		return detectAzureBuild()
+	case CircleCI:
+		return detectCircleCiBuild()
+	case BitbucketPipelines:
+		return detectBitbucketBuild()
+	case Buildkite:
+		return detectBuildkiteBuild()
+	case TeamCity:
+		return detectTeamCityBuild()
+	case Jenkins:
+		return detectJenkinsBuild()
+	}
+	return nil
+}
+
+func detectGitHubBuild() *BuildDetails {
+	details := &BuildDetails{
+		Provider:    GitHubActions,
+		BuildName:   os.Getenv("GITHUB_WORKFLOW"),
+		BuildNumber: os.Getenv("GITHUB_RUN_NUMBER"),
+		VcsRevision: os.Getenv("GITHUB_SHA"),
+		VcsBranch:   os.Getenv("GITHUB_HEAD_REF"),
+	}
+	// GITHUB_HEAD_REF is set for pull request events only.
+	if details.VcsBranch == "" {
+		details.VcsBranch = os.Getenv("GITHUB_REF_NAME")
+	}
+	serverUrl, repository, runId := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if serverUrl != "" && repository != "" && runId != "" {
+		details.BuildUrl = serverUrl + "/" + repository + "/actions/runs/" + runId
+	}
+	return details
+}
+
+func detectGitLabBuild() *BuildDetails {
+	return &BuildDetails{
+		Provider:    GitLab,
+		BuildName:   os.Getenv("CI_PROJECT_PATH"),
+		BuildNumber: os.Getenv("CI_PIPELINE_IID"),
+		BuildUrl:    os.Getenv("CI_PIPELINE_URL"),
+		VcsRevision: os.Getenv("CI_COMMIT_SHA"),
+		VcsBranch:   os.Getenv("CI_COMMIT_REF_NAME"),
+	}
+}
+
+func detectAzureBuild() *BuildDetails {
+	details := &BuildDetails{
+		Provider:    AzurePipelines,
+		BuildName:   os.Getenv("BUILD_DEFINITIONNAME"),
+		BuildNumber: os.Getenv("BUILD_BUILDNUMBER"),
+		VcsRevision: os.Getenv("BUILD_SOURCEVERSION"),
+		VcsBranch:   trimBranchRef(os.Getenv("BUILD_SOURCEBRANCH")),
+	}
+	collectionUri, teamProject, buildId := os.Getenv("SYSTEM_COLLECTIONURI"), os.Getenv("SYSTEM_TEAMPROJECT"), os.Getenv("BUILD_BUILDID")
+	if collectionUri != "" && teamProject != "" && buildId != "" {
+		details.BuildUrl = strings.TrimSuffix(collectionUri, "/") + "/" + teamProject + "/_build/results?buildId=" + buildId
+	}
+	return details
+}
+
+func detectCircleCiBuild() *BuildDetails {
+	return &BuildDetails{
+		Provider:    CircleCI,
+		BuildName:   os.Getenv("CIRCLE_PROJECT_REPONAME"),
+		BuildNumber: os.Getenv("CIRCLE_BUILD_NUM"),
+		BuildUrl:    os.Getenv("CIRCLE_BUILD_URL"),
+		VcsRevision: os.Getenv("CIRCLE_SHA1"),
+		VcsBranch:   os.Getenv("CIRCLE_BRANCH"),
+	}
+}
+
+func detectBitbucketBuild() *BuildDetails {
+	details := &BuildDetails{
+		Provider:    BitbucketPipelines,
+		BuildName:   os.Getenv("BITBUCKET_REPO_SLUG"),
+		BuildNumber: os.Getenv("BITBUCKET_BUILD_NUMBER"),
+		VcsRevision: os.Getenv("BITBUCKET_COMMIT"),
+		VcsBranch:   os.Getenv("BITBUCKET_BRANCH"),
+	}
+	if repoFullName := os.Getenv("BITBUCKET_REPO_FULL_NAME"); repoFullName != "" {
+		details.BuildUrl = "https://bitbucket.org/" + repoFullName + "/pipelines/results/" + details.BuildNumber
+	}
+	return details
+}
+
+func detectBuildkiteBuild() *BuildDetails {
+	return &BuildDetails{
+		Provider:    Buildkite,
+		BuildName:   os.Getenv("BUILDKITE_PIPELINE_SLUG"),
+		BuildNumber: os.Getenv("BUILDKITE_BUILD_NUMBER"),
+		BuildUrl:    os.Getenv("BUILDKITE_BUILD_URL"),
+		VcsRevision: os.Getenv("BUILDKITE_COMMIT"),
+		VcsBranch:   os.Getenv("BUILDKITE_BRANCH"),
+	}
+}
+
+func detectTeamCityBuild() *BuildDetails {
+	// TeamCity doesn't expose the build URL or the branch as environment variables by default.
+	return &BuildDetails{
+		Provider:    TeamCity,
+		BuildName:   os.Getenv("TEAMCITY_BUILDCONF_NAME"),
+		BuildNumber: os.Getenv("BUILD_NUMBER"),
+		VcsRevision: os.Getenv("BUILD_VCS_NUMBER"),
+	}
+}
+
+func detectJenkinsBuild() *BuildDetails {
+	details := &BuildDetails{
+		Provider:    Jenkins,
+		BuildName:   os.Getenv("JOB_NAME"),
+		BuildNumber: os.Getenv("BUILD_NUMBER"),
+		BuildUrl:    os.Getenv("BUILD_URL"),
+		VcsRevision: os.Getenv("GIT_COMMIT"),
+		VcsBranch:   os.Getenv("BRANCH_NAME"),
+	}
+	// BRANCH_NAME is set by multibranch pipelines only, while GIT_BRANCH is set by the Git plugin.
+	if details.VcsBranch == "" {
+		details.VcsBranch = strings.TrimPrefix(os.Getenv("GIT_BRANCH"), "origin/")
+	}
+	return details
+}
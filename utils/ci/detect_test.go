@@ -0,0 +1,44 @@
+package ci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected *BuildDetails
+	}{
+		{"noCi", map[string]string{}, nil},
+		{"github", map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_WORKFLOW": "release", "GITHUB_RUN_NUMBER": "12", "GITHUB_SERVER_URL": "https://github.com", "GITHUB_REPOSITORY": "jfrog/jfrog-cli", "GITHUB_RUN_ID": "345", "GITHUB_SHA": "abc", "GITHUB_REF_NAME": "main"},
+			&BuildDetails{Provider: GitHubActions, BuildName: "release", BuildNumber: "12", BuildUrl: "https://github.com/jfrog/jfrog-cli/actions/runs/345", VcsRevision: "abc", VcsBranch: "main"}},
+		{"gitlab", map[string]string{"GITLAB_CI": "true", "CI_PROJECT_PATH": "group/project", "CI_PIPELINE_IID": "8", "CI_PIPELINE_URL": "https://gitlab.com/group/project/-/pipelines/100", "CI_COMMIT_SHA": "abc", "CI_COMMIT_REF_NAME": "dev"},
+			&BuildDetails{Provider: GitLab, BuildName: "group/project", BuildNumber: "8", BuildUrl: "https://gitlab.com/group/project/-/pipelines/100", VcsRevision: "abc", VcsBranch: "dev"}},
+		{"azure", map[string]string{"TF_BUILD": "True", "BUILD_DEFINITIONNAME": "pipeline", "BUILD_BUILDNUMBER": "20240101.1", "SYSTEM_COLLECTIONURI": "https://dev.azure.com/org/", "SYSTEM_TEAMPROJECT": "proj", "BUILD_BUILDID": "77", "BUILD_SOURCEVERSION": "abc", "BUILD_SOURCEBRANCH": "refs/heads/main"},
+			&BuildDetails{Provider: AzurePipelines, BuildName: "pipeline", BuildNumber: "20240101.1", BuildUrl: "https://dev.azure.com/org/proj/_build/results?buildId=77", VcsRevision: "abc", VcsBranch: "main"}},
+		{"circleci", map[string]string{"CIRCLECI": "true", "CIRCLE_PROJECT_REPONAME": "repo", "CIRCLE_BUILD_NUM": "5", "CIRCLE_BUILD_URL": "https://circleci.com/gh/org/repo/5", "CIRCLE_SHA1": "abc", "CIRCLE_BRANCH": "main"},
+			&BuildDetails{Provider: CircleCI, BuildName: "repo", BuildNumber: "5", BuildUrl: "https://circleci.com/gh/org/repo/5", VcsRevision: "abc", VcsBranch: "main"}},
+		{"bitbucket", map[string]string{"BITBUCKET_BUILD_NUMBER": "4", "BITBUCKET_REPO_SLUG": "repo", "BITBUCKET_REPO_FULL_NAME": "team/repo", "BITBUCKET_COMMIT": "abc", "BITBUCKET_BRANCH": "main"},
+			&BuildDetails{Provider: BitbucketPipelines, BuildName: "repo", BuildNumber: "4", BuildUrl: "https://bitbucket.org/team/repo/pipelines/results/4", VcsRevision: "abc", VcsBranch: "main"}},
+		{"buildkite", map[string]string{"BUILDKITE": "true", "BUILDKITE_PIPELINE_SLUG": "pipeline", "BUILDKITE_BUILD_NUMBER": "9", "BUILDKITE_BUILD_URL": "https://buildkite.com/org/pipeline/builds/9", "BUILDKITE_COMMIT": "abc", "BUILDKITE_BRANCH": "main"},
+			&BuildDetails{Provider: Buildkite, BuildName: "pipeline", BuildNumber: "9", BuildUrl: "https://buildkite.com/org/pipeline/builds/9", VcsRevision: "abc", VcsBranch: "main"}},
+		{"teamcity", map[string]string{"TEAMCITY_VERSION": "2024.03", "TEAMCITY_BUILDCONF_NAME": "Build", "BUILD_NUMBER": "31", "BUILD_VCS_NUMBER": "abc"},
+			&BuildDetails{Provider: TeamCity, BuildName: "Build", BuildNumber: "31", VcsRevision: "abc"}},
+		{"jenkins", map[string]string{"JENKINS_URL": "http://jenkins", "JOB_NAME": "job", "BUILD_NUMBER": "2", "BUILD_URL": "http://jenkins/job/job/2/", "GIT_COMMIT": "abc", "GIT_BRANCH": "origin/main"},
+			&BuildDetails{Provider: Jenkins, BuildName: "job", BuildNumber: "2", BuildUrl: "http://jenkins/job/job/2/", VcsRevision: "abc", VcsBranch: "main"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, envVar := range providerEnvVars {
+				t.Setenv(envVar, "")
+			}
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			assert.Equal(t, test.expected, DetectBuild())
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package ci
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pull request (or merge request) metadata, as exposed by the CI provider running the current job.
+type PullRequest struct {
+	Provider     Provider
+	Number       string
+	SourceBranch string
+	TargetBranch string
+	Author       string
+}
+
+// Reads the pull request metadata from the environment variables of the current CI job.
+// The metadata is read from the environment only, no network calls are made.
+// Returns nil if the job is not running in the context of a pull request.
+func DetectPullRequest() *PullRequest {
+	var pr *PullRequest
+	switch DetectProvider() {
+	case GitHubActions:
+		pr = detectGitHubPullRequest()
+	case GitLab:
+		pr = detectGitLabMergeRequest()
+	case AzurePipelines:
+		pr = detectAzurePullRequest()
+	case BitbucketPipelines:
+		pr = detectBitbucketPullRequest()
+	case Jenkins:
+		pr = detectJenkinsPullRequest()
+	}
+	if pr == nil || pr.Number == "" {
+		return nil
+	}
+	return pr
+}
+
+func detectGitHubPullRequest() *PullRequest {
+	eventName := os.Getenv("GITHUB_EVENT_NAME")
+	if eventName != "pull_request" && eventName != "pull_request_target" {
+		return nil
+	}
+	pr := &PullRequest{
+		Provider:     GitHubActions,
+		Number:       getGitHubPullRequestNumber(os.Getenv("GITHUB_REF")),
+		SourceBranch: os.Getenv("GITHUB_HEAD_REF"),
+		TargetBranch: os.Getenv("GITHUB_BASE_REF"),
+		Author:       os.Getenv("GITHUB_ACTOR"),
+	}
+	// The event payload is written to the runner's file system, and holds the actual author of the pull request.
+	if event := readGitHubEvent(os.Getenv("GITHUB_EVENT_PATH")); event != nil {
+		if event.PullRequest.Number > 0 {
+			pr.Number = strconv.Itoa(event.PullRequest.Number)
+		}
+		if event.PullRequest.User.Login != "" {
+			pr.Author = event.PullRequest.User.Login
+		}
+	}
+	return pr
+}
+
+// GITHUB_REF is in the form of refs/pull/<number>/merge for pull request events.
+func getGitHubPullRequestNumber(ref string) string {
+	parts := strings.Split(ref, "/")
+	if len(parts) == 4 && parts[0] == "refs" && parts[1] == "pull" {
+		return parts[2]
+	}
+	return ""
+}
+
+type gitHubEvent struct {
+	PullRequest struct {
+		Number int `json:"number,omitempty"`
+		User   struct {
+			Login string `json:"login,omitempty"`
+		} `json:"user,omitempty"`
+	} `json:"pull_request,omitempty"`
+}
+
+func readGitHubEvent(eventPath string) *gitHubEvent {
+	if eventPath == "" {
+		return nil
+	}
+	content, err := os.ReadFile(eventPath)
+	if err != nil {
+		log.Debug("Couldn't read the GitHub event file:", err.Error())
+		return nil
+	}
+	event := new(gitHubEvent)
+	if err = json.Unmarshal(content, event); err != nil {
+		log.Debug("Couldn't parse the GitHub event file:", err.Error())
+		return nil
+	}
+	return event
+}
+
+func detectGitLabMergeRequest() *PullRequest {
+	return &PullRequest{
+		Provider:     GitLab,
+		Number:       os.Getenv("CI_MERGE_REQUEST_IID"),
+		SourceBranch: os.Getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"),
+		TargetBranch: os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"),
+		Author:       os.Getenv("GITLAB_USER_LOGIN"),
+	}
+}
+
+func detectJenkinsPullRequest() *PullRequest {
+	// Set by multibranch pipelines when building a change request.
+	return &PullRequest{
+		Provider:     Jenkins,
+		Number:       os.Getenv("CHANGE_ID"),
+		SourceBranch: os.Getenv("CHANGE_BRANCH"),
+		TargetBranch: os.Getenv("CHANGE_TARGET"),
+		Author:       os.Getenv("CHANGE_AUTHOR"),
+	}
+}
+
+func detectAzurePullRequest() *PullRequest {
+	// Pull requests from GitHub repositories expose their number, while pull requests from Azure Repos expose their ID.
+	number := os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER")
+	if number == "" {
+		number = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTID")
+	}
+	return &PullRequest{
+		Provider:     AzurePipelines,
+		Number:       number,
+		SourceBranch: trimBranchRef(os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH")),
+		TargetBranch: trimBranchRef(os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH")),
+		Author:       os.Getenv("BUILD_REQUESTEDFOR"),
+	}
+}
+
+// Bitbucket Pipelines doesn't expose the pull request author, so it's left empty.
+func detectBitbucketPullRequest() *PullRequest {
+	return &PullRequest{
+		Provider:     BitbucketPipelines,
+		Number:       os.Getenv("BITBUCKET_PR_ID"),
+		SourceBranch: os.Getenv("BITBUCKET_BRANCH"),
+		TargetBranch: os.Getenv("BITBUCKET_PR_DESTINATION_BRANCH"),
+	}
+}
+
+func trimBranchRef(ref string) string {
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
@@ -0,0 +1,64 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Environment variables which identify the CI provider, cleared before each test case.
+var providerEnvVars = []string{"GITHUB_ACTIONS", "GITLAB_CI", "TF_BUILD", "CIRCLECI", "BITBUCKET_BUILD_NUMBER", "BUILDKITE", "TEAMCITY_VERSION", "JENKINS_URL"}
+
+func TestDetectPullRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected *PullRequest
+	}{
+		{"noCi", map[string]string{}, nil},
+		{"githubPush", map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_EVENT_NAME": "push", "GITHUB_REF": "refs/heads/main"}, nil},
+		{"githubPullRequest", map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_EVENT_NAME": "pull_request", "GITHUB_REF": "refs/pull/42/merge", "GITHUB_HEAD_REF": "feature", "GITHUB_BASE_REF": "main", "GITHUB_ACTOR": "octocat"},
+			&PullRequest{Provider: GitHubActions, Number: "42", SourceBranch: "feature", TargetBranch: "main", Author: "octocat"}},
+		{"gitlabMergeRequest", map[string]string{"GITLAB_CI": "true", "CI_MERGE_REQUEST_IID": "7", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature", "CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "main", "GITLAB_USER_LOGIN": "dev"},
+			&PullRequest{Provider: GitLab, Number: "7", SourceBranch: "feature", TargetBranch: "main", Author: "dev"}},
+		{"gitlabBranch", map[string]string{"GITLAB_CI": "true"}, nil},
+		{"jenkinsChangeRequest", map[string]string{"JENKINS_URL": "http://jenkins", "CHANGE_ID": "3", "CHANGE_BRANCH": "PR-3", "CHANGE_TARGET": "master", "CHANGE_AUTHOR": "dev"},
+			&PullRequest{Provider: Jenkins, Number: "3", SourceBranch: "PR-3", TargetBranch: "master", Author: "dev"}},
+		{"azurePullRequest", map[string]string{"TF_BUILD": "True", "SYSTEM_PULLREQUEST_PULLREQUESTID": "15", "SYSTEM_PULLREQUEST_SOURCEBRANCH": "refs/heads/feature", "SYSTEM_PULLREQUEST_TARGETBRANCH": "refs/heads/main", "BUILD_REQUESTEDFOR": "Dev"},
+			&PullRequest{Provider: AzurePipelines, Number: "15", SourceBranch: "feature", TargetBranch: "main", Author: "Dev"}},
+		{"bitbucketPullRequest", map[string]string{"BITBUCKET_BUILD_NUMBER": "10", "BITBUCKET_PR_ID": "5", "BITBUCKET_BRANCH": "feature", "BITBUCKET_PR_DESTINATION_BRANCH": "main"},
+			&PullRequest{Provider: BitbucketPipelines, Number: "5", SourceBranch: "feature", TargetBranch: "main"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, envVar := range providerEnvVars {
+				t.Setenv(envVar, "")
+			}
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			assert.Equal(t, test.expected, DetectPullRequest())
+		})
+	}
+}
+
+func TestDetectGitHubPullRequestFromEvent(t *testing.T) {
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	assert.NoError(t, os.WriteFile(eventPath, []byte(`{"pull_request":{"number":42,"user":{"login":"author"}}}`), 0600))
+	for _, envVar := range providerEnvVars {
+		t.Setenv(envVar, "")
+	}
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_EVENT_NAME", "pull_request_target")
+	t.Setenv("GITHUB_REF", "refs/heads/main")
+	t.Setenv("GITHUB_ACTOR", "actor")
+	t.Setenv("GITHUB_EVENT_PATH", eventPath)
+
+	pr := DetectPullRequest()
+	if assert.NotNil(t, pr) {
+		assert.Equal(t, "42", pr.Number)
+		assert.Equal(t, "author", pr.Author)
+	}
+}
@@ -14,6 +14,8 @@ const (
 	CmdDistribution   = "ds"
 	CmdCompletion     = "completion"
 	CmdPlugin         = "plugin"
+	CmdCi             = "ci"
+	CmdDeps           = "deps"
 	CmdConfig         = "config"
 	CmdOptions        = "options"
 	CmdProject        = "project"
@@ -40,6 +42,7 @@ const (
 	// Env
 	BuildUrl                       = "JFROG_CLI_BUILD_URL"
 	EnvExclude                     = "JFROG_CLI_ENV_EXCLUDE"
+	CiDetection                    = "JFROG_CLI_CI_DETECTION"
 	UserAgent                      = "JFROG_CLI_USER_AGENT"
 	JfrogCliAvoidNewVersionWarning = "JFROG_CLI_AVOID_NEW_VERSION_WARNING"
 )
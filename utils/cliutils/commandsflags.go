@@ -31,6 +31,10 @@ const (
 	BuildScanLegacy        = "build-scan-legacy"
 	BuildPromote           = "build-promote"
 	BuildDiscard           = "build-discard"
+	BuildsList             = "builds-list"
+	BuildsQuery            = "builds-query"
+	BuildVerifySignature   = "build-verify-signature"
+	BuildLicenses          = "build-licenses"
 	BuildAddDependencies   = "build-add-dependencies"
 	BuildAddGit            = "build-add-git"
 	BuildCollectEnv        = "build-collect-env"
@@ -43,6 +47,7 @@ const (
 	Docker                 = "docker"
 	DockerPush             = "docker-push"
 	DockerPull             = "docker-pull"
+	Oci                    = "oci"
 	ContainerPull          = "container-pull"
 	ContainerPush          = "container-push"
 	BuildDockerCreate      = "build-docker-create"
@@ -52,10 +57,23 @@ const (
 	NpmInstallCi           = "npm-install-ci"
 	NpmPublish             = "npm-publish"
 	PnpmConfig             = "pnpm-config"
+	Pnpm                   = "pnpm"
+	CargoConfig            = "cargo-config"
+	Cargo                  = "cargo"
+	HelmConfig             = "helm-config"
+	Helm                   = "helm"
+	ConanConfig            = "conan-config"
+	Conan                  = "conan"
+	GemConfig              = "gem-config"
+	Gem                    = "gem"
+	Bundle                 = "bundle"
+	ComposerConfig         = "composer-config"
+	Composer               = "composer"
 	YarnConfig             = "yarn-config"
 	Yarn                   = "yarn"
 	NugetConfig            = "nuget-config"
 	Nuget                  = "nuget"
+	NugetDepsTree          = "nuget-deps-tree"
 	Dotnet                 = "dotnet"
 	DotnetConfig           = "dotnet-config"
 	Go                     = "go"
@@ -71,6 +89,17 @@ const (
 	PipenvConfig           = "pipenv-config"
 	PipenvInstall          = "pipenv-install"
 	PoetryConfig           = "poetry-config"
+	UvConfig               = "uv-config"
+	Uv                     = "uv"
+	CondaConfig            = "conda-config"
+	Conda                  = "conda"
+	BazelConfig            = "bazel-config"
+	Bazel                  = "bazel"
+	SbtConfig              = "sbt-config"
+	Sbt                    = "sbt"
+	Exec                   = "exec"
+	DepsExport             = "deps-export"
+	DepsImport             = "deps-import"
 	Poetry                 = "poetry"
 	Ping                   = "ping"
 	RtCurl                 = "rt-curl"
@@ -283,6 +312,17 @@ const (
 	envExclude         = "env-exclude"
 	buildUrl           = "build-url"
 	Project            = "project"
+	signKey            = "sign-key"
+
+	// Unique build-verify-signature flags
+	verifyKey = "key"
+
+	// Unique build-licenses flags
+	buildLicensesPrefix = "bli-"
+	bliFromRt           = buildLicensesPrefix + fromRt
+	bliFormat           = buildLicensesPrefix + xrOutput
+	bliFail             = buildLicensesPrefix + fail
+	licensePolicy       = "policy"
 
 	// Unique build-add-dependencies flags
 	badPrefix    = "bad-"
@@ -293,7 +333,9 @@ const (
 	badModule    = badPrefix + module
 
 	// Unique build-add-git flags
-	configFlag = "config"
+	configFlag   = "config"
+	prMetadata   = "pr-metadata"
+	changedFiles = "changed-files"
 
 	// Unique build-scan flags
 	fail   = "fail"
@@ -319,6 +361,17 @@ const (
 	excludeBuilds      = "exclude-builds"
 	deleteArtifacts    = "delete-artifacts"
 
+	// Unique builds list and builds query flags
+	buildsPrefix          = "builds-"
+	buildsName            = buildsPrefix + name
+	buildsLimit           = buildsPrefix + limit
+	buildsFormat          = buildsPrefix + xrOutput
+	since                 = "since"
+	buildsProperty        = "property"
+	buildsPromotionStatus = buildsPrefix + Status
+	vcsRevision           = "vcs-revision"
+	sha256Flag            = "sha256"
+
 	repo = "repo"
 
 	// Unique git-lfs-clean flags
@@ -362,8 +415,26 @@ const (
 	targetTag           = "target-tag"
 	dockerPromoteCopy   = dockerPromotePrefix + Copy
 
+	// Unique docker sign, attest and verify flags
+	dockerSigningPrefix = "docker-signing-"
+	dockerSigningKey    = dockerSigningPrefix + "key"
+	dockerPredicate     = dockerSigningPrefix + "predicate"
+	dockerPredicateType = dockerSigningPrefix + "type"
+
+	// Unique exec flags
+	execPrefix    = "exec-"
+	execArtifacts = execPrefix + "artifacts"
+	execTarget    = execPrefix + "target"
+
+	// Unique deps export and import flags
+	depsPrefix        = "deps-"
+	depsBuild         = depsPrefix + "build"
+	depsRepos         = depsPrefix + "repos"
+	depsTargetRepoMap = depsPrefix + "target-repo-map"
+
 	// Unique build docker create
 	imageFile = "image-file"
+	imageName = "image-name"
 
 	// Unique oc start-build flags
 	ocStartBuildPrefix = "oc-start-build-"
@@ -376,6 +447,10 @@ const (
 	// Unique nuget/dotnet config flags
 	nugetV2 = "nuget-v2"
 
+	// Unique nuget-deps-tree flags
+	nugetDepsTreePrefix = "nuget-deps-tree-"
+	nugetDepsTreeFormat = nugetDepsTreePrefix + xrOutput
+
 	// Unique go flags
 	noFallback = "no-fallback"
 
@@ -990,6 +1065,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  configFlag,
 		Usage: "[Optional] Path to a configuration file.` `",
 	},
+	prMetadata: cli.BoolFlag{
+		Name:  prMetadata,
+		Usage: "[Default: false] Set to true to add the pull request number, source and target branches and author to the build-info. The metadata is read from the environment variables of GitHub Actions, GitLab CI, Jenkins, Azure Pipelines or Bitbucket Pipelines.` `",
+	},
+	changedFiles: cli.BoolFlag{
+		Name:  changedFiles,
+		Usage: "[Default: false] Set to true to add the list of files changed since the VCS revision of the latest published build to the build-info.` `",
+	},
 	fail: cli.BoolTFlag{
 		Name:  fail,
 		Usage: "[Default: true] Set to false if you do not wish the command to return exit code 3, even if the 'Fail Build' rule is matched by Xray.` `",
@@ -1042,6 +1125,38 @@ var flagsMap = map[string]cli.Flag{
 		Name:  "copy",
 		Usage: "[Default: false] If set true, the Docker image is copied to the target repository, otherwise it is moved.` `",
 	},
+	dockerSigningKey: cli.StringFlag{
+		Name:  "key",
+		Usage: "[Mandatory for sign, attest and verify] Path to the private key which signs the image, or to the public key which verifies it. An encrypted cosign key is decrypted with the password in the COSIGN_PASSWORD environment variable.` `",
+	},
+	dockerPredicate: cli.StringFlag{
+		Name:  "predicate",
+		Usage: "[Mandatory for attest] Path to the JSON predicate of the attestation.` `",
+	},
+	dockerPredicateType: cli.StringFlag{
+		Name:  "type",
+		Usage: "[Default for attest: custom] The predicate type of the attestation: slsaprovenance, slsaprovenance1, spdx, spdxjson, cyclonedx, link, vuln, openvex, custom, or a URI. If set for verify, an attestation of this type is verified as well.` `",
+	},
+	execArtifacts: cli.StringFlag{
+		Name:  "artifacts",
+		Usage: "[Optional] Semicolon-separated wildcard patterns of the local files which the command creates. After the command succeeds, the files are uploaded to the target and added to the build-info.` `",
+	},
+	execTarget: cli.StringFlag{
+		Name:  "target",
+		Usage: "[Mandatory with --artifacts] The target path of the artifacts in Artifactory, in the form of <repository>/<path>.` `",
+	},
+	depsBuild: cli.StringFlag{
+		Name:  "build",
+		Usage: "[Mandatory] The published build whose dependencies are exported, in the form of <build name>/<build number>. If the build is assigned to a specific project please provide the project key using the --project flag.` `",
+	},
+	depsRepos: cli.StringFlag{
+		Name:  "repos",
+		Usage: "[Optional] Semicolon-separated list of the repositories from which the dependencies are exported. If not specified, the dependencies are exported from any repository.` `",
+	},
+	depsTargetRepoMap: cli.StringFlag{
+		Name:  "target-repo-map",
+		Usage: "[Mandatory] Comma-separated mapping of the package types of the archive to the local repositories to which their dependencies are uploaded, in the form of <package type>=<repository>. For example: maven=libs-local,npm=npm-local.` `",
+	},
 	maxDays: cli.StringFlag{
 		Name:  maxDays,
 		Usage: "[Optional] The maximum number of days to keep builds in Artifactory.` `",
@@ -1058,6 +1173,66 @@ var flagsMap = map[string]cli.Flag{
 		Name:  deleteArtifacts,
 		Usage: "[Default: false] If set to true, automatically removes build artifacts stored in Artifactory.` `",
 	},
+	signKey: cli.StringFlag{
+		Name:  signKey,
+		Usage: "[Optional] Path to a local Ed25519 or ECDSA private key in PEM format, or an armored GPG private key. If provided, the published build-info is signed, and the signature is stored in the properties of the build-info file in Artifactory. The passphrase of an encrypted GPG key is read from the JFROG_CLI_SIGNING_KEY_PASSPHRASE environment variable.` `",
+	},
+	verifyKey: cli.StringFlag{
+		Name:  verifyKey,
+		Usage: "[Mandatory] Path to a local Ed25519 or ECDSA public key in PEM format, or an armored GPG public key, matching the key used to sign the build-info.` `",
+	},
+	bliFromRt: cli.BoolFlag{
+		Name:  fromRt,
+		Usage: "[Default: false] Set to true to read the build-info published to Artifactory, rather than the local build-info collected by the build commands.` `",
+	},
+	bliFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table, csv, json.` `",
+	},
+	bliFail: cli.BoolTFlag{
+		Name:  fail,
+		Usage: "[Default: true] Set to false if you do not wish the command to fail when the license of a dependency violates the license policy.` `",
+	},
+	licensePolicy: cli.StringFlag{
+		Name:  licensePolicy,
+		Usage: "[Optional] Path to a YAML or JSON file with the allow and deny lists of licenses, in the form of 'allow: [MIT, Apache-2.0]' and 'deny: [GPL-*]'. The licenses may include the * wildcard. For SPDX expressions, such as 'MIT OR GPL-2.0', any allowed alternative of an OR is enough, while all the parts of an AND must be allowed.` `",
+	},
+	buildsName: cli.StringFlag{
+		Name:  name,
+		Usage: "[Optional] Build name pattern. The pattern may include the * wildcard.` `",
+	},
+	buildsLimit: cli.StringFlag{
+		Name:  limit,
+		Usage: "[Default: 50] Maximum number of builds to show, starting from the most recent one.` `",
+	},
+	buildsFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table, json.` `",
+	},
+	nugetDepsTreeFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: json] The output format of the dependency graph read from the packages.lock.json files. Acceptable values are: json, dot, text.` `",
+	},
+	since: cli.StringFlag{
+		Name:  since,
+		Usage: "[Optional] Show only builds published within the specified period. The period is a number followed by one of the units: h, d, w, mo or y. For example: 30d.` `",
+	},
+	buildsProperty: cli.StringFlag{
+		Name:  buildsProperty,
+		Usage: "[Optional] Show only builds with the specified build-info property, in the form of key=value.` `",
+	},
+	buildsPromotionStatus: cli.StringFlag{
+		Name:  Status,
+		Usage: "[Optional] Show only builds whose latest promotion status is the specified status.` `",
+	},
+	vcsRevision: cli.StringFlag{
+		Name:  vcsRevision,
+		Usage: "[Optional] Show only builds of the specified VCS revision. Short revisions are matched by prefix.` `",
+	},
+	sha256Flag: cli.StringFlag{
+		Name:  sha256Flag,
+		Usage: "[Optional] Show only builds which produced an artifact with the specified SHA-256 checksum.` `",
+	},
 	bdiAsync: cli.BoolFlag{
 		Name:  Async,
 		Usage: "[Default: false] If set to true, build discard will run asynchronously and will not wait for response.` `",
@@ -1500,7 +1675,11 @@ var flagsMap = map[string]cli.Flag{
 	},
 	imageFile: cli.StringFlag{
 		Name:  imageFile,
-		Usage: "[Mandatory] Path to a file which includes one line in the following format: <IMAGE-TAG>@sha256:<MANIFEST-SHA256>.` `",
+		Usage: "[Mandatory] Path to a file which includes lines in the following format: <IMAGE-TAG>@sha256:<MANIFEST-SHA256>, such as the files written by Kaniko's --image-name-with-digest-file option and the output of ko. The buildx --metadata-file, and the digest files of Kaniko (--digest-file) and Buildah (--digestfile) are accepted as well.` `",
+	},
+	imageName: cli.StringFlag{
+		Name:  imageName,
+		Usage: "[Optional] The image, in the <IMAGE-TAG> form, to which the digest belongs, if the image file includes only the digest.` `",
 	},
 
 	// Config commands Flags
@@ -1777,7 +1956,14 @@ var commandFlags = map[string][]string{
 	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary,
+		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary, signKey,
+	},
+	BuildVerifySignature: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, Project, verifyKey,
+	},
+	BuildLicenses: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, Project,
+		bliFromRt, bliFormat, bliFail, licensePolicy,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
@@ -1787,14 +1973,14 @@ var commandFlags = map[string][]string{
 		specFlag, specVars, uploadExclusions, badRecursive, badRegexp, badDryRun, Project, badFromRt, serverId, badModule,
 	},
 	BuildAddGit: {
-		configFlag, serverId, Project,
+		configFlag, serverId, Project, prMetadata, changedFiles,
 	},
 	BuildCollectEnv: {
 		Project,
 	},
 	BuildDockerCreate: {
 		buildName, buildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId, imageFile, Project,
+		serverId, imageFile, imageName, Project,
 	},
 	OcStartBuild: {
 		buildName, buildNumber, module, Project, serverId, ocStartBuildRepo,
@@ -1811,6 +1997,14 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, maxDays, maxBuilds,
 		excludeBuilds, deleteArtifacts, bdiAsync, InsecureTls, Project,
 	},
+	BuildsList: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, Project,
+		buildsName, since, buildsLimit, buildsFormat,
+	},
+	BuildsQuery: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, Project,
+		buildsName, since, buildsLimit, buildsFormat, buildsProperty, buildsPromotionStatus, vcsRevision, sha256Flag,
+	},
 	GitLfsClean: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, refs, glcRepo, glcDryRun,
 		glcQuiet, InsecureTls, retries, retryWaitTime,
@@ -1831,6 +2025,7 @@ var commandFlags = map[string][]string{
 	Docker: {
 		buildName, buildNumber, module, Project,
 		serverId, skipLogin, threads, detailedSummary, watches, repoPath, licenses, xrOutput, fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly, vuln,
+		dockerSigningKey, dockerPredicate, dockerPredicateType,
 	},
 	DockerPush: {
 		buildName, buildNumber, module, Project,
@@ -1840,6 +2035,10 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, Project,
 		serverId, skipLogin,
 	},
+	Oci: {
+		buildName, buildNumber, module, Project,
+		serverId, detailedSummary,
+	},
 	DockerPromote: {
 		targetDockerImage, sourceTag, targetTag, dockerPromoteCopy, url, user, password, accessToken, sshPassphrase, sshKeyPath,
 		serverId,
@@ -1862,7 +2061,10 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, Project, npmDetailedSummary, xrayScan, xrOutput,
 	},
 	PnpmConfig: {
-		global, serverIdResolve, repoResolve,
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Pnpm: {
+		buildName, buildNumber, module, Project, npmDetailedSummary, xrayScan, xrOutput,
 	},
 	YarnConfig: {
 		global, serverIdResolve, repoResolve,
@@ -1876,6 +2078,9 @@ var commandFlags = map[string][]string{
 	Nuget: {
 		buildName, buildNumber, module, Project,
 	},
+	NugetDepsTree: {
+		nugetDepsTreeFormat,
+	},
 	DotnetConfig: {
 		global, serverIdResolve, repoResolve, nugetV2,
 	},
@@ -1892,7 +2097,7 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, Project, noFallback,
 	},
 	TerraformConfig: {
-		global, serverIdDeploy, repoDeploy,
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
 	Terraform: {
 		namespace, provider, tag, exclusions,
@@ -1920,6 +2125,39 @@ var commandFlags = map[string][]string{
 	PipInstall: {
 		buildName, buildNumber, module, Project,
 	},
+	CargoConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Cargo: {
+		buildName, buildNumber, module, Project,
+	},
+	HelmConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Helm: {
+		buildName, buildNumber, module, Project,
+	},
+	ConanConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Conan: {
+		buildName, buildNumber, module, Project, detailedSummary,
+	},
+	GemConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Gem: {
+		buildName, buildNumber, module, Project,
+	},
+	Bundle: {
+		buildName, buildNumber, module, Project,
+	},
+	ComposerConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Composer: {
+		buildName, buildNumber, module, Project, detailedSummary,
+	},
 	PipenvConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
@@ -1932,6 +2170,42 @@ var commandFlags = map[string][]string{
 	Poetry: {
 		buildName, buildNumber, module, Project,
 	},
+	UvConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Uv: {
+		buildName, buildNumber, module, Project,
+	},
+	CondaConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Conda: {
+		buildName, buildNumber, module, Project,
+	},
+	BazelConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Bazel: {
+		buildName, buildNumber, module, Project,
+	},
+	SbtConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Sbt: {
+		buildName, buildNumber, module, Project, detailedSummary,
+	},
+	Exec: {
+		buildName, buildNumber, module, Project, serverId, execArtifacts, execTarget,
+	},
+	DepsExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, depsBuild, Project, depsRepos,
+	},
+	DepsImport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, depsTargetRepoMap,
+	},
+
 	ReleaseBundleV1Create: {
 		distUrl, user, password, accessToken, serverId, specFlag, specVars, targetProps,
 		rbDryRun, sign, desc, exclusions, releaseNotesPath, releaseNotesSyntax, rbPassphrase, rbRepo, InsecureTls, distTarget, rbDetailedSummary,
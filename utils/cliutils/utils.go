@@ -28,6 +28,7 @@ import (
 	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/ci"
 	"github.com/jfrog/jfrog-cli/utils/summary"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -353,7 +354,27 @@ func GetBuildName(buildName string) string {
 }
 
 func GetBuildUrl(buildUrl string) string {
-	return getOrDefaultEnv(buildUrl, BuildUrl)
+	if buildUrl = getOrDefaultEnv(buildUrl, BuildUrl); buildUrl != "" {
+		return buildUrl
+	}
+	if ciBuild := DetectCiBuild(); ciBuild != nil {
+		return ciBuild.BuildUrl
+	}
+	return ""
+}
+
+// Returns the build details exposed by the CI provider running the current job.
+// Returns nil if no supported CI provider is detected, or unless the detection is enabled by the JFROG_CLI_CI_DETECTION environment variable.
+func DetectCiBuild() *ci.BuildDetails {
+	enabled, err := clientutils.GetBoolEnvValue(CiDetection, false)
+	if err != nil {
+		log.Warn(err.Error())
+		return nil
+	}
+	if !enabled {
+		return nil
+	}
+	return ci.DetectBuild()
 }
 
 func GetEnvExclude(envExclude string) string {
@@ -610,22 +631,47 @@ func SetCliExecutableName(executablePath string) {
 // Returns build configuration struct using the args (build name/number) and options (project) provided by the user.
 // Any empty configuration could be later overridden by environment variables if set.
 func CreateBuildConfiguration(c *cli.Context) *buildUtils.BuildConfiguration {
-	buildConfiguration := new(buildUtils.BuildConfiguration)
 	buildNameArg, buildNumberArg := c.Args().Get(0), c.Args().Get(1)
 	if buildNameArg == "" || buildNumberArg == "" {
 		buildNameArg = ""
 		buildNumberArg = ""
 	}
-	buildConfiguration.SetBuildName(buildNameArg).SetBuildNumber(buildNumberArg).SetProject(c.String("project")).SetModule(c.String("module"))
+	return newBuildConfiguration(c, buildNameArg, buildNumberArg)
+}
+
+// Returns build configuration struct with the build name and number, and the options (project, module) provided by the user.
+// If neither the build name nor the build number is provided, they're detected from the CI provider, when the detection is enabled.
+func newBuildConfiguration(c *cli.Context, buildName, buildNumber string) *buildUtils.BuildConfiguration {
+	if buildName == "" && buildNumber == "" {
+		buildName, buildNumber = getCiBuildNameAndNumber()
+	}
+	buildConfiguration := new(buildUtils.BuildConfiguration)
+	buildConfiguration.SetBuildName(buildName).SetBuildNumber(buildNumber).SetProject(c.String("project")).SetModule(c.String("module"))
 	return buildConfiguration
 }
 
+// Returns the build name and number detected from the CI provider, if the detection is enabled,
+// and they are not set by environment variables or by the build config file.
+func getCiBuildNameAndNumber() (buildName, buildNumber string) {
+	if os.Getenv(coreutils.BuildName) != "" || os.Getenv(coreutils.BuildNumber) != "" {
+		return
+	}
+	ciBuild := DetectCiBuild()
+	if ciBuild == nil || ciBuild.BuildName == "" || ciBuild.BuildNumber == "" {
+		return
+	}
+	if _, exists, err := project.GetProjectConfFilePath(project.Build); err != nil || exists {
+		return
+	}
+	log.Debug("Using build name '" + ciBuild.BuildName + "' and build number '" + ciBuild.BuildNumber + "' detected from " + string(ciBuild.Provider) + ".")
+	return ciBuild.BuildName, ciBuild.BuildNumber
+}
+
 // Returns build configuration struct using the options provided by the user.
 // Any empty configuration could be later overridden by environment variables if set.
 func CreateBuildConfigurationWithModule(c *cli.Context) (buildConfigConfiguration *buildUtils.BuildConfiguration, err error) {
-	buildConfigConfiguration = new(buildUtils.BuildConfiguration)
-	err = buildConfigConfiguration.SetBuildName(c.String("build-name")).SetBuildNumber(c.String("build-number")).
-		SetProject(c.String("project")).SetModule(c.String("module")).ValidateBuildAndModuleParams()
+	buildConfigConfiguration = newBuildConfiguration(c, c.String("build-name"), c.String("build-number"))
+	err = buildConfigConfiguration.ValidateBuildAndModuleParams()
 	return
 }
 
@@ -2,6 +2,7 @@ package cliutils
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	biutils "github.com/jfrog/build-info-go/utils"
 	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
 	"github.com/jfrog/jfrog-cli/utils/tests"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
 )
 
 func TestSplitAgentNameAndVersion(t *testing.T) {
@@ -140,3 +143,40 @@ func TestShouldCheckLatestCliVersion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, shouldCheck)
 }
+
+func TestGetBuildDetailsFromCi(t *testing.T) {
+	for _, envVar := range []string{coreutils.BuildName, coreutils.BuildNumber, BuildUrl, CiDetection, "GITLAB_CI", "TF_BUILD", "CIRCLECI", "BITBUCKET_BUILD_NUMBER", "BUILDKITE", "TEAMCITY_VERSION", "JENKINS_URL"} {
+		t.Setenv(envVar, "")
+	}
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_WORKFLOW", "release")
+	t.Setenv("GITHUB_RUN_NUMBER", "12")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "jfrog/jfrog-cli")
+	t.Setenv("GITHUB_RUN_ID", "345")
+	context := cli.NewContext(nil, flag.NewFlagSet("test", flag.ContinueOnError), nil)
+
+	// The detection is disabled by default.
+	assert.Empty(t, GetBuildUrl(""))
+	buildName, err := newBuildConfiguration(context, "", "").GetBuildName()
+	assert.NoError(t, err)
+	assert.Empty(t, buildName)
+
+	t.Setenv(CiDetection, "true")
+	// Arguments take precedence over the detected values.
+	assert.Equal(t, "https://ci/1", GetBuildUrl("https://ci/1"))
+	buildName, err = newBuildConfiguration(context, "my-build", "1").GetBuildName()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-build", buildName)
+
+	assert.Equal(t, "https://github.com/jfrog/jfrog-cli/actions/runs/345", GetBuildUrl(""))
+	buildConfiguration := newBuildConfiguration(context, "", "")
+	buildName, err = buildConfiguration.GetBuildName()
+	assert.NoError(t, err)
+	assert.Equal(t, "release", buildName)
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, "12", buildNumber)
+	// The build name of build-discard isn't detected.
+	assert.Empty(t, GetBuildName(""))
+}
@@ -0,0 +1,330 @@
+// Package imageindex collects the build-info of multi-platform images, which are pushed to Artifactory as OCI image indexes
+// or Docker manifest lists. Artifactory stores the index as list.manifest.json in the folder of the tag,
+// and each of the manifests it references in a folder named by its digest (sha256__<hex>), next to the tag folder.
+package imageindex
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/buildinfoutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	IndexFileName = "list.manifest.json"
+
+	attestationsModuleIdPrefix = "attestations"
+	// The annotations of the attestation manifests, which buildx adds to the index.
+	referenceTypeAnnotation   = "vnd.docker.reference.type"
+	referenceDigestAnnotation = "vnd.docker.reference.digest"
+	attestationReferenceType  = "attestation-manifest"
+	unknownPlatform           = "unknown"
+	imageTagProperty          = "docker.image.tag"
+)
+
+type index struct {
+	Manifests []indexManifest `json:"manifests"`
+}
+
+type indexManifest struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Platform    platform          `json:"platform"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	Os           string `json:"os"`
+	Variant      string `json:"variant"`
+}
+
+// A module of a multi-platform image in the build-info.
+type Module struct {
+	Id string
+	// The module of the image index, for the modules of the manifests it references.
+	Parent    string
+	Artifacts []buildinfo.Artifact
+}
+
+func parseIndex(content []byte) (*index, error) {
+	idx := new(index)
+	if err := json.Unmarshal(content, idx); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", IndexFileName, err.Error())
+	}
+	return idx, nil
+}
+
+// Returns <os>/<architecture>[/<variant>], or an empty string if the platform is unknown.
+func (p platform) path() string {
+	if p.Os == "" || p.Architecture == "" || p.Os == unknownPlatform || p.Architecture == unknownPlatform {
+		return ""
+	}
+	return path.Join(p.Os, p.Architecture, p.Variant)
+}
+
+// Returns the module ID of a manifest referenced by the index: <os>/<architecture>[/<variant>]/<base module>.
+// Attestation manifests are placed under attestations/, with the platform of the manifest they refer to.
+func (idx *index) moduleId(manifest indexManifest, baseModuleId string) string {
+	if manifest.Annotations[referenceTypeAnnotation] == attestationReferenceType {
+		platformPath := ""
+		for _, referenced := range idx.Manifests {
+			if referenced.Digest == manifest.Annotations[referenceDigestAnnotation] {
+				platformPath = referenced.Platform.path()
+			}
+		}
+		return path.Join(attestationsModuleIdPrefix, platformPath, baseModuleId)
+	}
+	return path.Join(manifest.Platform.path(), baseModuleId)
+}
+
+func isIndexMediaType(mediaType string) bool {
+	return mediaType == "application/vnd.oci.image.index.v1+json" || mediaType == "application/vnd.docker.distribution.manifest.list.v2+json"
+}
+
+// Returns the folder in which Artifactory stores a manifest referenced by an index.
+func ManifestFolder(imagePath, digest string) string {
+	return path.Join(imagePath, strings.Replace(digest, ":", "__", 1))
+}
+
+// Returns the candidate paths of the tag folder in the repository, for a reverse proxy registry (the image name starts after the host)
+// and for a proxy-less registry (the repository follows the host).
+func tagPathCandidates(imageTag string) ([]string, error) {
+	longName, err := container.NewImage(imageTag).GetImageLongNameWithTag()
+	if err != nil {
+		return nil, err
+	}
+	tagPath := strings.Replace(longName, ":", "/", 1)
+	candidates := []string{tagPath}
+	if _, proxyless, found := strings.Cut(tagPath, "/"); found && strings.Contains(proxyless, "/") {
+		candidates = append(candidates, proxyless)
+	}
+	return candidates, nil
+}
+
+// Searches the image index of an image tag in the repository.
+// Returns the path of the tag folder in the repository and the sha256 of the index, or an empty path if the tag isn't an image index.
+func FindImageIndex(serverDetails *config.ServerDetails, repo, imageTag string) (tagPath, sha256 string, err error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return "", "", err
+	}
+	candidates, err := tagPathCandidates(imageTag)
+	if err != nil {
+		return "", "", err
+	}
+	for _, candidate := range candidates {
+		if sha256, err = searchIndex(servicesManager, repo, candidate); err != nil || sha256 != "" {
+			return candidate, sha256, err
+		}
+	}
+	return "", "", nil
+}
+
+// Returns the sha256 of the image index in the tag folder, or an empty string if the tag isn't an image index.
+func searchIndex(servicesManager artifactory.ArtifactoryServicesManager, repo, tagPath string) (string, error) {
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = path.Join(repo, tagPath, IndexFileName)
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return "", err
+	}
+	item := new(specutils.ResultItem)
+	found := reader.NextRecord(item) == nil
+	if err = errors.Join(reader.GetError(), reader.Close()); err != nil || !found {
+		return "", err
+	}
+	return item.Sha256, nil
+}
+
+// Returns the modules of the image index in the tag folder: a module of the index, and a module of each manifest it references,
+// including the manifests of nested indexes. The base module ID is the module of the build configuration, or defaultModuleId.
+// The build properties are set on the files of the modules, if build-info is collected.
+func GetModules(serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration, repo, tagPath, defaultModuleId string) ([]Module, error) {
+	baseModuleId := buildConfiguration.GetModule()
+	if baseModuleId == "" {
+		baseModuleId = defaultModuleId
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := buildinfoutils.GetDeployedArtifacts(serverDetails, buildConfiguration, repo, tagPath+"/*")
+	if err != nil {
+		return nil, err
+	}
+	modules := []Module{{Id: baseModuleId, Artifacts: artifacts}}
+	walker := &indexWalker{servicesManager: servicesManager, repo: repo, imagePath: path.Dir(tagPath)}
+	err = walker.walk(tagPath, func(idx *index, manifest indexManifest, folder string) error {
+		artifacts, err := buildinfoutils.GetDeployedArtifacts(serverDetails, buildConfiguration, repo, folder+"/*")
+		if err != nil {
+			return err
+		}
+		modules = append(modules, Module{Id: idx.moduleId(manifest, baseModuleId), Parent: baseModuleId, Artifacts: artifacts})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// Returns the folders of the manifests referenced by the image index in the tag folder, including the manifests of nested indexes.
+// Returns nil if the tag isn't an image index.
+func GetManifestFolders(serverDetails *config.ServerDetails, repo, tagPath string) ([]string, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	if sha256, err := searchIndex(servicesManager, repo, tagPath); err != nil || sha256 == "" {
+		return nil, err
+	}
+	var folders []string
+	walker := &indexWalker{servicesManager: servicesManager, repo: repo, imagePath: path.Dir(tagPath)}
+	err = walker.walk(tagPath, func(_ *index, _ indexManifest, folder string) error {
+		folders = append(folders, folder)
+		return nil
+	})
+	return folders, err
+}
+
+// Reads the image indexes in a repository.
+type indexWalker struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	repo            string
+	imagePath       string
+}
+
+// Calls visit with each manifest referenced by the index in the folder, and with the folder of the manifest. Nested indexes are walked after they're visited.
+func (iw *indexWalker) walk(indexFolder string, visit func(idx *index, manifest indexManifest, folder string) error) error {
+	idx, err := iw.readIndex(indexFolder)
+	if err != nil {
+		return err
+	}
+	for _, manifest := range idx.Manifests {
+		folder := ManifestFolder(iw.imagePath, manifest.Digest)
+		if err = visit(idx, manifest, folder); err != nil {
+			return err
+		}
+		if isIndexMediaType(manifest.MediaType) {
+			if err = iw.walk(folder, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (iw *indexWalker) readIndex(folder string) (idx *index, err error) {
+	reader, err := iw.servicesManager.ReadRemoteFile(path.Join(iw.repo, folder, IndexFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseIndex(content)
+}
+
+// Saves the modules to the build-info, as the docker commands save the modules of a multi-platform image:
+// the module of the image index has the docker.image.tag property, and it's the parent of the modules of the manifests.
+// The modules keep their IDs, regardless of the module of the build configuration.
+func SaveModules(buildConfiguration *build.BuildConfiguration, imageTag string, modules []Module) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	buildInfo := new(buildinfo.BuildInfo)
+	for _, module := range modules {
+		buildInfoModule := buildinfo.Module{Id: module.Id, Type: buildinfo.Docker, Parent: module.Parent, Artifacts: module.Artifacts}
+		if module.Parent == "" {
+			buildInfoModule.Properties = map[string]string{imageTagProperty: imageTag}
+		}
+		buildInfo.Modules = append(buildInfo.Modules, buildInfoModule)
+	}
+	return build.SaveBuildInfo(buildName, buildNumber, project, buildInfo)
+}
+
+// Collects the build-info of an image tag which was pushed by the docker client, if it's an image index.
+// Returns false if the image isn't an image index, or if build-info isn't collected.
+func CollectBuildInfo(serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration, repo, imageTag string) (bool, error) {
+	if collect, err := buildConfiguration.IsCollectBuildInfo(); err != nil || !collect {
+		return false, err
+	}
+	tagPath, _, err := FindImageIndex(serverDetails, repo, imageTag)
+	if err != nil || tagPath == "" {
+		return false, err
+	}
+	log.Info("Collecting the build-info of the multi-platform image", imageTag)
+	defaultModuleId, err := container.NewImage(imageTag).GetImageShortNameWithTag()
+	if err != nil {
+		return false, err
+	}
+	modules, err := GetModules(serverDetails, buildConfiguration, repo, tagPath, defaultModuleId)
+	if err != nil {
+		return false, err
+	}
+	return true, SaveModules(buildConfiguration, imageTag, modules)
+}
+
+// Promotes the manifests referenced by an image index, which the promotion of its tag doesn't include.
+// The folders of the manifests are copied or moved next to the target tag, as the tag is.
+func PromoteManifests(serverDetails *config.ServerDetails, params services.DockerPromoteParams, manifestFolders []string) error {
+	if len(manifestFolders) == 0 {
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	targetImage := params.TargetDockerImage
+	if targetImage == "" {
+		targetImage = params.SourceDockerImage
+	}
+	var moveCopyParams []services.MoveCopyParams
+	for _, folder := range manifestFolders {
+		mcp := services.NewMoveCopyParams()
+		mcp.Pattern = path.Join(params.SourceRepo, folder) + "/*"
+		mcp.Target = path.Join(params.TargetRepo, targetImage, path.Base(folder)) + "/"
+		mcp.Flat = true
+		moveCopyParams = append(moveCopyParams, mcp)
+	}
+	promote, action := servicesManager.Move, "move"
+	if params.Copy {
+		promote, action = servicesManager.Copy, "copy"
+	}
+	log.Info("Promoting the", len(manifestFolders), "manifests of the image index...")
+	_, failed, err := promote(moveCopyParams...)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to %s %d files of the manifests of the image index", action, failed)
+	}
+	return nil
+}
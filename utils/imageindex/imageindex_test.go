@@ -0,0 +1,93 @@
+package imageindex
+
+import (
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIndex = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:aaa", "platform": {"architecture": "amd64", "os": "linux"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:bbb", "platform": {"architecture": "arm64", "os": "linux", "variant": "v8"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:ccc", "platform": {"architecture": "arm", "os": "linux", "variant": "v7"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:ddd", "platform": {"architecture": "unknown", "os": "unknown"},
+      "annotations": {"vnd.docker.reference.digest": "sha256:bbb", "vnd.docker.reference.type": "attestation-manifest"}},
+    {"mediaType": "application/vnd.oci.image.index.v1+json", "digest": "sha256:eee"}
+  ]
+}`
+
+func TestModuleId(t *testing.T) {
+	idx, err := parseIndex([]byte(testIndex))
+	require.NoError(t, err)
+	var moduleIds []string
+	for _, manifest := range idx.Manifests {
+		moduleIds = append(moduleIds, idx.moduleId(manifest, "app:1.0"))
+	}
+	assert.Equal(t, []string{
+		"linux/amd64/app:1.0",
+		"linux/arm64/v8/app:1.0",
+		"linux/arm/v7/app:1.0",
+		"attestations/linux/arm64/v8/app:1.0",
+		"app:1.0",
+	}, moduleIds)
+	assert.True(t, isIndexMediaType(idx.Manifests[4].MediaType))
+	assert.Equal(t, "org/app/sha256__bbb", ManifestFolder("org/app", idx.Manifests[1].Digest))
+}
+
+func TestTagPathCandidates(t *testing.T) {
+	tests := []struct {
+		imageTag string
+		expected []string
+	}{
+		{"acme.jfrog.io/docker-local/org/app:1.0", []string{"docker-local/org/app/1.0", "org/app/1.0"}},
+		{"docker-local.acme.jfrog.io/app:1.0", []string{"app/1.0"}},
+		{"acme.jfrog.io/docker-local/app", []string{"docker-local/app/latest", "app/latest"}},
+	}
+	for _, test := range tests {
+		t.Run(test.imageTag, func(t *testing.T) {
+			candidates, err := tagPathCandidates(test.imageTag)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, candidates)
+		})
+	}
+}
+
+// Serves the image indexes in a repository by their paths.
+type testIndexes struct {
+	artifactory.ArtifactoryServicesManager
+	indexes map[string]string
+}
+
+func (ti *testIndexes) ReadRemoteFile(filePath string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(ti.indexes[filePath])), nil
+}
+
+func TestWalkIndex(t *testing.T) {
+	nestedIndex := `{"manifests": [{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:fff"}]}`
+	servicesManager := &testIndexes{indexes: map[string]string{
+		path.Join("docker-local", "org/app/1.0", IndexFileName):         testIndex,
+		path.Join("docker-local", "org/app/sha256__eee", IndexFileName): nestedIndex,
+	}}
+	walker := &indexWalker{servicesManager: servicesManager, repo: "docker-local", imagePath: "org/app"}
+	var folders []string
+	require.NoError(t, walker.walk("org/app/1.0", func(_ *index, _ indexManifest, folder string) error {
+		folders = append(folders, folder)
+		return nil
+	}))
+	assert.Equal(t, []string{
+		"org/app/sha256__aaa",
+		"org/app/sha256__bbb",
+		"org/app/sha256__ccc",
+		"org/app/sha256__ddd",
+		"org/app/sha256__eee",
+		"org/app/sha256__fff",
+	}, folders)
+}
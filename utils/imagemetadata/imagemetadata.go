@@ -0,0 +1,197 @@
+// Package imagemetadata reads the files in which image build tools record the digests of the images they pushed:
+// the buildx --metadata-file (of a build or of a bake), the Kaniko --image-name-with-digest-file and --image-name-tag-with-digest-file,
+// the image references printed by ko, and the digest-only files of Kaniko (--digest-file) and Buildah (--digestfile).
+package imagemetadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/artifactoryutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	buildxImageNameKey = "image.name"
+	buildxDigestKey    = "containerimage.digest"
+)
+
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// An image pushed by a build tool, and the digest of its manifest or index.
+type Image struct {
+	Name   string
+	Digest string
+}
+
+// Returns the image in the <image>@sha256:<digest> form of the image files of build-docker-create.
+func (img Image) String() string {
+	return img.Name + "@" + img.Digest
+}
+
+// Reads the images of a metadata file. The image name is used for the digest-only files, which don't include it,
+// and for buildx metadata without an image name.
+func ReadFile(filePath, imageName string) ([]Image, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the image file %s: %s", filePath, err.Error())
+	}
+	images, err := parse(content, imageName)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("unexpected format of the image file %s: %s", filePath, err.Error())
+	}
+	if len(images) == 0 {
+		return nil, errorutils.CheckErrorf("the image file %s doesn't include any image", filePath)
+	}
+	return images, nil
+}
+
+func parse(content []byte, imageName string) ([]Image, error) {
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "{") {
+		return parseBuildxMetadata([]byte(trimmed), imageName)
+	}
+	var images []Image
+	for _, line := range strings.Split(trimmed, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		name, digest := imageName, line
+		if separator := strings.LastIndex(line, "@"); separator >= 0 {
+			name, digest = line[:separator], line[separator+1:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("the file includes only the digest %s. Please provide the name of the image with the --image-name option", digest)
+		}
+		image, err := newImage(name, digest)
+		if err != nil {
+			return nil, err
+		}
+		images = appendImage(images, image)
+	}
+	return images, nil
+}
+
+// Parses the metadata of 'docker buildx build', or of 'docker buildx bake', which maps each target to its build metadata.
+func parseBuildxMetadata(content []byte, imageName string) ([]Image, error) {
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, err
+	}
+	if _, isBuild := metadata[buildxDigestKey]; isBuild {
+		return parseBuildxBuildMetadata(content, imageName)
+	}
+	targets := make([]string, 0, len(metadata))
+	for target := range metadata {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	var images []Image
+	for _, target := range targets {
+		targetImages, err := parseBuildxBuildMetadata(metadata[target], "")
+		if err != nil {
+			return nil, fmt.Errorf("target %s: %w", target, err)
+		}
+		for _, image := range targetImages {
+			images = appendImage(images, image)
+		}
+	}
+	return images, nil
+}
+
+// Parses the metadata of a single build. The image name may include several comma-separated names, which were pushed with the same digest.
+func parseBuildxBuildMetadata(content []byte, imageName string) ([]Image, error) {
+	var metadata map[string]any
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		// Bake metadata also includes entries which aren't targets, such as the build warnings.
+		return nil, nil
+	}
+	digest, _ := metadata[buildxDigestKey].(string)
+	if digest == "" {
+		// The metadata of a target which wasn't pushed.
+		return nil, nil
+	}
+	names, _ := metadata[buildxImageNameKey].(string)
+	if names == "" {
+		names = imageName
+	}
+	if names == "" {
+		return nil, fmt.Errorf("the metadata doesn't include the image name of the digest %s. Please provide the name of the image with the --image-name option", digest)
+	}
+	var images []Image
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		image, err := newImage(name, digest)
+		if err != nil {
+			return nil, err
+		}
+		images = appendImage(images, image)
+	}
+	return images, nil
+}
+
+func newImage(name, digest string) (Image, error) {
+	if !digestPattern.MatchString(digest) {
+		return Image{}, fmt.Errorf("invalid digest '%s' of the image %s", digest, name)
+	}
+	return Image{Name: name, Digest: digest}, nil
+}
+
+func appendImage(images []Image, image Image) []Image {
+	for _, existing := range images {
+		if existing == image {
+			return images
+		}
+	}
+	return append(images, image)
+}
+
+// Verifies that the manifest or the index of each image exists in the repository, before the image is recorded in the build-info.
+// Artifactory stores them as files whose sha256 is the digest of the image.
+func ValidateDigests(serverDetails *config.ServerDetails, repo string, images []Image) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, image := range images {
+		exists, err := digestExists(servicesManager, repo, image.Digest)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			missing = append(missing, image.String())
+		}
+	}
+	if len(missing) > 0 {
+		return errorutils.CheckErrorf("the following images were not found in the %s repository: %s", repo, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func digestExists(servicesManager artifactory.ArtifactoryServicesManager, repo, digest string) (bool, error) {
+	criteria, err := json.Marshal(map[string]any{
+		"repo":   repo,
+		"type":   "file",
+		"sha256": strings.TrimPrefix(digest, "sha256:"),
+		"$or":    []map[string]string{{"name": "manifest.json"}, {"name": "list.manifest.json"}},
+	})
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = artifactoryutils.RunAql(servicesManager, fmt.Sprintf(`items.find(%s).include("path","name").limit(1)`, criteria), result); err != nil {
+		return false, err
+	}
+	return len(result.Results) > 0, nil
+}
@@ -0,0 +1,50 @@
+package imagemetadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDigest      = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	otherTestDigest = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		content   string
+		imageName string
+		expected  []Image
+	}{
+		{"kaniko image name with digest", "my.jfrog.io/docker-local/app@" + testDigest + "\n", "", []Image{{"my.jfrog.io/docker-local/app", testDigest}}},
+		{"kaniko several destinations", "my.jfrog.io/docker-local/app:1.0@" + testDigest + "\nmy.jfrog.io/docker-local/app:latest@" + testDigest + "\n", "",
+			[]Image{{"my.jfrog.io/docker-local/app:1.0", testDigest}, {"my.jfrog.io/docker-local/app:latest", testDigest}}},
+		{"ko", "my.jfrog.io/docker-local/app@" + testDigest + "\nmy.jfrog.io/docker-local/worker@" + otherTestDigest, "",
+			[]Image{{"my.jfrog.io/docker-local/app", testDigest}, {"my.jfrog.io/docker-local/worker", otherTestDigest}}},
+		{"kaniko or buildah digest file", testDigest, "my.jfrog.io/docker-local/app:1.0", []Image{{"my.jfrog.io/docker-local/app:1.0", testDigest}}},
+		{"buildx build", `{"buildx.build.ref":"builder/builder0/abc","containerimage.digest":"` + testDigest + `","image.name":"my.jfrog.io/docker-local/app:1.0,my.jfrog.io/docker-local/app:latest"}`, "",
+			[]Image{{"my.jfrog.io/docker-local/app:1.0", testDigest}, {"my.jfrog.io/docker-local/app:latest", testDigest}}},
+		{"buildx bake", `{"buildx.build.warnings":[],"worker":{"containerimage.digest":"` + otherTestDigest + `","image.name":"my.jfrog.io/docker-local/worker:1.0"},"app":{"containerimage.digest":"` + testDigest + `","image.name":"my.jfrog.io/docker-local/app:1.0"},"test":{"buildx.build.ref":"builder/builder0/def"}}`, "",
+			[]Image{{"my.jfrog.io/docker-local/app:1.0", testDigest}, {"my.jfrog.io/docker-local/worker:1.0", otherTestDigest}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			images, err := parse([]byte(testCase.content), testCase.imageName)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, images)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	// A digest-only file requires the image name.
+	_, err := parse([]byte(testDigest), "")
+	assert.ErrorContains(t, err, "--image-name")
+	_, err = parse([]byte("my.jfrog.io/docker-local/app@sha256:123"), "")
+	assert.ErrorContains(t, err, "invalid digest")
+	_, err = parse([]byte(`{"containerimage.digest":"`+testDigest+`"}`), "")
+	assert.ErrorContains(t, err, "--image-name")
+}
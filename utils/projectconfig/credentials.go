@@ -0,0 +1,24 @@
+package projectconfig
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Returns the username and password which the build tools authenticate to the server with.
+// When only an access token is configured, the token is the password, and the username is extracted from the token.
+// The password is empty if the server has no credentials.
+func GetCredentials(serverDetails *config.ServerDetails) (username, password string, err error) {
+	username, password = serverDetails.GetUser(), serverDetails.GetPassword()
+	switch {
+	case serverDetails.GetAccessToken() != "" && password == "":
+		password = serverDetails.GetAccessToken()
+		if username == "" {
+			username = auth.ExtractUsernameFromAccessToken(password)
+		}
+	case serverDetails.SshKeyPath != "":
+		return "", "", errorutils.CheckErrorf("SSH authentication is not supported in this command")
+	}
+	return username, password, nil
+}
@@ -0,0 +1,29 @@
+package projectconfig
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCredentials(t *testing.T) {
+	username, password, err := GetCredentials(&config.ServerDetails{User: "user", Password: "pass", AccessToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+
+	// The access token is the password when no password is configured.
+	username, password, err = GetCredentials(&config.ServerDetails{User: "user", AccessToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "token", password)
+
+	_, password, err = GetCredentials(&config.ServerDetails{})
+	require.NoError(t, err)
+	assert.Empty(t, password)
+
+	_, _, err = GetCredentials(&config.ServerDetails{SshKeyPath: "/home/user/.ssh/id_rsa"})
+	assert.Error(t, err)
+}
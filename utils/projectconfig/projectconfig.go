@@ -0,0 +1,211 @@
+// Package projectconfig manages the configuration files of the build tools which aren't included in the project types of jfrog-cli-core.
+// The files are written in the same format and location as the files of the core project types.
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	global             = "global"
+	resolutionServerId = "server-id-resolve"
+	deploymentServerId = "server-id-deploy"
+	resolutionRepo     = "repo-resolve"
+	deploymentRepo     = "repo-deploy"
+)
+
+// Creates the .jfrog/projects/<tool>.yaml configuration file from the command flags.
+// Unlike the configuration commands of the core project types, the repositories must be provided by flags.
+func CreateConfigCmd(c *cli.Context, toolName string) error {
+	configFile := &commands.ConfigFile{
+		Version:    commands.BuildConfVersion,
+		ConfigType: toolName,
+		Resolver:   project.Repository{ServerId: c.String(resolutionServerId), Repo: c.String(resolutionRepo)},
+		Deployer:   project.Repository{ServerId: c.String(deploymentServerId), Repo: c.String(deploymentRepo)},
+	}
+	if configFile.Resolver.Repo == "" && configFile.Deployer.Repo == "" {
+		return errorutils.CheckErrorf("at least one of the --%s and --%s options should be provided", resolutionRepo, deploymentRepo)
+	}
+	return CreateConfigFile(configFile, c.Bool(global))
+}
+
+// Returns true if the configuration should be created interactively, which is when none of the server and repository options
+// was provided, and the command doesn't run in a CI.
+func IsInteractive(c *cli.Context) bool {
+	if strings.ToLower(os.Getenv(coreutils.CI)) == "true" {
+		return false
+	}
+	for _, flagName := range []string{resolutionServerId, deploymentServerId, resolutionRepo, deploymentRepo} {
+		if c.IsSet(flagName) {
+			return false
+		}
+	}
+	return true
+}
+
+// Asks whether to use Artifactory, and if so, asks for the server ID and for the repository.
+// An empty repository is returned if the user chooses not to use Artifactory.
+func AskRepository(useArtifactoryQuestion, repoPrompt string, repoTypes ...utils.RepoType) (repository project.Repository, err error) {
+	serverConfigs, err := config.GetAllServersConfigs()
+	if err != nil {
+		return
+	}
+	if len(serverConfigs) == 0 {
+		return repository, errorutils.CheckErrorf("no Artifactory servers are configured. Use the 'jf c add' command to set the Artifactory server details")
+	}
+	if !coreutils.AskYesNo(useArtifactoryQuestion, true) {
+		return
+	}
+	var serverIds []string
+	var defaultServerId string
+	for _, serverConfig := range serverConfigs {
+		serverIds = append(serverIds, serverConfig.ServerId)
+		if serverConfig.IsDefault {
+			defaultServerId = serverConfig.ServerId
+		}
+	}
+	repository.ServerId = ioutils.AskFromList("", "Set Artifactory server ID", false, ioutils.ConvertToSuggests(serverIds), defaultServerId)
+	repos, err := getRepositories(repository.ServerId, repoTypes...)
+	if err != nil {
+		log.Error("failed getting repositories list: " + err.Error())
+		// Continue without auto complete.
+		repos = nil
+	}
+	if len(repos) > 0 {
+		repository.Repo = ioutils.AskFromListWithMismatchConfirmation(repoPrompt, "Repository not found.", ioutils.ConvertToSuggests(repos))
+	} else {
+		repository.Repo = ioutils.AskString("", repoPrompt, false, false)
+	}
+	return repository, nil
+}
+
+// Returns the names of the repositories of the types in the server.
+func getRepositories(serverId string, repoTypes ...utils.RepoType) ([]string, error) {
+	serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+	if err != nil {
+		return nil, err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	var repos []string
+	for _, repoType := range repoTypes {
+		typeRepos, err := utils.GetFilteredRepositoriesWithFilterParams(servicesManager, nil, nil, services.RepositoriesFilterParams{RepoType: repoType.String()})
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, typeRepos...)
+	}
+	return repos, nil
+}
+
+// Writes the configuration file into the project directory, or into the JFrog home directory if global is true.
+func CreateConfigFile(configFile *commands.ConfigFile, global bool) error {
+	if err := setDefaultServerId(&configFile.Resolver, configFile.ConfigType, "resolution"); err != nil {
+		return err
+	}
+	if err := setDefaultServerId(&configFile.Deployer, configFile.ConfigType, "deployment"); err != nil {
+		return err
+	}
+	projectDir, err := utils.GetProjectDir(global)
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(configFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(filepath.Join(projectDir, configFile.ConfigType+".yaml"), content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(configFile.ConfigType + " build config successfully created.")
+	return nil
+}
+
+// If a repository was provided without a server ID, the server ID is taken from the JFROG_CLI_SERVER_ID environment variable,
+// or from the default server configuration.
+func setDefaultServerId(repository *project.Repository, toolName, repositoryType string) error {
+	if repository.Repo == "" || repository.ServerId != "" {
+		return nil
+	}
+	repository.ServerId = os.Getenv(coreutils.ServerID)
+	if repository.ServerId != "" {
+		return nil
+	}
+	defaultServerDetails, err := config.GetDefaultServerConf()
+	if err != nil {
+		return err
+	}
+	if defaultServerDetails == nil {
+		return errorutils.CheckErrorf("the %s server ID of the %s configuration must be set. Use the --server-id-resolve/deploy option or configure a default server using the 'jf c add' and 'jf c use' commands", repositoryType, toolName)
+	}
+	repository.ServerId = defaultServerDetails.ServerId
+	return nil
+}
+
+// Returns the path of the configuration file of the tool, in the project directory or in one of its parent directories.
+// If no project configuration exists, the global configuration in the JFrog home directory is returned.
+func GetConfigFilePath(toolName string) (configFilePath string, exists bool, err error) {
+	configFileName := filepath.Join("projects", toolName+".yaml")
+	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil {
+		return
+	}
+	if exists {
+		configFilePath = filepath.Join(projectDir, ".jfrog", configFileName)
+		if exists, err = fileutils.IsFileExists(configFilePath, false); err != nil || exists {
+			return
+		}
+	}
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return
+	}
+	configFilePath = filepath.Join(jfrogHomeDir, configFileName)
+	if exists, err = fileutils.IsFileExists(configFilePath, false); err != nil || !exists {
+		configFilePath = ""
+	}
+	return
+}
+
+func GetConfigFilePathOrThrow(toolName string) (string, error) {
+	configFilePath, exists, err := GetConfigFilePath(toolName)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errorutils.CheckErrorf("no config file was found! Before running the 'jf %s' command on a project for the first time, the project should be configured with the 'jf %s-config' command", toolName, toolName)
+	}
+	return configFilePath, nil
+}
+
+// Returns the resolver or deployer repository configuration, or nil if it's missing from the configuration file.
+func GetRepoConfig(configFilePath, prefix string) (*project.RepositoryConfig, error) {
+	vConfig, err := project.ReadConfigFile(configFilePath, project.YAML)
+	if err != nil {
+		return nil, err
+	}
+	if !vConfig.IsSet(prefix) {
+		return nil, nil
+	}
+	return project.GetRepoConfigByPrefix(configFilePath, prefix, vConfig)
+}
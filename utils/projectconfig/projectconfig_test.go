@@ -0,0 +1,48 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientTestUtils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateConfigFile(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	projectDir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallback := clientTestUtils.ChangeDirWithCallback(t, wd, projectDir)
+	defer chdirCallback()
+
+	_, exists, err := GetConfigFilePath("cargo")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = GetConfigFilePathOrThrow("cargo")
+	assert.ErrorContains(t, err, "'jf cargo-config'")
+
+	configFile := &commands.ConfigFile{
+		Version:    commands.BuildConfVersion,
+		ConfigType: "cargo",
+		Resolver:   project.Repository{ServerId: "server", Repo: "cargo-virtual"},
+	}
+	require.NoError(t, CreateConfigFile(configFile, false))
+	configFilePath, exists, err := GetConfigFilePath("cargo")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, filepath.Join(projectDir, ".jfrog", "projects", "cargo.yaml"), configFilePath)
+	content, err := os.ReadFile(configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1\ntype: cargo\nresolver:\n  repo: cargo-virtual\n  serverId: server\n", string(content))
+
+	// The missing deployer isn't an error.
+	deployerConfig, err := GetRepoConfig(configFilePath, project.ProjectConfigDeployerPrefix)
+	assert.NoError(t, err)
+	assert.Nil(t, deployerConfig)
+}